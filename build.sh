@@ -1,5 +1,10 @@
-go mod tidy
-go build ./...
-go test ./...
-go fmt ./...
-golangci-lint run ./...
+for dir in $(find . -name go.mod -exec dirname {} \;); do
+    (
+        cd "$dir" || exit 1
+        go mod tidy
+        go build ./...
+        go test ./...
+        go fmt ./...
+        golangci-lint run ./...
+    )
+done
@@ -0,0 +1,152 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Aggregate collects the failures of the individual items of a batch operation,
+// keyed by the index or ID of the failed item. It can contain any mix of user
+// errors, system errors and other errors.
+//
+//	Example:
+//	   agg := &fault.Aggregate{}
+//	   for i, row := range rows {
+//	      agg.Add(i, importRow(row))
+//	   }
+//	   return agg.ErrorOrNil()
+type Aggregate struct {
+	entries []aggregateEntry
+}
+
+type aggregateEntry struct {
+	index int
+	id    string
+	byID  bool
+	err   error
+}
+
+func (e aggregateEntry) key() string {
+	if e.byID {
+		return e.id
+	}
+	return strconv.Itoa(e.index)
+}
+
+// Add records the failure of the item at the given index.
+// Nothing will be recorded if the error is nil.
+func (a *Aggregate) Add(index int, err error) {
+	if err == nil {
+		return
+	}
+	a.entries = append(a.entries, aggregateEntry{index: index, err: err})
+}
+
+// AddID records the failure of the item with the given ID.
+// Nothing will be recorded if the error is nil.
+func (a *Aggregate) AddID(id string, err error) {
+	if err == nil {
+		return
+	}
+	a.entries = append(a.entries, aggregateEntry{id: id, byID: true, err: err})
+}
+
+// Len returns the number of failed items.
+func (a *Aggregate) Len() int {
+	return len(a.entries)
+}
+
+// Failed returns the failures of the items which have been added by index.
+func (a *Aggregate) Failed() map[int]error {
+	failed := map[int]error{}
+	for _, e := range a.entries {
+		if !e.byID {
+			failed[e.index] = e.err
+		}
+	}
+	return failed
+}
+
+// FailedIDs returns the failures of the items which have been added by ID.
+func (a *Aggregate) FailedIDs() map[string]error {
+	failed := map[string]error{}
+	for _, e := range a.entries {
+		if e.byID {
+			failed[e.id] = e.err
+		}
+	}
+	return failed
+}
+
+// Keys returns the keys of the failed items in the order in which they were added,
+// which are their indices formatted as decimal numbers or their IDs.
+func (a *Aggregate) Keys() []string {
+	keys := make([]string, len(a.entries))
+	for i, e := range a.entries {
+		keys[i] = e.key()
+	}
+	return keys
+}
+
+// ErrorOrNil returns the Aggregate if any failure has been recorded, or nil otherwise.
+func (a *Aggregate) ErrorOrNil() error {
+	if a == nil || len(a.entries) == 0 {
+		return nil
+	}
+	return a
+}
+
+// Error returns a summary of all failures in the order in which they were added.
+//
+//	Example:
+//	   2 items failed:
+//	   - item 0: Please provide a name (MISSING_NAME)
+//	   - item a1b2: failed to save user
+//	         connection refused
+func (a *Aggregate) Error() string {
+	noun := "items"
+	if len(a.entries) == 1 {
+		noun = "item"
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%d %s failed:", len(a.entries), noun))
+	for _, e := range a.entries {
+		msg := strings.ReplaceAll(e.err.Error(), "\n", "\n"+padding)
+		sb.WriteString(fmt.Sprintf("\n- item %s: %s", e.key(), msg))
+	}
+	return sb.String()
+}
+
+// Is reports whether the error of any failed item matches the target, so that
+// errors.Is can match them before Go 1.20, which doesn't support Unwrap() []error.
+func (a *Aggregate) Is(target error) bool {
+	for _, e := range a.entries {
+		if errors.Is(e.err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of the failed items which matches the target, so that
+// errors.As can match them before Go 1.20, which doesn't support Unwrap() []error.
+func (a *Aggregate) As(target interface{}) bool {
+	for _, e := range a.entries {
+		if errors.As(e.err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the errors of all failed items, so that
+// errors.Is and errors.As can match any of them.
+func (a *Aggregate) Unwrap() []error {
+	errs := make([]error, len(a.entries))
+	for i, e := range a.entries {
+		errs[i] = e.err
+	}
+	return errs
+}
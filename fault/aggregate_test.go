@@ -0,0 +1,115 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_Aggregate_Error(t *testing.T) {
+	agg := &Aggregate{}
+	agg.Add(0, User("MISSING_NAME", "Please provide a name"))
+	agg.Add(1, nil)
+	agg.AddID("a1b2", SystemWrap(errors.New("connection refused"), "failed to save user"))
+
+	expected := "2 items failed:\n" +
+		"- item 0: Please provide a name (MISSING_NAME)\n" +
+		"- item a1b2: failed to save user\n" +
+		"      connection refused"
+	actual := agg.Error()
+
+	if actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Aggregate_Failed(t *testing.T) {
+	userErr := User("a", "aaa")
+	sysErr := System("b")
+	agg := &Aggregate{}
+	agg.Add(3, userErr)
+	agg.AddID("x", sysErr)
+
+	if failed := agg.Failed(); len(failed) != 1 || failed[3] != userErr {
+		t.Errorf(expectedFormat, "map[3:aaa (a)]", fmt.Sprint(failed))
+	}
+	if failed := agg.FailedIDs(); len(failed) != 1 || failed["x"] != sysErr {
+		t.Errorf(expectedFormat, "map[x:b]", fmt.Sprint(failed))
+	}
+}
+
+func Test_Aggregate_Unwrap(t *testing.T) {
+	sysErr := System("b")
+	agg := &Aggregate{}
+	agg.Add(0, User("a", "aaa"))
+	agg.Add(1, sysErr)
+
+	err := agg.ErrorOrNil()
+
+	if !errors.Is(err, sysErr) {
+		t.Error("The aggregate was expected to match the system error.")
+	}
+	var userErr *UserError
+	if !errors.As(err, &userErr) {
+		t.Error("The aggregate was expected to match the user error.")
+	}
+}
+
+func Test_Aggregate_ErrorOrNil_WithoutFailures(t *testing.T) {
+	agg := &Aggregate{}
+	agg.Add(0, nil)
+
+	if err := agg.ErrorOrNil(); err != nil {
+		t.Errorf(expectedFormat, "nil", err)
+	}
+}
+
+func Test_Aggregate_Keys(t *testing.T) {
+	agg := &Aggregate{}
+	agg.Add(3, User("a", "aaa"))
+	agg.AddID("a1b2", System("b"))
+	agg.Add(0, System("c"))
+
+	if actual := fmt.Sprint(agg.Keys()); actual != "[3 a1b2 0]" {
+		t.Errorf(expectedFormat, "[3 a1b2 0]", actual)
+	}
+}
+
+func Test_Aggregate_IsAndAs(t *testing.T) {
+	sysErr := System("b")
+	agg := &Aggregate{}
+	agg.Add(0, User("a", "aaa"))
+	agg.Add(1, fmt.Errorf("c: %w", sysErr))
+
+	// Is and As are called directly, since errors.Is and errors.As
+	// would use Unwrap() []error instead from Go 1.20 onwards:
+	if !agg.Is(sysErr) {
+		t.Error("The aggregate was expected to match the system error.")
+	}
+	if agg.Is(System("b")) {
+		t.Error("The aggregate wasn't expected to match another system error.")
+	}
+	var userErr *UserError
+	if !agg.As(&userErr) || userErr.Codes()[0] != "a" {
+		t.Error("The aggregate was expected to match the user error.")
+	}
+}
+
+func Test_Aggregate_Classification(t *testing.T) {
+	agg := &Aggregate{}
+	agg.Add(0, errors.New("a"))
+	agg.Add(1, SystemWrap(System("b").WithRetryAfter(time.Second), "c"))
+	agg.Add(2, System("d").WithKind(NotFound))
+	err := SystemWrap(agg, "failed to import users")
+
+	if actual := KindOf(err); actual != NotFound {
+		t.Errorf(expectedFormat, NotFound, actual)
+	}
+	if actual, ok := RetryAfter(err); !ok || actual != time.Second {
+		t.Errorf(expectedFormat, time.Second.String(), actual.String())
+	}
+	if !IsRetryable(err) {
+		t.Errorf(expectedFormat, "true", "false")
+	}
+}
@@ -0,0 +1,99 @@
+package fault
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func wrapN(depth int) *SystemError {
+	err := System("connection refused")
+	for i := 1; i < depth; i++ {
+		err = SystemWrap(err, "failed to query the database")
+	}
+	return err
+}
+
+func Benchmark_System(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = System("connection refused")
+	}
+}
+
+func Benchmark_SystemWrap(b *testing.B) {
+	for _, depth := range []int{1, 2, 5, 10} {
+		err := wrapN(depth)
+		b.Run(fmt.Sprintf("depth=%d", depth), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = SystemWrap(err, "failed to load user")
+			}
+		})
+	}
+}
+
+func Benchmark_SystemError_Error(b *testing.B) {
+	for _, depth := range []int{1, 5, 10} {
+		err := wrapN(depth)
+		b.Run(fmt.Sprintf("depth=%d", depth), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = err.Error()
+			}
+		})
+	}
+}
+
+func Benchmark_SystemError_String(b *testing.B) {
+	err := wrapN(5)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = err.String()
+	}
+}
+
+func Benchmark_SystemError_MarshalJSON(b *testing.B) {
+	err := wrapN(5)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = json.Marshal(err)
+	}
+}
+
+func Benchmark_UserError_Error(b *testing.B) {
+	err := User("MISSING_NAME", "Please provide a name.")
+	err.Add("INVALID_EMAIL", "Please provide a valid email address.")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
+
+// Test_Allocations guards the cost of creating and printing faults. Raise a limit
+// only deliberately, after checking the benchmarks of the affected function.
+func Test_Allocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("The race detector allocates on its own.")
+	}
+	wrapped := wrapN(5)
+	userErr := User("MISSING_NAME", "Please provide a name.")
+
+	testCases := []struct {
+		name string
+		max  float64
+		fn   func()
+	}{
+		{"System", 5, func() { _ = System("connection refused") }},
+		{"SystemWrap", 26, func() { _ = SystemWrap(wrapped, "failed to load user") }},
+		{"SystemError.Error", 17, func() { _ = wrapped.Error() }},
+		{"UserError.Error", 10, func() { _ = userErr.Error() }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := testing.AllocsPerRun(100, tc.fn); actual > tc.max {
+				t.Errorf("expected at most %v allocations, got %v", tc.max, actual)
+			}
+		})
+	}
+}
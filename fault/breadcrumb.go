@@ -0,0 +1,127 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultBreadcrumbCapacity is the number of breadcrumbs which are retained
+// by a context of WithBreadcrumbs if no positive capacity has been given.
+const DefaultBreadcrumbCapacity = 32
+
+// Breadcrumb is an entry of the trail of events which happened before a failure.
+type Breadcrumb struct {
+	Time    time.Time
+	Message string
+}
+
+// String returns the time and message of the breadcrumb.
+func (b Breadcrumb) String() string {
+	return fmt.Sprintf("%s %s", b.Time.Format("15:04:05.000"), b.Message)
+}
+
+// breadcrumbRecorder is a ring buffer of the most recent breadcrumbs.
+type breadcrumbRecorder struct {
+	mu     sync.Mutex
+	crumbs []Breadcrumb
+	next   int
+	full   bool
+}
+
+func (r *breadcrumbRecorder) add(b Breadcrumb) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.crumbs[r.next] = b
+	r.next = (r.next + 1) % len(r.crumbs)
+	r.full = r.full || r.next == 0
+}
+
+func (r *breadcrumbRecorder) list() []Breadcrumb {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]Breadcrumb(nil), r.crumbs[:r.next]...)
+	}
+	return append(append([]Breadcrumb(nil), r.crumbs[r.next:]...), r.crumbs[:r.next]...)
+}
+
+type breadcrumbKey struct{}
+
+// WithBreadcrumbs returns a copy of the context which records breadcrumbs,
+// retaining the most recent ones up to the given capacity.
+//
+//	Example:
+//	   ctx = fault.WithBreadcrumbs(ctx, 0)
+//	   ...
+//	   fault.AddBreadcrumb(ctx, "fetched user 42")
+//	   ...
+//	   return fault.SystemWrap(err, "failed to update user").WithContext(ctx)
+func WithBreadcrumbs(ctx context.Context, capacity int) context.Context {
+	if capacity <= 0 {
+		capacity = DefaultBreadcrumbCapacity
+	}
+	return context.WithValue(ctx, breadcrumbKey{}, &breadcrumbRecorder{
+		crumbs: make([]Breadcrumb, capacity),
+	})
+}
+
+// AddBreadcrumb records a breadcrumb in the context.
+// It is a no-op if the context doesn't record breadcrumbs (see WithBreadcrumbs).
+func AddBreadcrumb(ctx context.Context, msg string) {
+	if r, ok := ctx.Value(breadcrumbKey{}).(*breadcrumbRecorder); ok {
+		r.add(Breadcrumb{Time: time.Now(), Message: msg})
+	}
+}
+
+// AddBreadcrumbf records a breadcrumb in the context.
+// It is a no-op if the context doesn't record breadcrumbs (see WithBreadcrumbs).
+func AddBreadcrumbf(ctx context.Context, format string, a ...interface{}) {
+	AddBreadcrumb(ctx, fmt.Sprintf(format, a...))
+}
+
+// BreadcrumbsFromContext returns the breadcrumbs which have been recorded in the context,
+// starting with the oldest one.
+func BreadcrumbsFromContext(ctx context.Context) []Breadcrumb {
+	if r, ok := ctx.Value(breadcrumbKey{}).(*breadcrumbRecorder); ok {
+		return r.list()
+	}
+	return nil
+}
+
+// WithContext attaches the request scoped data of the context to the SystemError,
+// which are the breadcrumbs which have been recorded up to now.
+//
+// If the context is done then the cause of the context (see context.Cause) is attached
+// as the context.cause field and the remaining time until its deadline, if any, as the
+// context.deadline_remaining field, which explain why the context has been canceled
+// beyond the bare "context canceled" message.
+func (e *SystemError) WithContext(ctx context.Context) *SystemError {
+	if crumbs := BreadcrumbsFromContext(ctx); len(crumbs) > 0 {
+		e.breadcrumbs = crumbs
+	}
+	if ctx.Err() != nil {
+		e.WithField("context.cause", contextCause(ctx).Error())
+		if deadline, ok := ctx.Deadline(); ok {
+			e.WithField("context.deadline_remaining", time.Until(deadline).String())
+		}
+	}
+	return e
+}
+
+// Breadcrumbs returns the first breadcrumbs which have been attached
+// to a SystemError in the error's chain, starting with the oldest one.
+func (e *SystemError) Breadcrumbs() []Breadcrumb {
+	if e == nil {
+		return nil
+	}
+	for err := error(e); err != nil; err = errors.Unwrap(err) {
+		// nolint: errorlint // Walking the chain manually:
+		if sysErr, ok := err.(*SystemError); ok && len(sysErr.breadcrumbs) > 0 {
+			return sysErr.breadcrumbs
+		}
+	}
+	return nil
+}
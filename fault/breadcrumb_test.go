@@ -0,0 +1,75 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func breadcrumbMessages(crumbs []Breadcrumb) []string {
+	var msgs []string
+	for _, b := range crumbs {
+		msgs = append(msgs, b.Message)
+	}
+	return msgs
+}
+
+func Test_AddBreadcrumb_WithoutRecorder_IsNoOp(t *testing.T) {
+	ctx := context.Background()
+	AddBreadcrumb(ctx, "fetched user 42")
+
+	if crumbs := BreadcrumbsFromContext(ctx); crumbs != nil {
+		t.Errorf(expectedFormat, "nil", fmt.Sprint(crumbs))
+	}
+}
+
+func Test_AddBreadcrumb_RetainsMostRecent(t *testing.T) {
+	ctx := WithBreadcrumbs(context.Background(), 3)
+	for i := 1; i <= 5; i++ {
+		AddBreadcrumbf(ctx, "step %d", i)
+	}
+
+	expected := "[step 3 step 4 step 5]"
+	actual := fmt.Sprint(breadcrumbMessages(BreadcrumbsFromContext(ctx)))
+	if actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_AddBreadcrumb_BelowCapacity(t *testing.T) {
+	ctx := WithBreadcrumbs(context.Background(), 0)
+	AddBreadcrumb(ctx, "a")
+	AddBreadcrumb(ctx, "b")
+
+	expected := "[a b]"
+	actual := fmt.Sprint(breadcrumbMessages(BreadcrumbsFromContext(ctx)))
+	if actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_SystemError_WithContext_AttachesBreadcrumbs(t *testing.T) {
+	ctx := WithBreadcrumbs(context.Background(), 0)
+	AddBreadcrumb(ctx, "fetched user 42")
+	inner := SystemWrap(errors.New("boom"), "failed to save user").WithContext(ctx)
+	AddBreadcrumb(ctx, "rolled back")
+	err := SystemWrap(inner, "failed to handle request")
+
+	expected := "[fetched user 42]"
+	actual := fmt.Sprint(breadcrumbMessages(err.Breadcrumbs()))
+	if actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_SystemError_WithContext_WithoutBreadcrumbs(t *testing.T) {
+	err := System("boom").WithContext(context.Background())
+
+	if crumbs := err.Breadcrumbs(); crumbs != nil {
+		t.Errorf(expectedFormat, "nil", fmt.Sprint(crumbs))
+	}
+	if fields := err.Fields(); len(fields) > 0 {
+		t.Errorf(expectedFormat, "no fields", fmt.Sprint(fields))
+	}
+}
@@ -0,0 +1,91 @@
+package fault
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// CallSite is the location in the source code at which a SystemError has been created.
+type CallSite struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// String returns the file and line of the call site.
+func (c CallSite) String() string {
+	return fmt.Sprintf("%s:%d", c.File, c.Line)
+}
+
+// CallSiteCount is the number of SystemErrors which have been created at a call site.
+type CallSiteCount struct {
+	CallSite CallSite
+	Count    uint64
+}
+
+// CallSiteCounter counts how many SystemErrors have been created at each call site,
+// which identifies the hot error paths of an application, e.g. in order to capture
+// less frames for them (see Profile.StackDepth). Its Record method is a CreateHook:
+//
+//	Example:
+//	   counter := fault.NewCallSiteCounter()
+//	   fault.SetCreateHook(counter.Record)
+//	   ...
+//	   for _, c := range counter.Top(10) {
+//	      log.Printf("%s: %d", c.CallSite, c.Count)
+//	   }
+type CallSiteCounter struct {
+	mu     sync.Mutex
+	counts map[CallSite]uint64
+}
+
+// NewCallSiteCounter creates an empty CallSiteCounter.
+func NewCallSiteCounter() *CallSiteCounter {
+	return &CallSiteCounter{counts: map[CallSite]uint64{}}
+}
+
+// Record counts the SystemError at the call site of its stack trace.
+// SystemErrors without a stack trace (e.g. restored ones) are ignored.
+func (c *CallSiteCounter) Record(e *SystemError) {
+	frame, ok := e.Trace().Caller()
+	if !ok {
+		return
+	}
+	site := CallSite{Function: frame.Function, File: frame.File, Line: frame.Line}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[site]++
+}
+
+// Counts returns the number of SystemErrors per call site.
+func (c *CallSiteCounter) Counts() map[CallSite]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := make(map[CallSite]uint64, len(c.counts))
+	for site, count := range c.counts {
+		counts[site] = count
+	}
+	return counts
+}
+
+// Top returns the n call sites with the most SystemErrors, starting with the most
+// frequent one. All call sites are returned if n is zero or negative.
+func (c *CallSiteCounter) Top(n int) []CallSiteCount {
+	counts := c.Counts()
+	top := make([]CallSiteCount, 0, len(counts))
+	for site, count := range counts {
+		top = append(top, CallSiteCount{CallSite: site, Count: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].CallSite.String() < top[j].CallSite.String()
+	})
+	if n > 0 && n < len(top) {
+		top = top[:n]
+	}
+	return top
+}
@@ -0,0 +1,40 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_CallSiteCounter(t *testing.T) {
+	counter := NewCallSiteCounter()
+	restore := SetCreateHook(counter.Record)
+
+	for i := 0; i < 3; i++ {
+		_ = SystemWrap(errors.New("connection refused"), "failed to connect")
+	}
+	_ = Systemf("failed to load user %d", 1)
+	_ = RestoreSystem(nil, []string{"restored"}, "")
+
+	restore()
+	_ = System("not counted")
+
+	top := counter.Top(0)
+	if len(top) != 2 {
+		t.Fatalf(expectedFormat, "2 call sites", fmt.Sprint(top))
+	}
+	if top[0].Count != 3 || top[1].Count != 1 {
+		t.Errorf(expectedFormat, "3 1", fmt.Sprint(top[0].Count, top[1].Count))
+	}
+	if !strings.HasSuffix(top[0].CallSite.Function, "Test_CallSiteCounter") ||
+		!strings.HasSuffix(top[0].CallSite.File, "callsite_test.go") {
+		t.Errorf(expectedFormat, "Test_CallSiteCounter", top[0].CallSite)
+	}
+	if top[0].CallSite == top[1].CallSite {
+		t.Errorf(expectedFormat, "different call sites", top[1].CallSite)
+	}
+	if actual := counter.Top(1); len(actual) != 1 || actual[0] != top[0] {
+		t.Errorf(expectedFormat, fmt.Sprint(top[:1]), fmt.Sprint(actual))
+	}
+}
@@ -0,0 +1,36 @@
+package fault
+
+import (
+	"context"
+	"errors"
+)
+
+// IsCanceled reports whether the error's chain contains context.Canceled
+// or a fault which has been classified as Canceled.
+func IsCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || KindOf(err) == Canceled
+}
+
+// IsTimeout reports whether the error's chain contains context.DeadlineExceeded,
+// an error which reports a timeout (e.g. a net.Error) or a fault which has been
+// classified as Timeout.
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || KindOf(err) == Timeout {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// ClassifyContext classifies context.Canceled as Canceled
+// and context.DeadlineExceeded as Timeout.
+func ClassifyContext(err error) (Kind, bool) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return Canceled, true
+	case errors.Is(err, context.DeadlineExceeded):
+		return Timeout, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,58 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+)
+
+func Test_IsCanceled(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{errors.New("a"), false},
+		{context.Canceled, true},
+		{SystemWrap(fmt.Errorf("a: %w", context.Canceled), "b"), true},
+		{System("a").WithKind(Canceled), true},
+		{context.DeadlineExceeded, false},
+	}
+	for _, test := range tests {
+		if actual := IsCanceled(test.err); actual != test.expected {
+			t.Errorf(expectedFormat, fmt.Sprint(test.expected), fmt.Sprint(actual))
+		}
+	}
+}
+
+func Test_IsTimeout(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{errors.New("a"), false},
+		{context.DeadlineExceeded, true},
+		{SystemWrap(context.DeadlineExceeded, "a"), true},
+		{SystemWrap(&net.DNSError{Err: "a", IsTimeout: true}, "b"), true},
+		{&net.DNSError{Err: "a"}, false},
+		{System("a").WithKind(Timeout), true},
+		{context.Canceled, false},
+	}
+	for _, test := range tests {
+		if actual := IsTimeout(test.err); actual != test.expected {
+			t.Errorf(expectedFormat, fmt.Sprint(test.expected), fmt.Sprint(actual))
+		}
+	}
+}
+
+func Test_SystemWrap_ClassifiesContextErrors(t *testing.T) {
+	if actual := SystemWrap(context.Canceled, "a").Kind(); actual != Canceled {
+		t.Errorf(expectedFormat, Canceled, actual)
+	}
+	if actual := SystemWrap(fmt.Errorf("a: %w", context.DeadlineExceeded), "b").Kind(); actual != Timeout {
+		t.Errorf(expectedFormat, Timeout, actual)
+	}
+}
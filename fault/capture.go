@@ -0,0 +1,61 @@
+package fault
+
+import (
+	"sync/atomic"
+
+	"github.com/dusted-go/fault/stack"
+)
+
+// Capturer captures the stack traces of new SystemErrors.
+type Capturer interface {
+	// Capture captures the stack trace of the calling goroutine.
+	Capture() *stack.Trace
+
+	// CapturePanic captures the stack trace of a panicking goroutine (see FromPanic).
+	CapturePanic() *stack.Trace
+}
+
+type runtimeCapturer struct{}
+
+func (runtimeCapturer) Capture() *stack.Trace {
+	return stack.CaptureDepth(CurrentProfile().StackDepth)
+}
+
+func (runtimeCapturer) CapturePanic() *stack.Trace {
+	return stack.CapturePanic()
+}
+
+type capturerHolder struct {
+	Capturer
+}
+
+var currentCapturer atomic.Value
+
+func init() {
+	currentCapturer.Store(capturerHolder{runtimeCapturer{}})
+}
+
+func capturer() Capturer {
+	return currentCapturer.Load().(capturerHolder).Capturer
+}
+
+// SetCapturer replaces the Capturer which captures the stack traces of new SystemErrors
+// and returns a function which restores the previous Capturer. A nil Capturer restores
+// the default, which captures the stack trace of the calling goroutine.
+//
+// Tests can install a deterministic Capturer (e.g. one which returns a stack.Synthetic
+// trace) in order to produce stable error output for golden file and snapshot tests.
+// Wrap sites (see SystemWrap) are only recorded by the default Capturer for the same reason.
+//
+//	Example:
+//	   restore := fault.SetCapturer(myCapturer)
+//	   defer restore()
+func SetCapturer(c Capturer) (restore func()) {
+	if c == nil {
+		c = runtimeCapturer{}
+	}
+	previous := currentCapturer.Swap(capturerHolder{c})
+	return func() {
+		currentCapturer.Store(previous)
+	}
+}
@@ -0,0 +1,30 @@
+package fault
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/dusted-go/fault/stack"
+)
+
+type fixedCapturer struct {
+	trace *stack.Trace
+}
+
+func (c fixedCapturer) Capture() *stack.Trace      { return c.trace }
+func (c fixedCapturer) CapturePanic() *stack.Trace { return c.trace }
+
+func Test_SetCapturer(t *testing.T) {
+	trace := stack.Synthetic(runtime.Frame{Function: "main.main", File: "main.go", Line: 1})
+	restore := SetCapturer(fixedCapturer{trace: trace})
+
+	err := System("a")
+	restore()
+
+	if err.Trace() != trace {
+		t.Errorf(expectedFormat, trace.String(), err.Trace().String())
+	}
+	if System("b").Trace() == trace {
+		t.Error("The previous Capturer was expected to be restored.")
+	}
+}
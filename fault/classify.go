@@ -0,0 +1,158 @@
+package fault
+
+import (
+	"errors"
+	"io/fs"
+	"sync"
+)
+
+// Classifier decides the kind of an error which isn't a fault (e.g. an error of the standard library).
+// It reports false if it can't classify the error.
+type Classifier func(err error) (Kind, bool)
+
+var (
+	classifiersMu sync.RWMutex
+	classifiers   = []Classifier{ClassifyContext, ClassifyTimeout}
+)
+
+// RegisterClassifier appends a classifier to the classifiers which are used by SystemWrap
+// and Errorf. Classifiers are applied in the order in which they have been registered,
+// after the built-in ClassifyContext and ClassifyTimeout.
+//
+// ClassifyOS isn't registered by default, because a missing or inaccessible file is
+// usually a failure of the server rather than of the client, and its kind would turn
+// it into a 4xx response which doesn't get logged (e.g. a missing config file into a 404).
+//
+//	Example:
+//	   fault.RegisterClassifier(fault.ClassifyOS)
+func RegisterClassifier(c Classifier) {
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+	classifiers = append(classifiers, c)
+}
+
+// Classify returns the kind of the error.
+//
+// The kind of the first classified fault in the error's chain wins.
+// Otherwise the kind of the first registered classifier which can classify
+// the error will be returned.
+func Classify(err error) (Kind, bool) {
+	if err == nil {
+		return "", false
+	}
+	if kind, ok := faultKind(err); ok {
+		return kind, true
+	}
+	return classifyCause(err)
+}
+
+// classifyCause applies the registered classifiers to the error.
+func classifyCause(err error) (Kind, bool) {
+	classifiersMu.RLock()
+	defer classifiersMu.RUnlock()
+	for _, c := range classifiers {
+		if kind, ok := c(err); ok {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// autoKind returns the kind with which SystemWrap classifies a new SystemError,
+// which is empty if the wrapped error already contains a classified fault.
+func autoKind(err error) Kind {
+	if _, ok := faultKind(err); ok {
+		return ""
+	}
+	kind, _ := classifyCause(err)
+	return kind
+}
+
+// ClassifyTimeout classifies errors which report a timeout
+// (e.g. os.ErrDeadlineExceeded or a net.Error) as Timeout.
+func ClassifyTimeout(err error) (Kind, bool) {
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return Timeout, true
+	}
+	return "", false
+}
+
+// ClassifyOS classifies errors of the file system and the operating system.
+// It is opt-in (see RegisterClassifier).
+//
+// fs.ErrNotExist is classified as NotFound, fs.ErrPermission as PermissionDenied,
+// fs.ErrExist as Conflict and errors which report a timeout as Timeout (see ClassifyTimeout).
+func ClassifyOS(err error) (Kind, bool) {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return NotFound, true
+	case errors.Is(err, fs.ErrPermission):
+		return PermissionDenied, true
+	case errors.Is(err, fs.ErrExist):
+		return Conflict, true
+	default:
+		return ClassifyTimeout(err)
+	}
+}
+
+// Adapter translates the errors of a third-party library (e.g. a database driver
+// or a cloud SDK) into SystemErrors with the kind and retryability which the errors
+// of the library imply. The adapter modules (e.g. faultsql) provide their Wrap
+// function and Rule through an Adapter.
+//
+//	Example:
+//	   var adapter = fault.Adapter{
+//	      Classify: classifyRedis,
+//	      Annotate: func(sysErr *fault.SystemError, err error) {
+//	         sysErr.WithField("redis_prefix", redisPrefix(err))
+//	      },
+//	   }
+type Adapter struct {
+	// Classify returns the kind and retryability of an error of the library.
+	// It reports false if the error can't be classified.
+	Classify func(err error) (kind Kind, retryable bool, ok bool)
+
+	// Annotate attaches details of an error of the library to the SystemError
+	// which wraps it (e.g. an error code as a field). It is optional.
+	Annotate func(sysErr *SystemError, err error)
+}
+
+// Wrap wraps the error into a SystemError like SystemWrap and applies the
+// classification and annotations of the error. Errors which can't be classified
+// are wrapped without a kind.
+func (a Adapter) Wrap(err error, msg string) *SystemError {
+	return a.annotate(systemWrap(err, msg, callSite(0)), err)
+}
+
+// WrapSkip is like Wrap, but records the wrap site like SystemWrapSkip,
+// so that the Wrap functions of adapter packages can attribute it to their caller.
+func (a Adapter) WrapSkip(err error, msg string, skip int) *SystemError {
+	return a.annotate(systemWrap(err, msg, callSite(skip)), err)
+}
+
+// Rule is a Rule which translates the errors of the library into classified and
+// annotated SystemErrors without adding a message. It doesn't apply to errors
+// which can't be classified.
+func (a Adapter) Rule(err error) error {
+	kind, _, ok := a.Classify(err)
+	if !ok {
+		return nil
+	}
+	// nolint: errorlint // ToKind always returns a SystemError:
+	return a.annotate(ToKind(kind)(err).(*SystemError), err)
+}
+
+// annotate attaches the classification and the annotations of the error.
+func (a Adapter) annotate(sysErr *SystemError, err error) *SystemError {
+	if err == nil {
+		return sysErr
+	}
+	if kind, retryable, ok := a.Classify(err); ok {
+		sysErr.WithKind(kind).WithRetryable(retryable)
+	}
+	if a.Annotate != nil {
+		a.Annotate(sysErr, err)
+	}
+	return sysErr
+}
@@ -0,0 +1,134 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func Test_SystemWrap_ClassifiesTimeouts(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected Kind
+	}{
+		{fmt.Errorf("read: %w", os.ErrDeadlineExceeded), Timeout},
+		{&fs.PathError{Op: "open", Path: "a", Err: fs.ErrNotExist}, Internal},
+		{&fs.PathError{Op: "open", Path: "a", Err: fs.ErrPermission}, Internal},
+		{errors.New("a"), Internal},
+	}
+	for _, test := range tests {
+		if actual := SystemWrap(test.err, "b").Kind(); actual != test.expected {
+			t.Errorf(expectedFormat, test.expected, actual)
+		}
+		if actual := Errorf("b: %w", test.err).Kind(); actual != test.expected {
+			t.Errorf(expectedFormat, test.expected, actual)
+		}
+	}
+}
+
+func Test_ClassifyOS(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected Kind
+	}{
+		{&fs.PathError{Op: "open", Path: "a", Err: fs.ErrNotExist}, NotFound},
+		{&fs.PathError{Op: "open", Path: "a", Err: fs.ErrPermission}, PermissionDenied},
+		{&os.LinkError{Op: "link", Old: "a", New: "b", Err: fs.ErrExist}, Conflict},
+		{fmt.Errorf("read: %w", os.ErrDeadlineExceeded), Timeout},
+		{errors.New("a"), ""},
+	}
+	for _, test := range tests {
+		if actual, _ := ClassifyOS(test.err); actual != test.expected {
+			t.Errorf(expectedFormat, test.expected, actual)
+		}
+	}
+}
+
+func Test_SystemWrap_KeepsClassifiedFault(t *testing.T) {
+	inner := SystemWrap(fs.ErrNotExist, "a").WithKind(Unavailable)
+
+	if actual := SystemWrap(inner, "b").Kind(); actual != Unavailable {
+		t.Errorf(expectedFormat, Unavailable, actual)
+	}
+}
+
+type testClassifiedError struct{}
+
+func (testClassifiedError) Error() string { return "classified" }
+
+func Test_RegisterClassifier(t *testing.T) {
+	RegisterClassifier(func(err error) (Kind, bool) {
+		if errors.As(err, &testClassifiedError{}) {
+			return ResourceExhausted, true
+		}
+		return "", false
+	})
+
+	if actual := SystemWrap(testClassifiedError{}, "a").Kind(); actual != ResourceExhausted {
+		t.Errorf(expectedFormat, ResourceExhausted, actual)
+	}
+	if kind, ok := Classify(fmt.Errorf("a: %w", testClassifiedError{})); !ok || kind != ResourceExhausted {
+		t.Errorf(expectedFormat, ResourceExhausted, kind)
+	}
+	if kind, ok := Classify(errors.New("a")); ok {
+		t.Errorf(expectedFormat, "", kind)
+	}
+}
+
+var testAdapter = Adapter{
+	Classify: func(err error) (Kind, bool, bool) {
+		if errors.As(err, &testClassifiedError{}) {
+			return Unavailable, true, true
+		}
+		return "", false, false
+	},
+	Annotate: func(sysErr *SystemError, err error) {
+		sysErr.WithField("classified", errors.As(err, &testClassifiedError{}))
+	},
+}
+
+func wrapClassified(err error) *SystemError {
+	return testAdapter.WrapSkip(err, "failed to call service", 1)
+}
+
+func Test_Adapter_Wrap(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	err := wrapClassified(testClassifiedError{})
+
+	if err.Kind() != Unavailable || !err.Retryable() {
+		t.Errorf(expectedFormat, "retryable Unavailable", fmt.Sprint(err.Retryable(), " ", err.Kind()))
+	}
+	if actual := fmt.Sprint(err.Fields()); actual != "map[classified:true]" {
+		t.Errorf(expectedFormat, "map[classified:true]", actual)
+	}
+	expected := fmt.Sprintf("\n\nwrap sites:\n%s:%d: failed to call service", file, line+1)
+	if actual := err.StackTrace(); !strings.HasSuffix(actual, expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+
+	unclassified := testAdapter.Wrap(errors.New("a"), "b")
+	if unclassified.Kind() != Internal || unclassified.Retryable() {
+		t.Errorf(expectedFormat, Internal, unclassified.Kind())
+	}
+	if actual := testAdapter.Wrap(nil, "b").Kind(); actual != Bug {
+		t.Errorf(expectedFormat, Bug, actual)
+	}
+}
+
+func Test_Adapter_Rule(t *testing.T) {
+	if err := testAdapter.Rule(errors.New("a")); err != nil {
+		t.Errorf(expectedFormat, "nil", err)
+	}
+
+	var sysErr *SystemError
+	if err := testAdapter.Rule(testClassifiedError{}); !errors.As(err, &sysErr) {
+		t.Fatalf(expectedFormat, "*SystemError", fmt.Sprint(err))
+	}
+	if sysErr.Kind() != Unavailable || !sysErr.Retryable() || sysErr.Error() != "classified" {
+		t.Errorf(expectedFormat, "retryable Unavailable", fmt.Sprint(sysErr.Retryable(), " ", sysErr.Kind()))
+	}
+}
@@ -0,0 +1,60 @@
+package fault
+
+import "strings"
+
+// Code is the error code of a user error (e.g. MISSING_FIRST_NAME).
+//
+// Codes can be namespaced with dots in order to prevent collisions between
+// the codes of different parts of a larger application (e.g. billing.CARD_DECLINED).
+//
+// Code is a string type so that untyped string constants remain valid codes,
+// while declaring codes as typed constants lets the compiler catch typos:
+//
+//	Example:
+//	   const CodeMissingName fault.Code = "MISSING_NAME"
+//	   ...
+//	   return fault.User(CodeMissingName, "Please provide a name.")
+type Code string
+
+// String returns the code as a string.
+func (c Code) String() string {
+	return string(c)
+}
+
+// Valid reports whether the code is non-empty and consists of ASCII letters, digits,
+// underscores and hyphens, with dots only separating non-empty namespace segments.
+func (c Code) Valid() bool {
+	if c == "" {
+		return false
+	}
+	for _, segment := range strings.Split(string(c), ".") {
+		if segment == "" {
+			return false
+		}
+		for _, r := range segment {
+			if !isCodeRune(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isCodeRune(r rune) bool {
+	return r >= 'a' && r <= 'z' ||
+		r >= 'A' && r <= 'Z' ||
+		r >= '0' && r <= '9' ||
+		r == '_' || r == '-'
+}
+
+// Namespace returns the part of the code which precedes the last dot,
+// or an empty string if the code isn't namespaced.
+//
+//	Example:
+//	   fault.Code("billing.CARD_DECLINED").Namespace() // billing
+func (c Code) Namespace() string {
+	if i := strings.LastIndex(string(c), "."); i >= 0 {
+		return string(c[:i])
+	}
+	return ""
+}
@@ -0,0 +1,48 @@
+package fault
+
+import "testing"
+
+func Test_Code_Valid(t *testing.T) {
+	testCases := []struct {
+		code     Code
+		expected bool
+	}{
+		{"MISSING_FIRST_NAME", true},
+		{"user-not-found", true},
+		{"billing.CARD_DECLINED", true},
+		{"", false},
+		{"billing.", false},
+		{".CARD_DECLINED", false},
+		{"billing..CARD_DECLINED", false},
+		{"MISSING NAME", false},
+		{"ÜNICODE", false},
+	}
+	for _, tc := range testCases {
+		if actual := tc.code.Valid(); actual != tc.expected {
+			t.Errorf("Valid() of %q: expected %v, got %v", tc.code, tc.expected, actual)
+		}
+	}
+}
+
+func Test_Code_Namespace(t *testing.T) {
+	testCases := map[Code]string{
+		"MISSING_FIRST_NAME":        "",
+		"billing.CARD_DECLINED":     "billing",
+		"shop.billing.CARD_EXPIRED": "shop.billing",
+	}
+	for code, expected := range testCases {
+		if actual := code.Namespace(); actual != expected {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
+
+func Test_User_WithTypedCode(t *testing.T) {
+	const code Code = "MISSING_NAME"
+
+	err := User(code, "Please provide a name.")
+
+	if !err.HasCode(code) || !err.HasCode("MISSING_NAME") {
+		t.Error("The user error was expected to have the code MISSING_NAME.")
+	}
+}
@@ -0,0 +1,160 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Collector collects the user errors, system faults and warnings of a request,
+// so that deeply nested code can report them without returning them
+// through every function signature.
+//
+// All methods are safe for concurrent use and are no-ops on a nil Collector,
+// so code can report to the Collector of a context which might not have one.
+//
+//	Example:
+//	   ctx = fault.NewContext(ctx, &fault.Collector{})
+//	   ...
+//	   fault.FromContext(ctx).AddUser("MISSING_NAME", "Please provide a name.")
+//	   ...
+//	   if err := fault.FromContext(ctx).Err(); err != nil {
+//	      ...
+//	   }
+type Collector struct {
+	mu       sync.Mutex
+	user     *UserError
+	system   Aggregate
+	warnings []Warning
+}
+
+// AddUser appends a user error.
+func (c *Collector) AddUser(code Code, msg string) {
+	c.Report(User(code, msg))
+}
+
+// AddUserf appends a user error.
+func (c *Collector) AddUserf(code Code, format string, a ...interface{}) {
+	c.AddUser(code, fmt.Sprintf(format, a...))
+}
+
+// Report records an error. The codes of a UserError get appended to the
+// collected user errors and any other error gets recorded as a system fault.
+// Nothing will be recorded if the error is nil.
+func (c *Collector) Report(err error) {
+	if c == nil || err == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// nolint: errorlint // Only a UserError at the top of the chain is merged:
+	if userErr, ok := err.(*UserError); ok {
+		c.user = mergeUserErrors(c.user, userErr)
+		return
+	}
+	c.system.Add(c.system.Len(), err)
+}
+
+// UserError returns the collected user errors, or nil if none have been collected.
+func (c *Collector) UserError() *UserError {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return mergeUserErrors(c.user)
+}
+
+// Err returns the collected errors, or nil if nothing has been collected.
+//
+// System faults take precedence over user errors: a single system fault
+// is returned as is and multiple system faults are returned as an Aggregate.
+// Otherwise the collected user errors are returned.
+func (c *Collector) Err() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch c.system.Len() {
+	case 0:
+		if c.user == nil {
+			return nil
+		}
+		return mergeUserErrors(c.user)
+	case 1:
+		return c.system.entries[0].err
+	default:
+		agg := Aggregate{entries: append([]aggregateEntry(nil), c.system.entries...)}
+		return &agg
+	}
+}
+
+// AddWarning appends a warning, which doesn't fail the request.
+func (c *Collector) AddWarning(code Code, msg string) {
+	c.Warn(Warn(code, msg))
+}
+
+// AddWarningf appends a warning, which doesn't fail the request.
+func (c *Collector) AddWarningf(code Code, format string, a ...interface{}) {
+	c.Warn(Warnf(code, format, a...))
+}
+
+// Warn appends warnings, which don't fail the request.
+func (c *Collector) Warn(warnings ...Warning) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warnings = append(c.warnings, warnings...)
+}
+
+// Warnings returns the collected warnings in the order in which they have been added.
+func (c *Collector) Warnings() []Warning {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Warning(nil), c.warnings...)
+}
+
+type collectorKey struct{}
+
+// NewContext returns a copy of the context which carries the Collector.
+func NewContext(ctx context.Context, c *Collector) context.Context {
+	return context.WithValue(ctx, collectorKey{}, c)
+}
+
+// FromContext returns the Collector of the context, or nil if the context doesn't carry one.
+func FromContext(ctx context.Context) *Collector {
+	c, _ := ctx.Value(collectorKey{}).(*Collector)
+	return c
+}
+
+// mergeUserErrors returns a new UserError which contains the errors of all given
+// UserErrors, or nil if there are none. The first retry hint is retained.
+func mergeUserErrors(errs ...*UserError) *UserError {
+	var merged *UserError
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &UserError{}
+		}
+		if merged.retryAfter == 0 {
+			merged.retryAfter = e.retryAfter
+		}
+		for i, code := range e.codes {
+			if field, ok := e.fields[code]; ok {
+				merged.AddField(field, Code(code), e.messages[i])
+			} else {
+				merged.Add(Code(code), e.messages[i])
+			}
+			merged.setParams(code, e.params[code])
+		}
+	}
+	return merged
+}
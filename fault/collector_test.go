@@ -0,0 +1,83 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func Test_Collector_WithUserErrors(t *testing.T) {
+	ctx := NewContext(context.Background(), &Collector{})
+
+	FromContext(ctx).AddUser("a", "aaa")
+	FromContext(ctx).Report(User("b", "bbb"))
+
+	expected := "- aaa (a)\n- bbb (b)"
+	err := FromContext(ctx).Err()
+	if err == nil || err.Error() != expected {
+		t.Errorf(expectedFormat, expected, err)
+	}
+}
+
+func Test_Collector_WithSystemFaults(t *testing.T) {
+	c := &Collector{}
+	sysErr := System("b")
+	c.AddUser("a", "aaa")
+	c.Report(sysErr)
+
+	if err := c.Err(); err != sysErr {
+		t.Errorf(expectedFormat, sysErr, err)
+	}
+
+	c.Report(errors.New("c"))
+
+	var agg *Aggregate
+	if !errors.As(c.Err(), &agg) || agg.Len() != 2 {
+		t.Errorf(expectedFormat, "Aggregate of 2 system faults", c.Err())
+	}
+	if userErr := c.UserError(); userErr == nil || !userErr.HasCode("a") {
+		t.Errorf(expectedFormat, "aaa (a)", userErr)
+	}
+}
+
+func Test_Collector_WithoutCollector(t *testing.T) {
+	c := FromContext(context.Background())
+
+	c.AddUser("a", "aaa")
+
+	if err := c.Err(); err != nil {
+		t.Errorf(expectedFormat, "nil", err)
+	}
+}
+
+func Test_Collector_WithWarnings(t *testing.T) {
+	ctx := NewContext(context.Background(), &Collector{})
+
+	FromContext(ctx).AddWarning("DEPRECATED_PARAMETER", "The parameter sort is deprecated.")
+	FromContext(ctx).AddWarningf("PARTIAL_RESULT", "%d of %d sources responded.", 2, 3)
+
+	c := FromContext(ctx)
+	if c.Err() != nil {
+		t.Errorf(expectedFormat, "<nil>", c.Err())
+	}
+	warnings := c.Warnings()
+	if len(warnings) != 2 || warnings[1].Message != "2 of 3 sources responded." {
+		t.Errorf(expectedFormat, "2 warnings", warnings)
+	}
+
+	var nilCollector *Collector
+	nilCollector.AddWarning("a", "a")
+	if nilCollector.Warnings() != nil {
+		t.Error("A nil Collector was expected to have no warnings.")
+	}
+}
+
+func Test_Collector_PreservesFields(t *testing.T) {
+	c := &Collector{}
+	c.Report(UserField("email", "INVALID_EMAIL", "Please provide a valid email address."))
+	c.AddUser("TERMS_NOT_ACCEPTED", "Please accept the terms.")
+
+	if actual := c.UserError().Field("INVALID_EMAIL"); actual != "email" {
+		t.Errorf(expectedFormat, "email", actual)
+	}
+}
@@ -0,0 +1,53 @@
+package fault
+
+import (
+	"io"
+	"os"
+)
+
+// ANSI escape codes of colored output.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+)
+
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// ColorEnabled reports whether faults which are written to w should be colored with
+// ANSI escape codes, so that CLI tools behave correctly when piped or run in CI.
+//
+// A non-empty NO_COLOR environment variable disables colors (see https://no-color.org),
+// whereas a FORCE_COLOR environment variable enables them, unless it is "0" or "false".
+// Otherwise colors are enabled if the current profile enables them (see Profile.Color),
+// w is a terminal and the TERM environment variable isn't "dumb".
+func ColorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	switch force := os.Getenv("FORCE_COLOR"); force {
+	case "":
+	case "0", "false":
+		return false
+	default:
+		return true
+	}
+	return CurrentProfile().Color && os.Getenv("TERM") != "dumb" && isTerminal(w)
+}
+
+// colorize wraps the text in the ANSI escape code if color is true.
+func colorize(s, code string, color bool) string {
+	if !color || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
@@ -0,0 +1,58 @@
+package fault
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func Test_ColorEnabled(t *testing.T) {
+	restoreTerminal := isTerminal
+	isTerminal = func(w io.Writer) bool { return w != nil }
+	defer func() { isTerminal = restoreTerminal }()
+
+	testCases := []struct {
+		name       string
+		profile    Profile
+		noColor    string
+		forceColor string
+		term       string
+		w          io.Writer
+		expected   bool
+	}{
+		{"terminal", Development, "", "", "xterm", &bytes.Buffer{}, true},
+		{"piped", Development, "", "", "xterm", nil, false},
+		{"dumb terminal", Development, "", "", "dumb", &bytes.Buffer{}, false},
+		{"profile without colors", Production, "", "", "xterm", &bytes.Buffer{}, false},
+		{"NO_COLOR", Development, "1", "", "xterm", &bytes.Buffer{}, false},
+		{"NO_COLOR over FORCE_COLOR", Development, "1", "1", "xterm", &bytes.Buffer{}, false},
+		{"FORCE_COLOR when piped", Production, "", "1", "dumb", nil, true},
+		{"FORCE_COLOR=0", Development, "", "0", "xterm", &bytes.Buffer{}, false},
+		{"FORCE_COLOR=false", Development, "", "false", "xterm", &bytes.Buffer{}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tc.noColor)
+			t.Setenv("FORCE_COLOR", tc.forceColor)
+			t.Setenv("TERM", tc.term)
+			restore := SetProfile(tc.profile)
+			defer restore()
+
+			if actual := ColorEnabled(tc.w); actual != tc.expected {
+				t.Errorf(expectedFormat, fmt.Sprint(tc.expected), fmt.Sprint(actual))
+			}
+		})
+	}
+}
+
+func Test_ColorEnabled_WithoutFile(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	restore := SetProfile(Development)
+	defer restore()
+
+	if ColorEnabled(&bytes.Buffer{}) {
+		t.Errorf(expectedFormat, "false", "true")
+	}
+}
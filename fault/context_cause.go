@@ -0,0 +1,10 @@
+//go:build go1.20
+
+package fault
+
+import "context"
+
+// contextCause returns the cause of the done context (see context.WithCancelCause).
+func contextCause(ctx context.Context) error {
+	return context.Cause(ctx)
+}
@@ -0,0 +1,39 @@
+//go:build go1.20
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_SystemError_WithContext_AttachesCause(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("client disconnected"))
+
+	err := SystemWrap(ctx.Err(), "failed to query users").WithContext(ctx)
+
+	expected := "map[context.cause:client disconnected]"
+	if actual := fmt.Sprint(err.Fields()); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_SystemError_WithContext_AttachesRemainingDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	err := SystemWrap(ctx.Err(), "failed to query users").WithContext(ctx)
+
+	fields := err.Fields()
+	if fields["context.cause"] != context.DeadlineExceeded.Error() {
+		t.Errorf(expectedFormat, context.DeadlineExceeded, fmt.Sprint(fields["context.cause"]))
+	}
+	if remaining := fmt.Sprint(fields["context.deadline_remaining"]); !strings.HasPrefix(remaining, "-1") {
+		t.Errorf(expectedFormat, "-1s", remaining)
+	}
+}
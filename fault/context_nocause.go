@@ -0,0 +1,11 @@
+//go:build !go1.20
+
+package fault
+
+import "context"
+
+// contextCause returns the error of the done context,
+// since causes are only supported as of Go 1.20.
+func contextCause(ctx context.Context) error {
+	return ctx.Err()
+}
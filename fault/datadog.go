@@ -0,0 +1,35 @@
+package fault
+
+import "errors"
+
+// DatadogAttributes returns the attributes which Datadog Error Tracking expects
+// in order to track the error:
+//
+//   - error.kind is the type of the error which caused the fault (see CauseType)
+//   - error.message is the error message
+//   - error.stack is the stack trace of a SystemError in the layout of the Go runtime
+//   - error.fingerprint groups the occurrences of the error (see Fingerprint)
+//
+// All values are strings, so that the attributes can be added to a log entry
+// as well as to a span of the tracer. The message is scrubbed by the DefaultScrubber.
+// It returns nil if the error is nil.
+//
+//	Example:
+//	   for k, v := range fault.DatadogAttributes(err) {
+//	      span.SetTag(k, v)
+//	   }
+func DatadogAttributes(err error) map[string]string {
+	if err == nil {
+		return nil
+	}
+	attrs := map[string]string{
+		"error.kind":        CauseType(err),
+		"error.message":     Scrub(err.Error()),
+		"error.fingerprint": Fingerprint(err),
+	}
+	var sysErr *SystemError
+	if errors.As(err, &sysErr) {
+		attrs["error.stack"] = sysErr.ExceptionStackTrace()
+	}
+	return attrs
+}
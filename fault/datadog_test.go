@@ -0,0 +1,44 @@
+package fault
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_DatadogAttributes_Nil(t *testing.T) {
+	if actual := DatadogAttributes(nil); actual != nil {
+		t.Errorf(expectedFormat, "nil", fmt.Sprint(actual))
+	}
+}
+
+func Test_DatadogAttributes_SystemError(t *testing.T) {
+	err := SystemWrap(System("connection refused"), "failed to load user")
+
+	attrs := DatadogAttributes(err)
+
+	expected := map[string]string{
+		"error.kind":        "*errors.errorString",
+		"error.message":     err.Error(),
+		"error.fingerprint": Fingerprint(err),
+	}
+	for k, v := range expected {
+		if actual := attrs[k]; actual != v {
+			t.Errorf(expectedFormat, k+": "+v, k+": "+actual)
+		}
+	}
+	if stack := attrs["error.stack"]; !strings.HasPrefix(stack, "goroutine 1 [running]:") {
+		t.Errorf(expectedFormat, "goroutine 1 [running]:", stack)
+	}
+}
+
+func Test_DatadogAttributes_UserError(t *testing.T) {
+	attrs := DatadogAttributes(User("MISSING_NAME", "Please provide a name."))
+
+	if expected := "*fault.UserError"; attrs["error.kind"] != expected {
+		t.Errorf(expectedFormat, expected, attrs["error.kind"])
+	}
+	if _, ok := attrs["error.stack"]; ok {
+		t.Errorf(expectedFormat, "no stack", attrs["error.stack"])
+	}
+}
@@ -0,0 +1,52 @@
+package fault
+
+import (
+	"fmt"
+	"strings"
+)
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// RenderDOT renders the full cause tree of the error (see RenderTree) as a Graphviz
+// DOT graph, so that complex failures can be visualized, e.g. with `dot -Tsvg`.
+// Each error is a node with an edge to each of its causes. Suppressed errors
+// are dashed nodes with a dashed edge. It returns an empty string if the error is nil.
+//
+//	Example:
+//	   digraph fault {
+//	      node [shape=box];
+//	      n0 [label="failed to import users"];
+//	      n1 [label="2 items failed"];
+//	      n0 -> n1;
+//	      ...
+//	   }
+func RenderDOT(err error) string {
+	if err == nil {
+		return ""
+	}
+	sb := strings.Builder{}
+	sb.WriteString("digraph fault {\n")
+	sb.WriteString("\tnode [shape=box];\n")
+	next := 0
+	renderDOTNode(&sb, buildTree(err), &next)
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// renderDOTNode writes the node and its descendants and returns the ID of the node.
+func renderDOTNode(sb *strings.Builder, node treeNode, next *int) string {
+	id := fmt.Sprintf("n%d", *next)
+	*next++
+	sb.WriteString(fmt.Sprintf("\t%s [label=\"%s\"];\n", id, dotEscaper.Replace(node.label)))
+	for _, s := range node.suppressed {
+		suppressedID := fmt.Sprintf("n%d", *next)
+		*next++
+		sb.WriteString(fmt.Sprintf("\t%s [label=\"%s\", style=dashed];\n", suppressedID, dotEscaper.Replace(s)))
+		sb.WriteString(fmt.Sprintf("\t%s -> %s [style=dashed, label=\"suppressed\"];\n", id, suppressedID))
+	}
+	for _, child := range node.children {
+		childID := renderDOTNode(sb, child, next)
+		sb.WriteString(fmt.Sprintf("\t%s -> %s;\n", id, childID))
+	}
+	return id
+}
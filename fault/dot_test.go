@@ -0,0 +1,39 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_RenderDOT_Nil(t *testing.T) {
+	if actual := RenderDOT(nil); actual != "" {
+		t.Errorf(expectedFormat, "", actual)
+	}
+}
+
+func Test_RenderDOT(t *testing.T) {
+	joined := joinedError{
+		errors.New(`invalid "name"`),
+		suppressingError{
+			error:      errors.New("failed to write"),
+			suppressed: []error{errors.New("failed to close")},
+		},
+	}
+	err := SystemWrap(joined, "failed to save")
+
+	expected := "digraph fault {\n" +
+		"\tnode [shape=box];\n" +
+		"\tn0 [label=\"failed to save\"];\n" +
+		"\tn1 [label=\"2 errors\"];\n" +
+		"\tn2 [label=\"invalid \\\"name\\\"\"];\n" +
+		"\tn1 -> n2;\n" +
+		"\tn3 [label=\"failed to write\"];\n" +
+		"\tn4 [label=\"failed to close\", style=dashed];\n" +
+		"\tn3 -> n4 [style=dashed, label=\"suppressed\"];\n" +
+		"\tn1 -> n3;\n" +
+		"\tn0 -> n1;\n" +
+		"}\n"
+	if actual := RenderDOT(err); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
@@ -0,0 +1,44 @@
+package fault
+
+import "sync/atomic"
+
+// DuplicatePolicy decides what happens when a user error gets added
+// to a UserError which contains an error with the same code already.
+type DuplicatePolicy int
+
+const (
+	// DuplicateOverwrite replaces the message of the existing error.
+	// It is the default policy.
+	DuplicateOverwrite DuplicatePolicy = iota
+
+	// DuplicateAppend adds a second error with the same code,
+	// so that both messages are retained.
+	DuplicateAppend
+
+	// DuplicatePanic panics, for applications which
+	// consider duplicate codes a programming error.
+	DuplicatePanic
+)
+
+var currentDuplicatePolicy atomic.Value
+
+func init() {
+	currentDuplicatePolicy.Store(DuplicateOverwrite)
+}
+
+// SetDuplicatePolicy sets the policy which applies when a code gets added to a UserError
+// which contains it already (see UserError.Add) and returns a function which restores the
+// previous policy. Restoring errors via Decode or gob retains duplicates regardless of the policy.
+//
+//	Example:
+//	   fault.SetDuplicatePolicy(fault.DuplicatePanic)
+func SetDuplicatePolicy(p DuplicatePolicy) (restore func()) {
+	previous := currentDuplicatePolicy.Swap(p)
+	return func() {
+		currentDuplicatePolicy.Store(previous)
+	}
+}
+
+func duplicatePolicy() DuplicatePolicy {
+	return currentDuplicatePolicy.Load().(DuplicatePolicy)
+}
@@ -0,0 +1,53 @@
+package fault
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Add_WithDuplicateOverwrite(t *testing.T) {
+	err := User("MISSING_NAME", "Please provide a name.")
+	err.Add("MISSING_NAME", "Please provide your name.")
+
+	expected := "Please provide your name. (MISSING_NAME)"
+	if actual := err.Error(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := len(err.Codes()); actual != 1 {
+		t.Errorf(expectedFormat, "1", fmt.Sprint(actual))
+	}
+}
+
+func Test_Add_WithDuplicateAppend(t *testing.T) {
+	restore := SetDuplicatePolicy(DuplicateAppend)
+	defer restore()
+
+	err := User("INVALID_TAG", "'a b' is not a valid tag.")
+	err.Add("INVALID_TAG", "'c d' is not a valid tag.")
+
+	expected := "- 'a b' is not a valid tag. (INVALID_TAG)\n- 'c d' is not a valid tag. (INVALID_TAG)"
+	if actual := err.Error(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := err.Errors()["INVALID_TAG"]; actual != "'a b' is not a valid tag." {
+		t.Errorf(expectedFormat, "'a b' is not a valid tag.", actual)
+	}
+
+	decoded := Decode(Encode(err))
+	if actual := decoded.Error(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Add_WithDuplicatePanic(t *testing.T) {
+	restore := SetDuplicatePolicy(DuplicatePanic)
+	defer restore()
+	err := User("MISSING_NAME", "Please provide a name.")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Adding a duplicate code was expected to panic.")
+		}
+	}()
+	err.Add("MISSING_NAME", "Please provide your name.")
+}
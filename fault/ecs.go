@@ -0,0 +1,67 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ECSFields returns the error fields of the Elastic Common Schema (ECS) which describe
+// the error, so that it can be logged in a shape which the error views of Kibana
+// understand without an ingest pipeline.
+//
+// The fields are keyed by their dotted ECS names:
+//
+//   - error.type is the type of the error which caused the fault (see CauseType)
+//   - error.code is the kind of a SystemError or the codes of a UserError
+//   - error.message is the error message
+//   - error.stack_trace is the stack trace of a SystemError
+//   - labels.<key> are the fields which have been attached to the SystemErrors of the chain
+//
+// Labels are stringified, since ECS only allows keyword values, and dots in their
+// keys are replaced by underscores. The message and labels are scrubbed by the
+// DefaultScrubber. It returns nil if the error is nil.
+//
+//	Example:
+//	   logger.Error("request failed", zap.Any("ecs", fault.ECSFields(err)))
+func ECSFields(err error) map[string]interface{} {
+	if err == nil {
+		return nil
+	}
+	fields := map[string]interface{}{
+		"error.type":    CauseType(err),
+		"error.message": Scrub(err.Error()),
+	}
+	var userErr *UserError
+	var sysErr *SystemError
+	switch {
+	case errors.As(err, &userErr):
+		fields["error.code"] = strings.Join(userErr.codes, ",")
+	case errors.As(err, &sysErr):
+		fields["error.code"] = sysErr.Kind().String()
+		fields["error.stack_trace"] = sysErr.ExceptionStackTrace()
+		for k, v := range ScrubFields(sysErr.Fields()) {
+			fields["labels."+strings.ReplaceAll(k, ".", "_")] = fmt.Sprint(v)
+		}
+	default:
+		fields["error.code"] = Internal.String()
+	}
+	return fields
+}
+
+// CauseType returns the type name of the error which caused the fault, which is
+// the outermost error of the chain that is neither a SystemError nor an annotation
+// of fmt.Errorf (e.g. *net.OpError). Error reporters use it as the error class.
+func CauseType(err error) string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		// nolint: errorlint // Walking the chain manually:
+		if _, ok := e.(*SystemError); ok {
+			continue
+		}
+		name := fmt.Sprintf("%T", e)
+		if name != "*fmt.wrapError" && name != "*fmt.wrapErrors" {
+			return name
+		}
+	}
+	return fmt.Sprintf("%T", err)
+}
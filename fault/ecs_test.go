@@ -0,0 +1,65 @@
+package fault
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func Test_ECSFields_Nil(t *testing.T) {
+	if actual := ECSFields(nil); actual != nil {
+		t.Errorf(expectedFormat, "nil", fmt.Sprint(actual))
+	}
+}
+
+func Test_ECSFields_SystemError(t *testing.T) {
+	cause := &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+	err := SystemWrap(cause, "failed to load config").
+		WithKind(NotFound).
+		WithField("service.name", "api").
+		WithField("attempt", 2)
+
+	fields := ECSFields(err)
+
+	expected := map[string]interface{}{
+		"error.type":          "*fs.PathError",
+		"error.code":          "not_found",
+		"error.message":       err.Error(),
+		"labels.service_name": "api",
+		"labels.attempt":      "2",
+	}
+	for k, v := range expected {
+		if actual := fields[k]; actual != v {
+			t.Errorf(expectedFormat, k+": "+fmt.Sprint(v), k+": "+fmt.Sprint(actual))
+		}
+	}
+	stackTrace, _ := fields["error.stack_trace"].(string)
+	if !strings.Contains(stackTrace, "Test_ECSFields_SystemError") {
+		t.Errorf(expectedFormat, "stack trace of the test", stackTrace)
+	}
+}
+
+func Test_ECSFields_UserError(t *testing.T) {
+	err := User("MISSING_NAME", "Please provide a name.")
+	err.Add("MISSING_EMAIL", "Please provide an email address.")
+
+	fields := ECSFields(err)
+
+	expected := "MISSING_NAME,MISSING_EMAIL"
+	if actual := fields["error.code"]; actual != expected {
+		t.Errorf(expectedFormat, expected, fmt.Sprint(actual))
+	}
+	if _, ok := fields["error.stack_trace"]; ok {
+		t.Errorf(expectedFormat, "no stack trace", fmt.Sprint(fields["error.stack_trace"]))
+	}
+}
+
+func Test_ECSFields_Type_SkipsAnnotations(t *testing.T) {
+	err := Errorf("failed to load config: %w", System("boom"))
+
+	expected := "*errors.errorString"
+	if actual := ECSFields(err)["error.type"]; actual != expected {
+		t.Errorf(expectedFormat, expected, fmt.Sprint(actual))
+	}
+}
@@ -0,0 +1,329 @@
+package fault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dusted-go/fault/stack"
+)
+
+const (
+	linkSystem = "system"
+	linkUser   = "user"
+	linkError  = "error"
+)
+
+type encodedEntry struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+	Params  Params `json:"params,omitempty"`
+}
+
+// encodedLink is a single error of an encoded chain.
+type encodedLink struct {
+	Type       string                 `json:"type"`
+	Message    string                 `json:"message,omitempty"`
+	Messages   []string               `json:"messages,omitempty"`
+	Errors     []encodedEntry         `json:"errors,omitempty"`
+	Kind       Kind                   `json:"kind,omitempty"`
+	Op         Op                     `json:"op,omitempty"`
+	Severity   Severity               `json:"severity,omitempty"`
+	Fields     map[string]interface{} `json:"fields,omitempty"`
+	Stack      string                 `json:"stack,omitempty"`
+	Retryable  *bool                  `json:"retryable,omitempty"`
+	RetryAfter time.Duration          `json:"retry_after,omitempty"`
+}
+
+type encodedChain struct {
+	Chain []encodedLink `json:"chain"`
+}
+
+// decodedError is a restored error of an encoded chain which is neither a UserError nor a SystemError.
+type decodedError struct {
+	msg string
+	err error
+}
+
+func (e *decodedError) Error() string {
+	return e.msg
+}
+
+func (e *decodedError) Unwrap() error {
+	return e.err
+}
+
+// Encode serializes the entire chain of the error, so that it can be transferred
+// to another process and be restored by Decode. It returns nil if the error is nil.
+//
+// For each error of the chain the type (UserError, SystemError or any other error) and
+// message get encoded. UserErrors retain their codes, messages, input fields and params.
+// SystemErrors which wrap each other are encoded once, with the messages and formatted stack
+// trace of the outermost one as well as their kind, op, severity, fields and retry classification.
+// Field values get encoded as JSON and fall back to their string representation.
+// Messages, fields, params and stack traces are scrubbed by the DefaultScrubber.
+//
+//	Example:
+//	   payload := fault.Encode(err)
+//	   ...
+//	   err := fault.Decode(payload)
+func Encode(err error) []byte {
+	return EncodeWith(err, json.Marshal)
+}
+
+// EncodeWith serializes the entire chain of the error like Encode, but with the given
+// marshal function of another encoding (e.g. CBOR or msgpack) instead of JSON.
+// The encoding must support the json struct tags, so that the schema is the same
+// as the one of Encode. Field values which can't be marshalled fall back to their
+// string representation. If the chain can't be marshalled at all then only the
+// scrubbed error message gets encoded, as a single error without its type.
+// It returns nil if the error is nil.
+func EncodeWith(err error, marshal func(v interface{}) ([]byte, error)) []byte {
+	if err == nil {
+		return nil
+	}
+	data, marshalErr := marshal(encodeChain(err, func(v interface{}) interface{} {
+		return encodableValue(v, marshal)
+	}))
+	if marshalErr != nil {
+		data, _ = marshal(encodedChain{Chain: []encodedLink{{Type: linkError, Message: Scrub(err.Error())}}})
+	}
+	return data
+}
+
+// encodeChain encodes each error of the chain. A run of SystemErrors which wrap each
+// other is encoded as a single link (see encodeSystemRun). The value function converts
+// field and param values into values which the encoding supports.
+func encodeChain(err error, value func(v interface{}) interface{}) encodedChain {
+	var chain encodedChain
+	for err != nil {
+		// nolint: errorlint // Walking the chain manually:
+		if sysErr, ok := err.(*SystemError); ok && sysErr != nil {
+			var link encodedLink
+			link, err = encodeSystemRun(sysErr, value)
+			chain.Chain = append(chain.Chain, link)
+			continue
+		}
+		chain.Chain = append(chain.Chain, encodeLink(err, value))
+		err = errors.Unwrap(err)
+	}
+	return chain
+}
+
+// encodeSystemRun encodes the SystemError together with the SystemErrors which it wraps,
+// since the outermost one already contains their messages and stack trace. The properties
+// of the outermost SystemError take precedence, like they do for the accessors (e.g. Kind).
+// The errors which only carry the messages (see isMessageCarrier) are skipped.
+// It returns the link and the error which follows the run.
+func encodeSystemRun(e *SystemError, value func(v interface{}) interface{}) (encodedLink, error) {
+	msgs := e.Messages()
+	for i, msg := range msgs {
+		msgs[i] = Scrub(msg)
+	}
+	link := encodedLink{
+		Type:     linkSystem,
+		Messages: msgs,
+		Stack:    Scrub(e.StackTrace()),
+	}
+	fields := map[string]interface{}{}
+	for {
+		if link.Kind == "" {
+			link.Kind = e.kind
+		}
+		if link.Op == "" {
+			link.Op = e.op
+		}
+		if link.Severity == 0 {
+			link.Severity = e.severity
+		}
+		if link.Retryable == nil {
+			link.Retryable = e.retryable
+		}
+		if link.RetryAfter == 0 {
+			link.RetryAfter = e.retryAfter
+		}
+		for k, v := range e.fields {
+			if _, ok := fields[k]; !ok {
+				fields[k] = v
+			}
+		}
+
+		next := e.err
+		if next != nil && isMessageCarrier(e, next) {
+			next = errors.Unwrap(next)
+		}
+		// nolint: errorlint // Walking the chain manually:
+		inner, ok := next.(*SystemError)
+		if !ok || inner == nil {
+			if len(fields) > 0 {
+				link.Fields = map[string]interface{}{}
+				for k, v := range ScrubFields(fields) {
+					link.Fields[k] = value(v)
+				}
+			}
+			return link, next
+		}
+		e = inner
+	}
+}
+
+func encodeLink(err error, value func(v interface{}) interface{}) encodedLink {
+	// nolint: errorlint // Walking the chain manually:
+	switch e := err.(type) {
+	case *UserError:
+		link := encodedLink{Type: linkUser, RetryAfter: e.retryAfter}
+		for i, code := range e.codes {
+			entry := encodedEntry{Code: code, Message: Scrub(e.messages[i]), Field: e.fields[code]}
+			if params := ScrubFields(e.params[code]); len(params) > 0 {
+				entry.Params = Params{}
+				for k, v := range params {
+					entry.Params[k] = value(v)
+				}
+			}
+			link.Errors = append(link.Errors, entry)
+		}
+		return link
+	default:
+		return encodedLink{Type: linkError, Message: Scrub(err.Error())}
+	}
+}
+
+// encodableValue returns the value if it can be marshalled
+// or otherwise its string representation.
+func encodableValue(v interface{}, marshal func(v interface{}) ([]byte, error)) interface{} {
+	if _, err := marshal(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return v
+}
+
+// Decode restores an error chain which has been serialized by Encode.
+// It returns nil if the data is empty.
+//
+// The restored chain consists of UserErrors and SystemErrors which can be matched
+// with errors.As, and of errors with the original messages in place of any other error.
+// The stack traces of restored SystemErrors are only available as text (see RestoreSystem)
+// and field values are restored as decoded JSON values (e.g. numbers as float64).
+//
+// If the data is invalid then a SystemError which describes the decoding failure is returned.
+func Decode(data []byte) error {
+	return DecodeWith(data, json.Unmarshal)
+}
+
+// DecodeWith restores an error chain which has been serialized by EncodeWith
+// with the corresponding unmarshal function. See Decode for more details.
+// Field values are restored as the values of the encoding.
+func DecodeWith(data []byte, unmarshal func(data []byte, v interface{}) error) error {
+	if len(data) == 0 {
+		return nil
+	}
+	var chain encodedChain
+	if err := unmarshal(data, &chain); err != nil {
+		return SystemWrap(err, "failed to decode error")
+	}
+	return decodeChain(chain)
+}
+
+// decodeChain restores the errors of the chain, starting with the innermost one.
+func decodeChain(chain encodedChain) error {
+	var err error
+	for i := len(chain.Chain) - 1; i >= 0; i-- {
+		link := chain.Chain[i]
+		switch link.Type {
+		case linkUser:
+			userErr := &UserError{retryAfter: link.RetryAfter}
+			for _, e := range link.Errors {
+				userErr.add(DuplicateAppend, Code(e.Code), e.Message)
+				if e.Field != "" {
+					if userErr.fields == nil {
+						userErr.fields = map[string]string{}
+					}
+					userErr.fields[e.Code] = e.Field
+				}
+				userErr.setParams(e.Code, e.Params)
+			}
+			err = userErr
+		case linkSystem:
+			sysErr := &SystemError{
+				err:        err,
+				stack:      &stack.Trace{},
+				stackText:  link.Stack,
+				kind:       link.Kind,
+				op:         link.Op,
+				severity:   link.Severity,
+				fields:     link.Fields,
+				retryable:  link.Retryable,
+				retryAfter: link.RetryAfter,
+			}
+			for j := len(link.Messages) - 1; j >= 0; j-- {
+				sysErr.msgs = append(sysErr.msgs, link.Messages[j])
+			}
+			err = sysErr
+		default:
+			err = &decodedError{msg: link.Message, err: err}
+		}
+	}
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The JSON representation is the same as the one produced by Encode.
+func (e *UserError) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	return Encode(e), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *UserError) UnmarshalJSON(data []byte) error {
+	var userErr *UserError
+	if err := decodeAs(data, &userErr); err != nil {
+		return err
+	}
+	*e = *userErr
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The JSON representation is the same as the one produced by Encode.
+func (e *SystemError) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	return Encode(e), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The stack trace of an unmarshalled SystemError is only available
+// as text (see RestoreSystem).
+func (e *SystemError) UnmarshalJSON(data []byte) error {
+	var sysErr *SystemError
+	if err := decodeAs(data, &sysErr); err != nil {
+		return err
+	}
+	*e = *sysErr
+	return nil
+}
+
+// decodeAs decodes an encoded chain whose outermost error must be of type T.
+func decodeAs[T error](data []byte, target *T) error {
+	var chain encodedChain
+	if err := json.Unmarshal(data, &chain); err != nil {
+		return err
+	}
+	return restoreAs(decodeChain(chain), target)
+}
+
+// restoreAs assigns the restored error to the target if it's of type T.
+func restoreAs[T error](err error, target *T) error {
+	// nolint: errorlint // Only the outermost error is of interest:
+	t, ok := err.(T)
+	if !ok {
+		return fmt.Errorf("fault: expected %T, got %T", *target, err)
+	}
+	*target = t
+	return nil
+}
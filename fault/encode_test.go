@@ -0,0 +1,187 @@
+package fault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_Encode_WithNil(t *testing.T) {
+	if data := Encode(nil); data != nil {
+		t.Errorf(expectedFormat, "nil", string(data))
+	}
+	if err := Decode(nil); err != nil {
+		t.Errorf(expectedFormat, "nil", err)
+	}
+}
+
+func Test_EncodeDecode_WithChain(t *testing.T) {
+	userErr := User("a", "aaa")
+	userErr.Add("b", "bbb")
+	inner := SystemWrap(userErr, "c").
+		WithKind(NotFound).
+		WithField("id", "123")
+	outer := SystemWrap(fmt.Errorf("d: %w", inner), "e").
+		WithRetryAfter(time.Second)
+
+	decoded := Decode(Encode(outer))
+
+	if decoded.Error() != outer.Error() {
+		t.Errorf(expectedFormat, outer.Error(), decoded.Error())
+	}
+
+	var sysErr *SystemError
+	if !errors.As(decoded, &sysErr) {
+		t.Fatalf(expectedFormat, "*SystemError", decoded)
+	}
+	if sysErr.String() != outer.String() {
+		t.Errorf(expectedFormat, outer.String(), sysErr.String())
+	}
+	if retryAfter, _ := sysErr.RetryAfter(); retryAfter != time.Second {
+		t.Errorf(expectedFormat, time.Second.String(), retryAfter.String())
+	}
+	if KindOf(decoded) != NotFound {
+		t.Errorf(expectedFormat, NotFound, KindOf(decoded))
+	}
+	if sysErr.Fields()["id"] != "123" {
+		t.Errorf(expectedFormat, inner.Fields(), sysErr.Fields())
+	}
+
+	var decodedUserErr *UserError
+	if !errors.As(decoded, &decodedUserErr) {
+		t.Fatalf(expectedFormat, "*UserError", decoded)
+	}
+	if decodedUserErr.Error() != userErr.Error() {
+		t.Errorf(expectedFormat, userErr.Error(), decodedUserErr.Error())
+	}
+}
+
+func Test_Encode_WithNestedSystemErrors(t *testing.T) {
+	err := SystemWrap(errors.New("connection refused"), "failed to connect").WithField("host", "db-1")
+	for i := 0; i < 4; i++ {
+		err = SystemWrapf(err, "failed to load user (%d)", i).WithKind(Unavailable)
+	}
+
+	var chain encodedChain
+	if jsonErr := json.Unmarshal(Encode(err), &chain); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	if len(chain.Chain) != 2 {
+		t.Fatalf(expectedFormat, "2 links", fmt.Sprint(chain.Chain))
+	}
+	if sys := chain.Chain[0]; len(sys.Messages) != 6 || sys.Kind != Unavailable || sys.Fields["host"] != "db-1" {
+		t.Errorf(expectedFormat, "6 messages, unavailable, host", fmt.Sprint(sys))
+	}
+	if actual := chain.Chain[1].Message; actual != "connection refused" {
+		t.Errorf(expectedFormat, "connection refused", actual)
+	}
+	if decoded := Decode(Encode(err)); decoded.Error() != err.Error() {
+		t.Errorf(expectedFormat, err.Error(), decoded.Error())
+	}
+}
+
+func Test_EncodeDecode_WithPlainError(t *testing.T) {
+	err := fmt.Errorf("a: %w", errors.New("b"))
+
+	decoded := Decode(Encode(err))
+
+	if decoded.Error() != err.Error() {
+		t.Errorf(expectedFormat, err.Error(), decoded.Error())
+	}
+	if inner := errors.Unwrap(decoded); inner == nil || inner.Error() != "b" {
+		t.Errorf(expectedFormat, "b", inner)
+	}
+}
+
+func Test_EncodeDecode_WithUserFieldsAndParams(t *testing.T) {
+	userErr := UserField("email", "EMAIL_TAKEN", "The email address is taken.")
+	userErr.AddParams("INVALID_ITEMS", "{count, plural, one {# item is} other {# items are}} invalid.", Params{"count": 2})
+
+	var decoded *UserError
+	if !errors.As(Decode(Encode(userErr)), &decoded) {
+		t.Fatal("The decoded error was expected to be a UserError.")
+	}
+
+	if actual := decoded.Field("EMAIL_TAKEN"); actual != "email" {
+		t.Errorf(expectedFormat, "email", actual)
+	}
+	c := Catalog{"de": {"INVALID_ITEMS": "{count, plural, one {# Eintrag ist} other {# Einträge sind}} ungültig."}}
+	if actual := decoded.Localize(c, "de").ErrorMessages()[1]; actual != "2 Einträge sind ungültig." {
+		t.Errorf(expectedFormat, "2 Einträge sind ungültig.", actual)
+	}
+}
+
+func Test_Decode_WithInvalidData(t *testing.T) {
+	err := Decode([]byte("foo"))
+
+	var sysErr *SystemError
+	if !errors.As(err, &sysErr) {
+		t.Errorf(expectedFormat, "*SystemError", err)
+	}
+}
+
+func Test_JSON_WithUserError(t *testing.T) {
+	userErr := User("a", "aaa")
+
+	data, err := json.Marshal(userErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual UserError
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	if actual.Error() != userErr.Error() {
+		t.Errorf(expectedFormat, userErr.Error(), actual.Error())
+	}
+}
+
+func Test_JSON_WithMismatchingType(t *testing.T) {
+	data, err := json.Marshal(User("a", "aaa"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual SystemError
+	if err := json.Unmarshal(data, &actual); err == nil {
+		t.Error("Unmarshalling a UserError into a SystemError was expected to fail.")
+	}
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add(Encode(SystemWrap(User("a", "aaa"), "b").WithField("c", 1)))
+	f.Add([]byte(`{"chain":[{"type":"error"}]}`))
+	f.Add([]byte(`{"chain":null}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := Decode(data); err != nil {
+			_ = fmt.Sprintf("%+v", err)
+		}
+	})
+}
+
+func Test_EncodeWith_WithMarshalError(t *testing.T) {
+	marshal := func(v interface{}) ([]byte, error) {
+		if chain, ok := v.(encodedChain); ok && len(chain.Chain) > 1 {
+			return nil, errors.New("chain too deep")
+		}
+		return json.Marshal(v)
+	}
+	err := SystemWrap(User("NOT_FOUND", "Not found."), "failed to load user")
+
+	data := EncodeWith(err, marshal)
+	if data == nil {
+		t.Fatal("The encoded error was expected to not be nil.")
+	}
+	decoded := Decode(data)
+	if decoded == nil {
+		t.Fatal("The decoded error was expected to not be nil.")
+	}
+	if decoded.Error() != err.Error() {
+		t.Errorf(expectedFormat, err.Error(), decoded.Error())
+	}
+}
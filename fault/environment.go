@@ -0,0 +1,69 @@
+package fault
+
+import (
+	"os"
+	"runtime"
+	"sync/atomic"
+)
+
+// environmentVariables maps the environment variables which identify the
+// container and Kubernetes pod of the process to the names of their fields.
+// The Kubernetes variables are commonly populated via the Downward API.
+var environmentVariables = []struct {
+	env   string
+	field string
+}{
+	{"CONTAINER_ID", "container.id"},
+	{"CONTAINER_NAME", "container.name"},
+	{"POD_NAME", "k8s.pod.name"},
+	{"POD_NAMESPACE", "k8s.namespace.name"},
+	{"NODE_NAME", "k8s.node.name"},
+}
+
+// EnvironmentFields returns fields which describe the runtime environment of the process:
+// the operating system (os.type), architecture (host.arch) and Go version
+// (process.runtime.version), as well as the container and Kubernetes identifiers
+// from the CONTAINER_ID, CONTAINER_NAME, POD_NAME, POD_NAMESPACE and NODE_NAME
+// environment variables, if set. The field names follow the OpenTelemetry
+// semantic conventions.
+func EnvironmentFields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"os.type":                 runtime.GOOS,
+		"host.arch":               runtime.GOARCH,
+		"process.runtime.version": runtime.Version(),
+	}
+	for _, v := range environmentVariables {
+		if value := os.Getenv(v.env); value != "" {
+			fields[v.field] = value
+		}
+	}
+	return fields
+}
+
+type environmentFieldsHolder struct {
+	fields map[string]interface{}
+}
+
+var currentEnvironmentFields atomic.Value
+
+func init() {
+	currentEnvironmentFields.Store(environmentFieldsHolder{})
+}
+
+// SetEnvironmentFields sets fields which get attached to every new SystemError which
+// doesn't wrap another SystemError, so that error reports can be sliced by environment,
+// and returns a function which restores the previous fields. Nil fields disable it.
+// The fields must not be modified afterwards.
+//
+//	Example:
+//	   fault.SetEnvironmentFields(fault.EnvironmentFields())
+func SetEnvironmentFields(fields map[string]interface{}) (restore func()) {
+	previous := currentEnvironmentFields.Swap(environmentFieldsHolder{fields})
+	return func() {
+		currentEnvironmentFields.Store(previous)
+	}
+}
+
+func environmentFields() map[string]interface{} {
+	return currentEnvironmentFields.Load().(environmentFieldsHolder).fields
+}
@@ -0,0 +1,44 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func Test_EnvironmentFields(t *testing.T) {
+	t.Setenv("POD_NAME", "api-7d9f")
+	t.Setenv("POD_NAMESPACE", "")
+
+	fields := EnvironmentFields()
+
+	if fields["os.type"] != runtime.GOOS || fields["host.arch"] != runtime.GOARCH {
+		t.Errorf(expectedFormat, runtime.GOOS+" "+runtime.GOARCH, fmt.Sprint(fields))
+	}
+	if fields["k8s.pod.name"] != "api-7d9f" {
+		t.Errorf(expectedFormat, "api-7d9f", fmt.Sprint(fields["k8s.pod.name"]))
+	}
+	if _, ok := fields["k8s.namespace.name"]; ok {
+		t.Errorf(expectedFormat, "no namespace", fmt.Sprint(fields))
+	}
+}
+
+func Test_SetEnvironmentFields(t *testing.T) {
+	restore := SetEnvironmentFields(map[string]interface{}{"k8s.pod.name": "api-7d9f"})
+	inner := SystemWrap(errors.New("connection refused"), "failed to connect").
+		WithField("k8s.pod.name", "overridden")
+	outer := SystemWrap(inner, "failed to load user")
+	restore()
+	after := System("failed to connect")
+
+	if actual := fmt.Sprint(inner.Fields()); actual != "map[k8s.pod.name:overridden]" {
+		t.Errorf(expectedFormat, "map[k8s.pod.name:overridden]", actual)
+	}
+	if len(outer.fields) > 0 {
+		t.Errorf(expectedFormat, "no fields on the outer error", fmt.Sprint(outer.fields))
+	}
+	if len(after.Fields()) > 0 {
+		t.Errorf(expectedFormat, "no fields after restoring", fmt.Sprint(after.Fields()))
+	}
+}
@@ -0,0 +1,116 @@
+package fault
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Equal reports whether two errors are deeply equal: whether their chains have the same
+// structure and each pair of corresponding errors has the same type and details. The details
+// are the codes, messages, fields and params of a UserError, the messages, kind, op, severity,
+// fields and retry classification of a SystemError and the message of any other error.
+// Stack traces and breadcrumbs are not compared, since they differ between two otherwise
+// identical failures.
+//
+// Use Equal rather than comparing the results of Error(), which omits
+// most details and is subject to the Limits.
+func Equal(a, b error) bool {
+	return Diff(a, b) == ""
+}
+
+// Diff returns a human readable description of the differences between two errors,
+// one per line, or an empty string if they are equal (see Equal). Each difference
+// is prefixed with the path of the error within the chain, where "err" is the
+// error itself and "err.cause[0]" its first cause.
+//
+//	Example:
+//	   err.cause[0] kind: "unavailable" != "timeout"
+//	   err.cause[0] fields: "map[host:db-1]" != "map[host:db-2]"
+func Diff(a, b error) string {
+	var diffs []string
+	diffErrors("err", a, b, &diffs)
+	return strings.Join(diffs, "\n")
+}
+
+func diffErrors(path string, a, b error, diffs *[]string) {
+	typeA, typeB := fmt.Sprintf("%T", a), fmt.Sprintf("%T", b)
+	if typeA != typeB {
+		*diffs = append(*diffs, fmt.Sprintf("%s type: %s != %s", path, typeA, typeB))
+		return
+	}
+	// Only the first property ("nil") is compared if one is a typed nil:
+	propsA, propsB := equalProperties(a), equalProperties(b)
+	for i := 0; i < len(propsA) && i < len(propsB); i++ {
+		if propsA[i].value != propsB[i].value {
+			*diffs = append(*diffs, fmt.Sprintf("%s %s: %q != %q",
+				path, propsA[i].name, propsA[i].value, propsB[i].value))
+		}
+	}
+
+	var causesA, causesB []error
+	if a != nil {
+		causesA, causesB = unwrapAll(a), unwrapAll(b)
+	}
+	if len(causesA) != len(causesB) {
+		*diffs = append(*diffs, fmt.Sprintf("%s causes: %d != %d", path, len(causesA), len(causesB)))
+		return
+	}
+	for i := range causesA {
+		diffErrors(fmt.Sprintf("%s.cause[%d]", path, i), causesA[i], causesB[i], diffs)
+	}
+}
+
+// equalProperty is a detail of an error which is compared by Equal.
+type equalProperty struct {
+	name  string
+	value string
+}
+
+// equalProperties returns the details of the error which are compared by Equal.
+// Errors of the same type always have the same properties in the same order.
+func equalProperties(err error) []equalProperty {
+	// nolint: errorlint // Comparing the error itself, not its chain:
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *UserError:
+		if e == nil {
+			return []equalProperty{{name: "nil", value: "true"}}
+		}
+		entries := make([]string, len(e.codes))
+		for i, code := range e.codes {
+			entries[i] = fmt.Sprintf("%s: %s", code, e.messages[i])
+		}
+		return []equalProperty{
+			{name: "nil", value: "false"},
+			{name: "errors", value: fmt.Sprint(entries)},
+			{name: "fields", value: fmt.Sprint(e.fields)},
+			{name: "params", value: fmt.Sprint(e.params)},
+			{name: "retry after", value: e.retryAfter.String()},
+		}
+	case *SystemError:
+		if e == nil {
+			return []equalProperty{{name: "nil", value: "true"}}
+		}
+		msgs := make([]string, len(e.msgs))
+		for i, msg := range e.msgs {
+			msgs[len(msgs)-1-i] = msg
+		}
+		retryable := "unset"
+		if e.retryable != nil {
+			retryable = fmt.Sprint(*e.retryable)
+		}
+		return []equalProperty{
+			{name: "nil", value: "false"},
+			{name: "messages", value: fmt.Sprintf("%q", msgs)},
+			{name: "kind", value: string(e.kind)},
+			{name: "op", value: string(e.op)},
+			{name: "severity", value: e.severity.String()},
+			{name: "fields", value: fmt.Sprint(e.fields)},
+			{name: "retryable", value: retryable},
+			{name: "retry after", value: e.retryAfter.String()},
+		}
+	default:
+		return []equalProperty{{name: "message", value: err.Error()}}
+	}
+}
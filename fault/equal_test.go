@@ -0,0 +1,55 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func newEqualTestError(host string) error {
+	cause := SystemWrap(errors.New("connection refused"), "failed to connect").
+		WithKind(Unavailable).
+		WithField("host", host)
+	return SystemWrap(cause, "failed to load user")
+}
+
+func Test_Equal(t *testing.T) {
+	testCases := []struct {
+		a, b     error
+		expected bool
+	}{
+		{nil, nil, true},
+		{nil, errors.New("a"), false},
+		{errors.New("a"), errors.New("a"), true},
+		{errors.New("a"), errors.New("b"), false},
+		{newEqualTestError("db-1"), newEqualTestError("db-1"), true},
+		{newEqualTestError("db-1"), newEqualTestError("db-2"), false},
+		{User("MISSING_NAME", "Please provide a name."), User("MISSING_NAME", "Please provide a name."), true},
+		{User("MISSING_NAME", "Please provide a name."), User("MISSING_EMAIL", "Please provide a name."), false},
+		{User("MISSING_NAME", "Please provide a name."), UserField("name", "MISSING_NAME", "Please provide a name."), false},
+		{User("MISSING_NAME", "Please provide a name."), (*UserError)(nil), false},
+		{System("a"), errors.New("a"), false},
+		{System("a"), System("a").WithSeverity(SeverityCritical), false},
+	}
+	for i, tc := range testCases {
+		if actual := Equal(tc.a, tc.b); actual != tc.expected {
+			t.Errorf("case %d: %s", i, fmt.Sprintf(expectedFormat, fmt.Sprint(tc.expected), fmt.Sprint(actual)))
+		}
+	}
+}
+
+func Test_Diff(t *testing.T) {
+	expected := `err.cause[0].cause[0] fields: "map[host:db-1]" != "map[host:db-2]"`
+	if actual := Diff(newEqualTestError("db-1"), newEqualTestError("db-2")); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+
+	expected = "err type: *fault.SystemError != *errors.errorString"
+	if actual := Diff(System("a"), errors.New("a")); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+
+	if actual := Diff(newEqualTestError("db-1"), newEqualTestError("db-1")); actual != "" {
+		t.Errorf(expectedFormat, "", actual)
+	}
+}
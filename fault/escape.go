@@ -0,0 +1,25 @@
+package fault
+
+import "html"
+
+// EscapeHTML returns a copy of the UserError whose messages have been HTML-escaped.
+//
+// Messages frequently echo user input (e.g. "'<script>' is not a valid name"),
+// so they must be escaped before they get written into HTML by means which
+// don't escape automatically, like text/template or string concatenation.
+func (e *UserError) EscapeHTML() *UserError {
+	if e == nil {
+		return nil
+	}
+	escaped := &UserError{
+		codes:      append([]string(nil), e.codes...),
+		messages:   make([]string, len(e.messages)),
+		retryAfter: e.retryAfter,
+		fields:     e.fields,
+		params:     e.params,
+	}
+	for i, msg := range e.messages {
+		escaped.messages[i] = html.EscapeString(msg)
+	}
+	return escaped
+}
@@ -0,0 +1,15 @@
+package fault
+
+import "testing"
+
+func Test_EscapeHTML(t *testing.T) {
+	err := User("INVALID_NAME", "'<script>' is not a valid name.")
+
+	expected := "&#39;&lt;script&gt;&#39; is not a valid name. (INVALID_NAME)"
+	if actual := err.EscapeHTML().Error(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := err.ErrorMessages()[0]; actual != "'<script>' is not a valid name." {
+		t.Error("EscapeHTML() was not expected to modify the original UserError.")
+	}
+}
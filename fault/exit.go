@@ -0,0 +1,115 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ExitCodeMapper maps user error codes and kinds to process exit codes.
+type ExitCodeMapper struct {
+	// UserCodes maps user error codes to exit codes.
+	// If a UserError contains multiple mapped codes then the first one wins.
+	UserCodes map[Code]int
+
+	// Kinds maps kinds of a SystemError to exit codes.
+	Kinds map[Kind]int
+
+	// UserFallback is the exit code of a UserError without a mapped code.
+	// Defaults to 2, the exit code for invalid usage.
+	UserFallback int
+
+	// Fallback is the exit code of any other error without a mapped kind.
+	// Defaults to 1.
+	Fallback int
+}
+
+// DefaultExitKinds maps the kinds of the fault package to their closest exit codes of sysexits.h.
+// Unclassified errors fall back to the exit code 1.
+var DefaultExitKinds = map[Kind]int{
+	Canceled:          130, // Terminated by Ctrl-C
+	Timeout:           75,  // EX_TEMPFAIL
+	Unavailable:       69,  // EX_UNAVAILABLE
+	NotFound:          66,  // EX_NOINPUT
+	PermissionDenied:  77,  // EX_NOPERM
+	Unauthenticated:   77,  // EX_NOPERM
+	ResourceExhausted: 75,  // EX_TEMPFAIL
+	InvalidArgument:   2,
+	Bug:               70, // EX_SOFTWARE
+}
+
+// DefaultExitCodeMapper is the ExitCodeMapper used by ExitCode and Exit.
+var DefaultExitCodeMapper = &ExitCodeMapper{Kinds: DefaultExitKinds}
+
+// ExitCode returns the process exit code for the error, which is 0 if the error is nil.
+func (m *ExitCodeMapper) ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var userErr *UserError
+	if errors.As(err, &userErr) {
+		for _, code := range userErr.Codes() {
+			if exitCode, ok := m.UserCodes[code]; ok {
+				return exitCode
+			}
+		}
+		if m.UserFallback != 0 {
+			return m.UserFallback
+		}
+		return 2
+	}
+
+	if exitCode, ok := m.Kinds[KindOf(err)]; ok {
+		return exitCode
+	}
+	if m.Fallback != 0 {
+		return m.Fallback
+	}
+	return 1
+}
+
+// ExitCode returns the process exit code for the error using the DefaultExitCodeMapper.
+func ExitCode(err error) int {
+	return DefaultExitCodeMapper.ExitCode(err)
+}
+
+var (
+	osExit           = os.Exit
+	stderr io.Writer = os.Stderr
+)
+
+// Exit writes the error to stderr and terminates the program with the exit code of
+// the DefaultExitCodeMapper. The program exits with 0 if the error is nil.
+//
+// A UserError is written as its friendly message, whereas a SystemError
+// is written with its message chain and stack trace. The output is colored
+// if stderr supports it (see ColorEnabled).
+//
+//	Example:
+//	   func main() {
+//	      fault.Exit(run(os.Args[1:]))
+//	   }
+func Exit(err error) {
+	writeExitError(stderr, err, ColorEnabled(stderr))
+	osExit(ExitCode(err))
+}
+
+func writeExitError(w io.Writer, err error, color bool) {
+	if err == nil {
+		return
+	}
+	var userErr *UserError
+	var sysErr *SystemError
+	switch {
+	case errors.As(err, &userErr):
+		fmt.Fprintf(w, "%s\n", colorize(userErr.FriendlyError(), ansiYellow, color))
+	case errors.As(err, &sysErr):
+		fmt.Fprintf(w, "%s\n%s\n",
+			colorize(err.Error(), ansiBold+ansiRed, color),
+			colorize(sysErr.StackTrace(), ansiDim, color))
+	default:
+		fmt.Fprintf(w, "%s\n", colorize(err.Error(), ansiBold+ansiRed, color))
+	}
+}
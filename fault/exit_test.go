@@ -0,0 +1,94 @@
+package fault
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_ExitCode(t *testing.T) {
+	mapper := &ExitCodeMapper{
+		UserCodes: map[Code]int{"TEST_EXIT_CONFLICT": 9},
+		Kinds:     DefaultExitKinds,
+	}
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{nil, 0},
+		{errors.New("a"), 1},
+		{System("a"), 1},
+		{System("a").WithKind(NotFound), 66},
+		{fmt.Errorf("b: %w", System("a").WithKind(Canceled)), 130},
+		{User("TEST_EXIT_INVALID", "a"), 2},
+		{User("TEST_EXIT_CONFLICT", "a"), 9},
+	}
+	for _, test := range tests {
+		if actual := mapper.ExitCode(test.err); actual != test.expected {
+			t.Errorf(expectedFormat, fmt.Sprint(test.expected), fmt.Sprint(actual))
+		}
+	}
+}
+
+func Test_ExitCode_WithFallbacks(t *testing.T) {
+	mapper := &ExitCodeMapper{UserFallback: 3, Fallback: 4}
+
+	if actual := mapper.ExitCode(User("A", "a")); actual != 3 {
+		t.Errorf(expectedFormat, "3", fmt.Sprint(actual))
+	}
+	if actual := mapper.ExitCode(System("a").WithKind(NotFound)); actual != 4 {
+		t.Errorf(expectedFormat, "4", fmt.Sprint(actual))
+	}
+}
+
+func Test_Exit(t *testing.T) {
+	buf := &bytes.Buffer{}
+	exitCode := -1
+	restoreStderr, restoreExit := stderr, osExit
+	stderr, osExit = buf, func(code int) { exitCode = code }
+	defer func() {
+		stderr, osExit = restoreStderr, restoreExit
+	}()
+
+	Exit(User("A", "Please provide a name."))
+	if buf.String() != "Please provide a name.\n" || exitCode != 2 {
+		t.Errorf(expectedFormat, "Please provide a name.\n (2)", fmt.Sprintf("%s (%d)", buf, exitCode))
+	}
+
+	buf.Reset()
+	Exit(SystemWrap(errors.New("a"), "b"))
+	if !strings.HasPrefix(buf.String(), "b\n   a\n\nat ") || exitCode != 1 {
+		t.Errorf(expectedFormat, "b\n   a\n\nat ... (1)", fmt.Sprintf("%s (%d)", buf, exitCode))
+	}
+
+	buf.Reset()
+	Exit(nil)
+	if buf.String() != "" || exitCode != 0 {
+		t.Errorf(expectedFormat, " (0)", fmt.Sprintf("%s (%d)", buf, exitCode))
+	}
+}
+
+func Test_Exit_WithColor(t *testing.T) {
+	t.Setenv("FORCE_COLOR", "1")
+	t.Setenv("NO_COLOR", "")
+	buf := &bytes.Buffer{}
+	restoreStderr, restoreExit := stderr, osExit
+	stderr, osExit = buf, func(int) {}
+	defer func() {
+		stderr, osExit = restoreStderr, restoreExit
+	}()
+
+	Exit(User("A", "Please provide a name."))
+	expected := "\x1b[33mPlease provide a name.\x1b[0m\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+
+	buf.Reset()
+	Exit(SystemWrap(errors.New("a"), "b"))
+	if actual := buf.String(); !strings.HasPrefix(actual, "\x1b[1m\x1b[31mb\n   a\x1b[0m\n\x1b[2m\nat ") {
+		t.Errorf(expectedFormat, "\x1b[1m\x1b[31mb\n   a\x1b[0m\n\x1b[2m\nat ...", actual)
+	}
+}
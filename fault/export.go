@@ -0,0 +1,72 @@
+package fault
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type codeInfoJSON struct {
+	Code        Code   `json:"code"`
+	Description string `json:"description,omitempty"`
+	Status      int    `json:"status,omitempty"`
+	GRPCCode    uint32 `json:"grpc_code,omitempty"`
+	DocURL      string `json:"doc_url,omitempty"`
+}
+
+// ExportCodesJSON writes all registered error codes as a JSON array sorted by code.
+// Zero status codes and empty fields are omitted.
+//
+//	Example:
+//	   [
+//	      {
+//	         "code": "NOT_FOUND",
+//	         "description": "The resource doesn't exist.",
+//	         "status": 404,
+//	         "doc_url": "https://example.com/errors#not-found"
+//	      }
+//	   ]
+func ExportCodesJSON(w io.Writer) error {
+	infos := RegisteredCodes()
+	codes := make([]codeInfoJSON, len(infos))
+	for i, info := range infos {
+		codes[i] = codeInfoJSON(info)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "   ")
+	return enc.Encode(codes)
+}
+
+// ExportCodesMarkdown writes all registered error codes as a Markdown table sorted by code.
+// Codes which have a DocURL are rendered as links.
+//
+//	Example:
+//	   | Code | Status | Description |
+//	   | --- | --- | --- |
+//	   | [`NOT_FOUND`](https://example.com/errors#not-found) | 404 | The resource doesn't exist. |
+func ExportCodesMarkdown(w io.Writer) error {
+	sb := strings.Builder{}
+	sb.WriteString("| Code | Status | Description |\n")
+	sb.WriteString("| --- | --- | --- |\n")
+	for _, info := range RegisteredCodes() {
+		code := fmt.Sprintf("`%s`", info.Code)
+		if info.DocURL != "" {
+			code = fmt.Sprintf("[%s](%s)", code, info.DocURL)
+		}
+		status := ""
+		if info.Status != 0 {
+			status = strconv.Itoa(info.Status)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", code, status, markdownCell(info.Description)))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(s)
+}
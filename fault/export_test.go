@@ -0,0 +1,69 @@
+package fault
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func init() {
+	RegisterCode(
+		CodeInfo{
+			Code:        "TEST_EXPORT_GONE",
+			Description: "The resource has | been deleted.",
+			Status:      410,
+			DocURL:      "https://example.com/errors#gone",
+		},
+		CodeInfo{Code: "TEST_EXPORT_INVALID", Description: "The input is invalid."},
+	)
+}
+
+func Test_ExportCodesJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := ExportCodesJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var codes []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &codes); err != nil {
+		t.Fatal(err)
+	}
+	found := 0
+	for _, c := range codes {
+		switch c["code"] {
+		case "TEST_EXPORT_GONE":
+			found++
+			if c["status"] != float64(410) || c["doc_url"] != "https://example.com/errors#gone" {
+				t.Errorf("Unexpected entry: %v", c)
+			}
+		case "TEST_EXPORT_INVALID":
+			found++
+			if _, ok := c["status"]; ok {
+				t.Errorf("Zero status was expected to be omitted: %v", c)
+			}
+		}
+	}
+	if found != 2 {
+		t.Errorf(expectedFormat, "2", fmt.Sprint(found))
+	}
+}
+
+func Test_ExportCodesMarkdown(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := ExportCodesMarkdown(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	actual := buf.String()
+	for _, expected := range []string{
+		"| Code | Status | Description |\n| --- | --- | --- |\n",
+		"| [`TEST_EXPORT_GONE`](https://example.com/errors#gone) | 410 | The resource has \\| been deleted. |\n",
+		"| `TEST_EXPORT_INVALID` |  | The input is invalid. |\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package fault
+
+// FailureClass is a machine readable failure category which allows circuit breakers
+// and load shedders to decide whether a failure indicates an unhealthy dependency.
+type FailureClass string
+
+const (
+	// NoFailure is the class of a nil error.
+	NoFailure FailureClass = "none"
+
+	// ClientFailure indicates that the caller caused the failure (e.g. a UserError,
+	// or the NotFound, Conflict, PermissionDenied, Unauthenticated and Canceled kinds).
+	// Client failures don't say anything about the health of a dependency.
+	ClientFailure FailureClass = "client"
+
+	// TransientFailure indicates that a dependency is unhealthy or overloaded
+	// (the Unavailable, Timeout and ResourceExhausted kinds).
+	TransientFailure FailureClass = "transient"
+
+	// InternalFailure indicates an unclassified failure or a bug (the Internal and Bug kinds).
+	InternalFailure FailureClass = "internal"
+)
+
+// String returns the name of the failure class.
+func (c FailureClass) String() string {
+	return string(c)
+}
+
+// Weight returns how much a failure of the class should count towards
+// tripping a circuit breaker: 1 for transient failures, 0.5 for internal
+// failures (which may hide an unclassified dependency failure) and 0 otherwise.
+func (c FailureClass) Weight() float64 {
+	switch c {
+	case TransientFailure:
+		return 1
+	case InternalFailure:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// ClassifyFailure returns the failure class of the error's chain.
+//
+//	Example:
+//	   breaker.Record(fault.ClassifyFailure(err).Weight())
+func ClassifyFailure(err error) FailureClass {
+	if err == nil {
+		return NoFailure
+	}
+	switch KindOf(err) {
+	case Unavailable, Timeout, ResourceExhausted:
+		return TransientFailure
+	case Internal, Bug:
+		return InternalFailure
+	default:
+		return ClientFailure
+	}
+}
+
+// FailureClass returns the failure class of the SystemError.
+func (e *SystemError) FailureClass() FailureClass {
+	if e == nil {
+		return NoFailure
+	}
+	return ClassifyFailure(e)
+}
+
+// FailureClass returns ClientFailure, which is the failure class of every UserError.
+func (e *UserError) FailureClass() FailureClass {
+	return ClientFailure
+}
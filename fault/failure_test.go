@@ -0,0 +1,44 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_ClassifyFailure(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected FailureClass
+		weight   float64
+	}{
+		{nil, NoFailure, 0},
+		{errors.New("a"), InternalFailure, 0.5},
+		{System("a"), InternalFailure, 0.5},
+		{User("A", "a"), ClientFailure, 0},
+		{SystemWrap(User("A", "a"), "b"), ClientFailure, 0},
+		{System("a").WithKind(NotFound), ClientFailure, 0},
+		{SystemWrap(context.Canceled, "a"), ClientFailure, 0},
+		{SystemWrap(context.DeadlineExceeded, "a"), TransientFailure, 1},
+		{fmt.Errorf("a: %w", System("b").WithKind(Unavailable)), TransientFailure, 1},
+		{System("a").WithKind(ResourceExhausted), TransientFailure, 1},
+	}
+	for _, test := range tests {
+		actual := ClassifyFailure(test.err)
+		if actual != test.expected || actual.Weight() != test.weight {
+			t.Errorf(expectedFormat,
+				fmt.Sprint(test.expected, test.weight),
+				fmt.Sprint(actual, actual.Weight()))
+		}
+	}
+}
+
+func Test_FailureClass(t *testing.T) {
+	if actual := System("a").WithKind(Timeout).FailureClass(); actual != TransientFailure {
+		t.Errorf(expectedFormat, TransientFailure, actual)
+	}
+	if actual := User("A", "a").FailureClass(); actual != ClientFailure {
+		t.Errorf(expectedFormat, ClientFailure, actual)
+	}
+}
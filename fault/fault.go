@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/dusted-go/fault/stack"
 )
@@ -29,41 +32,80 @@ import (
 //
 //	"MISSING_FIRST_NAME": "Please provide your first name"
 //	"INVALID_EMAIL_ADDR": "Please provide a valid email address"
+//
+// The methods of a nil *UserError return empty results rather than panicking,
+// so that a typed nil which has been returned as an error can still be logged.
 type UserError struct {
-	// map of error codes and messages
-	errors map[string]string
+	// codes and messages are the codes and messages of the
+	// errors in the order in which they have been added.
+	codes    []string
+	messages []string
+
+	// fields maps error codes to the input fields which they refer to.
+	fields map[string]string
+
+	// params maps error codes to the params which their messages have been formatted with.
+	params map[string]Params
 
-	// codes is used to preserve the order in which
-	// errors are being added, since a map[string]string
-	// will iterate in random order.
-	codes []string
+	retryAfter time.Duration
 }
 
 // Add appends an additional user error to the collection of errors.
-func (e *UserError) Add(code string, msg string) {
-	e.codes = append(e.codes, code)
-	e.errors[code] = msg
+// If the collection contains the code already then the DuplicatePolicy applies.
+func (e *UserError) Add(code Code, msg string) {
+	e.add(duplicatePolicy(), code, msg)
+}
+
+func (e *UserError) add(policy DuplicatePolicy, code Code, msg string) {
+	if i := e.index(code); i >= 0 {
+		switch policy {
+		case DuplicateOverwrite:
+			e.messages[i] = msg
+			return
+		case DuplicatePanic:
+			panic(fmt.Sprintf("fault: user error code %q has already been added", code))
+		}
+	}
+	e.codes = append(e.codes, string(code))
+	e.messages = append(e.messages, msg)
+}
+
+// index returns the index of the first error with the code, or -1 if there is none.
+func (e *UserError) index(code Code) int {
+	if e == nil {
+		return -1
+	}
+	for i, c := range e.codes {
+		if c == string(code) {
+			return i
+		}
+	}
+	return -1
 }
 
 // Addf appends an additional user error to the collection of errors.
-func (e *UserError) Addf(code string, format string, a ...interface{}) {
+func (e *UserError) Addf(code Code, format string, a ...interface{}) {
 	e.Add(code, fmt.Sprintf(format, a...))
 }
 
 func (e *UserError) errorMessage(includeCode bool) string {
-	if len(e.errors) == 0 {
+	if e == nil {
+		return ""
+	}
+	if len(e.codes) == 0 {
 		return ""
 	}
 	prefix := "- "
-	if len(e.errors) == 1 {
+	if len(e.codes) == 1 {
 		prefix = ""
 	}
 	sb := strings.Builder{}
-	for _, k := range e.codes {
-		v := e.errors[k]
+	for i, k := range e.codes {
+		v := e.messages[i]
 		if sb.Len() > 0 {
 			sb.WriteString("\n")
 		}
+		v = truncateMessage(v, limits().MaxMessageLength)
 		if includeCode {
 			sb.WriteString(fmt.Sprintf("%s%s (%s)", prefix, v, k))
 		} else {
@@ -124,32 +166,83 @@ func (e *UserError) FriendlyError() string {
 	return e.errorMessage(false)
 }
 
+// Flat returns all user errors on a single line separated by semicolons,
+// for log contexts and command line output where multiple lines break the
+// formatting. Line breaks and repeated whitespace within messages
+// are collapsed into single spaces.
+//
+//	Example:
+//	   First name is required (MISSING_FIRST_NAME); Invalid email address (INVALID_EMAIL_ADDRESS)
+//
+// The codes are omitted unless includeCodes is true.
+func (e *UserError) Flat(includeCodes bool) string {
+	if e == nil {
+		return ""
+	}
+	sb := strings.Builder{}
+	for i, code := range e.codes {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		msg := truncateMessage(e.messages[i], limits().MaxMessageLength)
+		sb.WriteString(strings.Join(strings.Fields(msg), " "))
+		if includeCodes {
+			sb.WriteString(fmt.Sprintf(" (%s)", code))
+		}
+	}
+	return sb.String()
+}
+
 // Errors returns a map of error codes and messages.
+// A code which has been added more than once maps to its first message.
 func (e *UserError) Errors() map[string]string {
-	return e.errors
+	if e == nil {
+		return map[string]string{}
+	}
+	errs := make(map[string]string, len(e.codes))
+	for i := len(e.codes) - 1; i >= 0; i-- {
+		errs[e.codes[i]] = e.messages[i]
+	}
+	return errs
+}
+
+// HasCode reports whether the collection of errors contains the given error code.
+func (e *UserError) HasCode(code Code) bool {
+	return e.index(code) >= 0
+}
+
+// Codes returns an array of error codes in the order in which they were added.
+func (e *UserError) Codes() []Code {
+	if e == nil {
+		return nil
+	}
+	codes := make([]Code, len(e.codes))
+	for i, code := range e.codes {
+		codes[i] = Code(code)
+	}
+	return codes
 }
 
 // ErrorMessages returns an array of error messages only.
 func (e *UserError) ErrorMessages() []string {
-	messages := make([]string, len(e.codes))
-	for i, k := range e.codes {
-		messages[i] = e.errors[k]
+	if e == nil {
+		return nil
 	}
+	messages := make([]string, len(e.messages))
+	copy(messages, e.messages)
 	return messages
 }
 
 // User creates a new UserError fault.
-func User(code string, msg string) *UserError {
+func User(code Code, msg string) *UserError {
 	return &UserError{
-		errors: map[string]string{
-			code: msg,
-		},
-		codes: []string{code},
+		codes:    []string{string(code)},
+		messages: []string{msg},
 	}
 }
 
 // Userf creates a new UserError fault.
-func Userf(code string, format string, a ...interface{}) *UserError {
+func Userf(code Code, format string, a ...interface{}) *UserError {
 	return User(code, fmt.Sprintf(format, a...))
 
 }
@@ -171,39 +264,181 @@ const (
 // - error reading from an IO stream
 // - unexpected error from making a HTTP call
 // - etc.
+//
+// The methods of a nil *SystemError return empty results rather than panicking,
+// so that a typed nil which has been returned as an error can still be logged.
 type SystemError struct {
-	err   error
-	msgs  []string
-	stack string
+	err      error
+	msgs     []string
+	stack    *stack.Trace
+	kind     Kind
+	op       Op
+	severity Severity
+	fields   map[string]interface{}
+
+	retryable  *bool
+	retryAfter time.Duration
+
+	breadcrumbs []Breadcrumb
+
+	// sites are the program counters of the locations at which the messages
+	// have been wrapped (see SystemWrap), in the same order as msgs. A zero
+	// program counter or missing entry means the message hasn't been wrapped.
+	sites []uintptr
+
+	// stackText is the formatted stack trace of a restored
+	// SystemError which has been captured by another process.
+	stackText string
 }
 
 // Error returns the error message.
 func (e *SystemError) Error() string {
 	pad := ""
 	sb := strings.Builder{}
-	lastIndex := len(e.msgs) - 1
-	for i := lastIndex; i >= 0; i-- {
-		if i < lastIndex {
+	for i, msg := range limitMessages(e.Messages()) {
+		if i > 0 {
 			sb.WriteString(fmt.Sprintf("\n%s", pad))
 		}
-		sb.WriteString(e.msgs[i])
+		sb.WriteString(msg)
 		pad = pad + padding
 	}
 	return sb.String()
 }
 
-// StackTrace returns the error message including the stack trace.
+// StackTrace returns the stack trace, followed by the locations at which the
+// messages of the chain have been wrapped (see SystemWrap), if any.
+//
+//	Example:
+//	   at app/store.go:17
+//	      --> app.(*Store).LoadUser
+//	   ...
+//
+//	   wrap sites:
+//	   app/handler.go:42: failed to handle request
+//	   app/service.go:28: failed to load user
 func (e *SystemError) StackTrace() string {
+	if e == nil {
+		return ""
+	}
+	if e.stackText != "" {
+		return e.stackText
+	}
+	return e.stack.String() + e.wrapSites()
+}
+
+// wrapSites returns the locations at which the messages have been wrapped,
+// starting with the outermost message.
+func (e *SystemError) wrapSites() string {
+	sb := strings.Builder{}
+	for i := len(e.sites) - 1; i >= 0; i-- {
+		if e.sites[i] == 0 {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("\n\nwrap sites:")
+		}
+		frame, _ := runtime.CallersFrames(e.sites[i : i+1]).Next()
+		msg := truncateMessage(e.msgs[i], limits().MaxMessageLength)
+		sb.WriteString(fmt.Sprintf("\n%s: %s", stack.Location(frame), msg))
+	}
+	return sb.String()
+}
+
+// Trace returns the stack trace which was captured when the SystemError was created.
+// The trace of a restored SystemError is empty.
+func (e *SystemError) Trace() *stack.Trace {
+	if e == nil {
+		return nil
+	}
 	return e.stack
 }
 
+// Callers returns the program counters of the stack trace of the innermost
+// SystemError of the chain, which is where the failure has been detected.
+// Error reporting SDKs which recognize the method (e.g. Bugsnag) report this
+// stack trace instead of the one of the location where the error got reported.
+// It returns nil for a restored SystemError.
+func (e *SystemError) Callers() []uintptr {
+	if e == nil {
+		return nil
+	}
+	return append([]uintptr(nil), *originOf(e).stack...)
+}
+
+// Stack returns the frames of the stack trace of the innermost SystemError of the chain.
+// Error reporting SDKs which recognize the method (e.g. Rollbar) report this stack trace
+// instead of the one of the location where the error got reported.
+// It returns nil for a restored SystemError.
+func (e *SystemError) Stack() []runtime.Frame {
+	if e == nil {
+		return nil
+	}
+	return originOf(e).stack.Frames()
+}
+
 // String returns the error message and stack trace.
 func (e *SystemError) String() string {
+	if e == nil {
+		return ""
+	}
 	return fmt.Sprintf("%s\n%s", e.Error(), e.StackTrace())
 }
 
+// ErrorReport returns the error message followed by the stack trace in the
+// layout of a Go runtime panic. Errors which are written to Google Cloud Logging
+// in this format are automatically picked up and grouped by Cloud Error Reporting.
+func (e *SystemError) ErrorReport() string {
+	if e == nil {
+		return ""
+	}
+	if e.stackText != "" {
+		return fmt.Sprintf("%s\n%s", e.Error(), e.stackText)
+	}
+	return fmt.Sprintf("%s\n\n%s", e.Error(), e.stack.RuntimeString())
+}
+
+// ExceptionType returns the value of the exception.type attribute
+// of the OpenTelemetry exception semantic conventions.
+func (e *SystemError) ExceptionType() string {
+	return reflect.TypeOf(e).String()
+}
+
+// ExceptionMessage returns the value of the exception.message attribute
+// of the OpenTelemetry exception semantic conventions.
+func (e *SystemError) ExceptionMessage() string {
+	return e.Error()
+}
+
+// ExceptionStackTrace returns the value of the exception.stacktrace attribute
+// of the OpenTelemetry exception semantic conventions.
+func (e *SystemError) ExceptionStackTrace() string {
+	if e == nil {
+		return ""
+	}
+	if e.stackText != "" {
+		return e.stackText
+	}
+	return e.stack.OTelStackTrace()
+}
+
+// Messages returns the message chain of the SystemError, starting with the outermost
+// message. The chain is complete, since the Limits only apply to the text renderers.
+func (e *SystemError) Messages() []string {
+	if e == nil {
+		return nil
+	}
+	msgs := make([]string, len(e.msgs))
+	for i, msg := range e.msgs {
+		msgs[len(msgs)-1-i] = msg
+	}
+	return msgs
+}
+
 // Unwrap returns the original underlying error.
 func (e *SystemError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
 	return e.err
 }
 
@@ -227,11 +462,36 @@ func (e *SystemError) Format(s fmt.State, verb rune) {
 
 // System creates a new SystemError fault whilst preserving the stack trace.
 func System(msg string) *SystemError {
-	return &SystemError{
+	return created(&SystemError{
 		err:   errors.New(msg),
 		msgs:  []string{msg},
-		stack: stack.Capture().String(),
+		stack: capturer().Capture(),
+	})
+}
+
+// FromPanic creates a new SystemError fault from a recovered panic value.
+// It must be called from within the deferred function which recovered the panic,
+// so that the stack trace points to the location where the panic occurred.
+//
+//	Example:
+//	   defer func() {
+//	      if r := recover(); r != nil {
+//	         err = fault.FromPanic(r)
+//	      }
+//	   }()
+func FromPanic(v interface{}) *SystemError {
+	msg := fmt.Sprintf("panic: %v", v)
+	err, ok := v.(error)
+	if ok {
+		err = fmt.Errorf("panic: %w", err)
+	} else {
+		err = errors.New(msg)
 	}
+	return created(&SystemError{
+		err:   err,
+		msgs:  []string{msg},
+		stack: capturer().CapturePanic(),
+	})
 }
 
 // Systemf creates a new SystemError fault whilst preserving the stack trace.
@@ -241,20 +501,91 @@ func Systemf(format string, a ...interface{}) *SystemError {
 
 // SystemWrap creates a new SystemError fault, wrapping an
 // existing error and preserving the entire stack trace.
+//
+// If the wrapped error doesn't contain a classified fault then the SystemError
+// gets classified by the registered classifiers (see Classify), e.g. wrapping
+// context.Canceled results in a SystemError of the Canceled kind.
+//
+// Wrapping a nil error is a bug, since the result would turn a success into a
+// failure. SystemWrap returns an Invariant fault in that case rather than nil,
+// because a nil *SystemError would be a non-nil error when returned as an error.
+// Use WrapDeferred to wrap an error which may be nil.
+//
+// The location of the call gets recorded alongside the message and is shown
+// with the stack trace (see StackTrace).
 func SystemWrap(err error, msg string) *SystemError {
+	return systemWrap(err, msg, callSite(0))
+}
+
+func systemWrap(err error, msg string, site uintptr) *SystemError {
+	if err == nil {
+		return invariant(fmt.Sprintf("%s: wrapped a nil error", msg))
+	}
+
 	var msgs []string
+	var sites []uintptr
 
 	// nolint: errorlint // Don't want to check the entire chain, just outer most error:
 	if sysErr, ok := err.(*SystemError); ok {
-		msgs = append(sysErr.msgs, msg)
+		// Allocate the exact capacity upfront, which also prevents
+		// two wrappers of the same error from sharing their messages:
+		msgs = make([]string, len(sysErr.msgs), len(sysErr.msgs)+1)
+		copy(msgs, sysErr.msgs)
+		msgs = append(msgs, msg)
+		sites = make([]uintptr, len(sysErr.msgs), len(sysErr.msgs)+1)
+		copy(sites, sysErr.sites)
+		sites = append(sites, site)
 	} else {
 		msgs = []string{err.Error(), msg}
+		sites = []uintptr{0, site}
 	}
 
-	return &SystemError{
+	return created(&SystemError{
 		err:   fmt.Errorf("%s\n%s%w", msg, padding, err),
 		msgs:  msgs,
-		stack: stack.Capture().String(),
+		sites: sites,
+		stack: capturer().Capture(),
+		kind:  autoKind(err),
+	})
+}
+
+// SystemWrapSkip is like SystemWrap, but records the location skip frames above
+// its caller as the wrap site, so that helpers which wrap errors on behalf of their
+// callers can attribute the wrap site to the caller. A skip of 0 is the caller itself.
+//
+//	Example:
+//	   func wrapQueryError(err error, msg string) *fault.SystemError {
+//	      return fault.SystemWrapSkip(err, msg, 1)
+//	   }
+func SystemWrapSkip(err error, msg string, skip int) *SystemError {
+	return systemWrap(err, msg, callSite(skip))
+}
+
+// callSite returns the program counter of the location which called the exported
+// function which calls callSite (plus skip frames above it), or 0 if a custom
+// Capturer has been installed.
+func callSite(skip int) uintptr {
+	if _, ok := capturer().(runtimeCapturer); !ok {
+		return 0
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3+skip, pcs[:])
+	return pcs[0]
+}
+
+// WrapDeferred wraps the error which errp points to with SystemWrap,
+// unless the error is nil. It is meant to be deferred by functions
+// which have a named error result. The recorded wrap site is the
+// location at which the function returned the error.
+//
+//	Example:
+//	   func importUsers(path string) (err error) {
+//	      defer fault.WrapDeferred(&err, "failed to import users")
+//	      ...
+//	   }
+func WrapDeferred(errp *error, msg string) {
+	if *errp != nil {
+		*errp = systemWrap(*errp, msg, callSite(0))
 	}
 }
 
@@ -264,7 +595,110 @@ func SystemWrapf(
 	err error,
 	format string,
 	a ...interface{}) *SystemError {
-	return SystemWrap(err, fmt.Sprintf(format, a...))
+	return systemWrap(err, fmt.Sprintf(format, a...), callSite(0))
+}
+
+// Errorf creates a new SystemError fault whilst preserving the stack trace.
+// It is a drop-in replacement for fmt.Errorf: the error message is identical
+// and errors wrapped with the %w verb can be matched with errors.Is and errors.As.
+//
+//	Example:
+//	   return fault.Errorf("failed to load user %d: %w", id, err)
+func Errorf(format string, a ...interface{}) *SystemError {
+	err := fmt.Errorf(format, a...)
+	return created(&SystemError{
+		err:   err,
+		msgs:  []string{err.Error()},
+		stack: capturer().Capture(),
+		kind:  autoKind(err),
+	})
+}
+
+// WithStack attaches the stack trace of the caller to an existing error without
+// adding a message, so that third-party errors carry location information while
+// their message remains unchanged. The returned SystemError unwraps to the error.
+//
+// The error is returned unchanged if it is nil or a SystemError already.
+//
+//	Example:
+//	   if err := client.Do(req); err != nil {
+//	      return fault.WithStack(err)
+//	   }
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	// nolint: errorlint // Only the outermost error needs to have a stack trace:
+	if _, ok := err.(*SystemError); ok {
+		return err
+	}
+	return created(&SystemError{
+		err:   err,
+		msgs:  []string{err.Error()},
+		stack: capturer().Capture(),
+		kind:  autoKind(err),
+	})
+}
+
+// RestoreSystem recreates a SystemError from its message chain (starting with the
+// outermost message) and its formatted stack trace, e.g. after it has been
+// transferred from another process.
+//
+// If cause is not nil then it replaces the innermost message, so that the
+// restored SystemError wraps the cause.
+func RestoreSystem(cause error, msgs []string, stackTrace string) *SystemError {
+	e := &SystemError{
+		stack:     &stack.Trace{},
+		stackText: stackTrace,
+	}
+	for i := len(msgs) - 1; i >= 0; i-- {
+		switch {
+		case e.err != nil:
+			e.err = fmt.Errorf("%s\n%s%w", msgs[i], padding, e.err)
+		case cause != nil:
+			e.err = cause
+		default:
+			e.err = errors.New(msgs[i])
+		}
+		e.msgs = append(e.msgs, msgs[i])
+	}
+	if e.err == nil && cause != nil {
+		e.err = cause
+		e.msgs = []string{cause.Error()}
+	}
+	return e
+}
+
+// ------
+// Fault
+// ------
+
+// Fault is the common interface of UserError and SystemError.
+type Fault interface {
+	error
+
+	// Kind returns the classification of the fault.
+	Kind() Kind
+
+	// Retryable reports whether the failed operation may succeed when being retried.
+	Retryable() bool
+}
+
+var (
+	_ Fault = (*UserError)(nil)
+	_ Fault = (*SystemError)(nil)
+)
+
+// IsUser reports whether the error's chain contains a UserError.
+func IsUser(err error) bool {
+	var userErr *UserError
+	return errors.As(err, &userErr)
+}
+
+// IsSystem reports whether the error's chain contains a SystemError.
+func IsSystem(err error) bool {
+	var sysErr *SystemError
+	return errors.As(err, &sysErr)
 }
 
 // As is similar, but a slightly different take on the errors.As function.
@@ -1,9 +1,12 @@
 package fault
 
 import (
+	"bytes"
 	"context"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -17,7 +20,7 @@ const (
 // ------
 
 func Test_Error_WithSingleUserError(t *testing.T) {
-	code := "missing_first_name"
+	code := Code("missing_first_name")
 	msg := "Please enter your first name."
 	f := User(code, msg)
 
@@ -30,11 +33,11 @@ func Test_Error_WithSingleUserError(t *testing.T) {
 }
 
 func Test_Error_WithMultipleUserErrors(t *testing.T) {
-	code1 := "b"
+	code1 := Code("b")
 	msg1 := "bbb"
 	f := User(code1, msg1)
 
-	code2 := "a"
+	code2 := Code("a")
 	msg2 := "aaa"
 	f.Add(code2, msg2)
 
@@ -47,7 +50,7 @@ func Test_Error_WithMultipleUserErrors(t *testing.T) {
 }
 
 func Test_FriendlyError_WithSingleUserError(t *testing.T) {
-	code := "missing_first_name"
+	code := Code("missing_first_name")
 	msg := "Please enter your first name."
 	f := User(code, msg)
 
@@ -60,11 +63,11 @@ func Test_FriendlyError_WithSingleUserError(t *testing.T) {
 }
 
 func Test_FriendlyError_WithMultipleUserErrors(t *testing.T) {
-	code1 := "b"
+	code1 := Code("b")
 	msg1 := "bbb"
 	f := User(code1, msg1)
 
-	code2 := "a"
+	code2 := Code("a")
 	msg2 := "aaa"
 
 	f.Add(code2, msg2)
@@ -78,7 +81,7 @@ func Test_FriendlyError_WithMultipleUserErrors(t *testing.T) {
 }
 
 func Test_Errors_WithSingleUserError(t *testing.T) {
-	code := "missing_first_name"
+	code := Code("missing_first_name")
 	msg := "Please enter your first name."
 	f := User(code, msg)
 
@@ -87,17 +90,17 @@ func Test_Errors_WithSingleUserError(t *testing.T) {
 	if len(actual) != 1 {
 		t.Error("Errors() was expected to return only one key value pair.")
 	}
-	if actual[code] != msg {
-		t.Errorf(expectedFormat, msg, actual[code])
+	if actual[string(code)] != msg {
+		t.Errorf(expectedFormat, msg, actual[string(code)])
 	}
 }
 
 func Test_Errors_WithMultipleUserErrors(t *testing.T) {
-	code1 := "b"
+	code1 := Code("b")
 	msg1 := "bbb"
 	f := User(code1, msg1)
 
-	code2 := "a"
+	code2 := Code("a")
 	msg2 := "aaa"
 	f.Add(code2, msg2)
 
@@ -106,16 +109,16 @@ func Test_Errors_WithMultipleUserErrors(t *testing.T) {
 	if len(actual) != 2 {
 		t.Error("Errors() was expected to return two key value pairs.")
 	}
-	if actual[code1] != msg1 {
-		t.Errorf(expectedFormat, msg1, actual[code1])
+	if actual[string(code1)] != msg1 {
+		t.Errorf(expectedFormat, msg1, actual[string(code1)])
 	}
-	if actual[code2] != msg2 {
-		t.Errorf(expectedFormat, msg2, actual[code2])
+	if actual[string(code2)] != msg2 {
+		t.Errorf(expectedFormat, msg2, actual[string(code2)])
 	}
 }
 
 func Test_ErrorMessages_WithSingleUserError(t *testing.T) {
-	code := "missing_first_name"
+	code := Code("missing_first_name")
 	msg := "Please enter your first name."
 	f := User(code, msg)
 
@@ -130,11 +133,11 @@ func Test_ErrorMessages_WithSingleUserError(t *testing.T) {
 }
 
 func Test_ErrorMessages_WithMultipleUserErrors(t *testing.T) {
-	code1 := "b"
+	code1 := Code("b")
 	msg1 := "bbb"
 	f := User(code1, msg1)
 
-	code2 := "a"
+	code2 := Code("a")
 	msg2 := "aaa"
 	f.Add(code2, msg2)
 
@@ -281,6 +284,22 @@ func Test_ErrorsIsStillWorksAsExpected(t *testing.T) {
 	}
 }
 
+func Test_Errorf_BehavesLikeFmtErrorf(t *testing.T) {
+	expected := fmt.Errorf("failed to load user %d: %w", 7, context.Canceled)
+
+	actual := Errorf("failed to load user %d: %w", 7, context.Canceled)
+
+	if actual.Error() != expected.Error() {
+		t.Errorf(expectedFormat, expected.Error(), actual.Error())
+	}
+	if !errors.Is(actual, context.Canceled) {
+		t.Error("actual was expected to match context.Canceled")
+	}
+	if len(actual.Trace().Frames()) == 0 {
+		t.Error("actual was expected to have a stack trace")
+	}
+}
+
 func Test_String_StackTraceWithoutFaultPackage(t *testing.T) {
 	f1 := errors.New("foo bar")
 	f2 := SystemWrap(f1, "f")
@@ -339,3 +358,423 @@ func Test_As(t *testing.T) {
 		t.Error("As method was expected to return a BarError.")
 	}
 }
+
+func Test_ErrorReport_WithLayersOfSystemErrors(t *testing.T) {
+	f1 := System("c")
+	f2 := SystemWrap(f1, "f")
+
+	actual := f2.ErrorReport()
+
+	expected := "f\n   c\n\ngoroutine 1 [running]:\ngithub.com/dusted-go/fault/fault.Test_ErrorReport_WithLayersOfSystemErrors()\n\t"
+	if !strings.HasPrefix(actual, expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_ExceptionAttributes_WithLayersOfSystemErrors(t *testing.T) {
+	f1 := System("c")
+	f2 := SystemWrap(f1, "f")
+
+	if actual, expected := f2.ExceptionType(), "*fault.SystemError"; actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual, expected := f2.ExceptionMessage(), "f\n   c"; actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual, expected := f2.ExceptionStackTrace(), "goroutine 1 [running]:\n"; !strings.HasPrefix(actual, expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func panicWithNilPointer() {
+	var f *SystemError
+	_ = f.msgs
+}
+
+func Test_FromPanic_WithRuntimeError(t *testing.T) {
+	var err *SystemError
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = FromPanic(r)
+			}
+		}()
+		panicWithNilPointer()
+	}()
+
+	expected := "panic: runtime error: invalid memory address or nil pointer dereference"
+	if !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf(expectedFormat, expected, err.Error())
+	}
+	var runtimeErr runtime.Error
+	if !errors.As(err, &runtimeErr) {
+		t.Error("err was expected to wrap the runtime.Error")
+	}
+	expected = "fault.panicWithNilPointer\n"
+	actual := err.StackTrace()
+	if !strings.HasPrefix(actual[strings.Index(actual, "--> ")+4:], expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_FromPanic_WithValue(t *testing.T) {
+	var err *SystemError
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = FromPanic(r)
+			}
+		}()
+		panic(42)
+	}()
+
+	expected := "panic: 42"
+	if err.Error() != expected {
+		t.Errorf(expectedFormat, expected, err.Error())
+	}
+}
+
+func Test_HasCode_WithMultipleUserErrors(t *testing.T) {
+	f := User("a", "aaa")
+	f.Add("b", "bbb")
+
+	if !f.HasCode("a") || !f.HasCode("b") {
+		t.Error("HasCode() was expected to return true for added codes.")
+	}
+	if f.HasCode("c") {
+		t.Error("HasCode() was expected to return false for unknown codes.")
+	}
+}
+
+func Test_Messages_WithLayersOfSystemErrors(t *testing.T) {
+	err := SystemWrap(SystemWrap(errors.New("a"), "b"), "c")
+
+	expected := "[c b a]"
+	actual := fmt.Sprint(err.Messages())
+	if actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_RestoreSystem_WithLayersOfSystemErrors(t *testing.T) {
+	original := SystemWrap(SystemWrap(errors.New("a"), "b"), "c")
+
+	restored := RestoreSystem(nil, original.Messages(), original.StackTrace())
+
+	if restored.Error() != original.Error() {
+		t.Errorf(expectedFormat, original.Error(), restored.Error())
+	}
+	if restored.String() != original.String() {
+		t.Errorf(expectedFormat, original.String(), restored.String())
+	}
+	if len(restored.Trace().Frames()) != 0 {
+		t.Error("The trace of a restored SystemError was expected to be empty.")
+	}
+}
+
+func Test_RestoreSystem_WithCause(t *testing.T) {
+	userErr := User("a", "aaa")
+	original := SystemWrap(userErr, "b")
+
+	restored := RestoreSystem(userErr, original.Messages(), original.StackTrace())
+
+	if restored.Error() != original.Error() {
+		t.Errorf(expectedFormat, original.Error(), restored.Error())
+	}
+	if !errors.Is(restored, userErr) {
+		t.Error("The restored SystemError was expected to wrap the cause.")
+	}
+}
+
+func Test_IsUser_IsSystem(t *testing.T) {
+	testCases := []struct {
+		err      error
+		isUser   bool
+		isSystem bool
+	}{
+		{err: nil},
+		{err: errors.New("a")},
+		{err: User("a", "aaa"), isUser: true},
+		{err: System("a"), isSystem: true},
+		{err: fmt.Errorf("b: %w", SystemWrap(User("a", "aaa"), "b")), isUser: true, isSystem: true},
+	}
+
+	for _, tc := range testCases {
+		if actual := IsUser(tc.err); actual != tc.isUser {
+			t.Errorf(expectedFormat, fmt.Sprint(tc.isUser), fmt.Sprint(actual))
+		}
+		if actual := IsSystem(tc.err); actual != tc.isSystem {
+			t.Errorf(expectedFormat, fmt.Sprint(tc.isSystem), fmt.Sprint(actual))
+		}
+	}
+}
+
+func Test_Fault_WithUserError(t *testing.T) {
+	var f Fault = User("a", "aaa")
+
+	if f.Kind() != InvalidArgument {
+		t.Errorf(expectedFormat, InvalidArgument, f.Kind())
+	}
+	if f.Retryable() {
+		t.Error("A UserError without a retry hint was not expected to be retryable.")
+	}
+}
+
+func newOriginError() *SystemError {
+	return System("connection refused")
+}
+
+func Test_Stack_ReturnsFramesOfOrigin(t *testing.T) {
+	err := SystemWrap(newOriginError(), "failed to load user")
+
+	frames := err.Stack()
+
+	expected := "github.com/dusted-go/fault/fault.newOriginError"
+	if len(frames) == 0 || frames[0].Function != expected {
+		t.Errorf(expectedFormat, expected, fmt.Sprint(frames))
+	}
+}
+
+func Test_Callers_ReturnsProgramCountersOfOrigin(t *testing.T) {
+	origin := newOriginError()
+	err := SystemWrap(origin, "failed to load user")
+
+	actual := err.Callers()
+
+	expected := []uintptr(*origin.Trace())
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf(expectedFormat, fmt.Sprint(expected), fmt.Sprint(actual))
+	}
+}
+
+func Test_Callers_RestoredSystemError(t *testing.T) {
+	err := RestoreSystem(nil, []string{"boom"}, "at main.go:12")
+
+	if actual := err.Callers(); actual != nil {
+		t.Errorf(expectedFormat, "nil", fmt.Sprint(actual))
+	}
+	if actual := err.Stack(); actual != nil {
+		t.Errorf(expectedFormat, "nil", fmt.Sprint(actual))
+	}
+}
+
+func Test_WithStack(t *testing.T) {
+	cause := errors.New("connection refused")
+
+	err := WithStack(cause)
+
+	if actual := err.Error(); actual != cause.Error() {
+		t.Errorf(expectedFormat, cause.Error(), actual)
+	}
+	var sysErr *SystemError
+	if !errors.As(err, &sysErr) || len(sysErr.Trace().Frames()) == 0 {
+		t.Fatal("A SystemError with a stack trace was expected.")
+	}
+	if !strings.HasSuffix(sysErr.Trace().Frames()[0].Function, "Test_WithStack") {
+		t.Errorf(expectedFormat, "Test_WithStack", sysErr.Trace().Frames()[0].Function)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("The error was expected to unwrap to its cause.")
+	}
+}
+
+func Test_WithStack_ReturnsSystemErrorAndNilUnchanged(t *testing.T) {
+	sysErr := System("boom")
+
+	if actual := WithStack(sysErr); actual != error(sysErr) {
+		t.Errorf(expectedFormat, sysErr, actual)
+	}
+	if actual := WithStack(nil); actual != nil {
+		t.Errorf(expectedFormat, "nil", actual)
+	}
+}
+
+func Test_WithStack_ClassifiesCause(t *testing.T) {
+	err := WithStack(context.Canceled)
+
+	if actual := KindOf(err); actual != Canceled {
+		t.Errorf(expectedFormat, Canceled, actual)
+	}
+}
+
+func Test_SystemWrap_SiblingsDontShareMessages(t *testing.T) {
+	f1 := System("a")
+	f2 := SystemWrap(f1, "b")
+	f3 := SystemWrap(f2, "c")
+	f4 := SystemWrap(f2, "d")
+
+	if actual := fmt.Sprint(f3.Messages()); actual != "[c b a]" {
+		t.Errorf(expectedFormat, "[c b a]", actual)
+	}
+	if actual := fmt.Sprint(f4.Messages()); actual != "[d b a]" {
+		t.Errorf(expectedFormat, "[d b a]", actual)
+	}
+}
+
+func Test_SystemWrap_RecordsWrapSites(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	f1 := System("connection refused")
+	f2 := SystemWrap(f1, "failed to load user")
+	f3 := SystemWrapf(f2, "failed to handle request %d", 7)
+	f4 := OpWrap("users.Load", f3)
+
+	expected := "\n\nwrap sites:\n" +
+		fmt.Sprintf("%s:%d: users.Load\n", file, line+4) +
+		fmt.Sprintf("%s:%d: failed to handle request 7\n", file, line+3) +
+		fmt.Sprintf("%s:%d: failed to load user", file, line+2)
+	if actual := f4.StackTrace(); !strings.HasSuffix(actual, expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := fmt.Sprintf("%+v", f4); !strings.HasSuffix(actual, expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := f1.StackTrace(); strings.Contains(actual, "wrap sites:") {
+		t.Errorf(expectedFormat, "no wrap sites", actual)
+	}
+}
+
+func Test_SystemWrap_RecordsWrapSitesOfOtherErrors(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	err := SystemWrap(errors.New("connection refused"), "failed to load user")
+
+	expected := fmt.Sprintf("\n\nwrap sites:\n%s:%d: failed to load user", file, line+1)
+	if actual := err.StackTrace(); !strings.HasSuffix(actual, expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func wrapOnBehalf(err error) *SystemError {
+	return SystemWrapSkip(err, "failed to load user", 1)
+}
+
+func Test_SystemWrapSkip(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	err := wrapOnBehalf(errors.New("connection refused"))
+
+	expected := fmt.Sprintf("\n\nwrap sites:\n%s:%d: failed to load user", file, line+1)
+	if actual := err.StackTrace(); !strings.HasSuffix(actual, expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Flat(t *testing.T) {
+	f := User("MISSING_FIRST_NAME", "First name is required")
+	f.Add("INVALID_ADDRESS", "The address\nis invalid")
+
+	expected := "First name is required (MISSING_FIRST_NAME); The address is invalid (INVALID_ADDRESS)"
+	if actual := f.Flat(true); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	expected = "First name is required; The address is invalid"
+	if actual := f.Flat(false); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_SystemWrap_WithNilError(t *testing.T) {
+	err := SystemWrap(nil, "failed to save user")
+
+	expected := "failed to save user: wrapped a nil error"
+	if actual := err.Error(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := err.Kind(); actual != Bug {
+		t.Errorf(expectedFormat, Bug, actual)
+	}
+	if actual := OpWrap("users.Save", nil).Op(); actual != "users.Save" {
+		t.Errorf(expectedFormat, "users.Save", actual)
+	}
+}
+
+func importUsers(fail bool) (err error) {
+	defer WrapDeferred(&err, "failed to import users")
+	if fail {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func Test_WrapDeferred(t *testing.T) {
+	if err := importUsers(false); err != nil {
+		t.Errorf(expectedFormat, "nil", err)
+	}
+
+	expected := "failed to import users\n   connection refused"
+	if actual := importUsers(true); actual == nil || actual.Error() != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_UserError_WithNilReceiver(t *testing.T) {
+	var f *UserError
+	var err error = f
+
+	if actual := fmt.Sprintf("%v", err); actual != "" {
+		t.Errorf(expectedFormat, "", actual)
+	}
+	if actual := f.Error() + f.String() + f.FriendlyError() + f.Flat(true) + f.Markdown(); actual != "" {
+		t.Errorf(expectedFormat, "", actual)
+	}
+	if len(f.Errors()) > 0 || len(f.Codes()) > 0 || len(f.ErrorMessages()) > 0 || len(f.FieldMap()) > 0 {
+		t.Errorf(expectedFormat, "no errors", fmt.Sprint(f.Errors()))
+	}
+	if f.HasCode("MISSING_FIRST_NAME") || f.Retryable() {
+		t.Errorf(expectedFormat, "false", "true")
+	}
+	if actual := f.Scrub(); actual != nil {
+		t.Errorf(expectedFormat, "nil", actual)
+	}
+	if actual := f.Hash(true); actual != (&UserError{}).Hash(true) {
+		t.Errorf(expectedFormat, fmt.Sprint((&UserError{}).Hash(true)), fmt.Sprint(actual))
+	}
+	if actual, err := f.MarshalJSON(); err != nil || string(actual) != "null" {
+		t.Errorf(expectedFormat, "null", string(actual))
+	}
+	if actual := marshalXML(t, f); actual != "" {
+		t.Errorf(expectedFormat, "", actual)
+	}
+	if _, err := f.GobEncode(); err == nil {
+		t.Error("GobEncode() was expected to return an error.")
+	}
+}
+
+func Test_SystemError_WithNilReceiver(t *testing.T) {
+	var f *SystemError
+	var err error = f
+
+	if actual := fmt.Sprintf("%v %+v %q", err, err, err); actual != "  \"\"" {
+		t.Errorf(expectedFormat, "  \"\"", actual)
+	}
+	if actual := f.Error() + f.String() + f.StackTrace() + f.ErrorReport() + f.Markdown(); actual != "" {
+		t.Errorf(expectedFormat, "", actual)
+	}
+	if f.Unwrap() != nil || f.Trace() != nil || f.Messages() != nil || f.Callers() != nil || f.Stack() != nil {
+		t.Errorf(expectedFormat, "nil", fmt.Sprint(f.Messages()))
+	}
+	if len(f.Fields()) > 0 || f.Breadcrumbs() != nil || f.Kind() != "" || f.Severity() != 0 || f.Retryable() {
+		t.Errorf(expectedFormat, "no details", fmt.Sprint(f.Fields()))
+	}
+	if actual, err := f.MarshalJSON(); err != nil || string(actual) != "null" {
+		t.Errorf(expectedFormat, "null", string(actual))
+	}
+	if actual := marshalXML(t, f); actual != "" {
+		t.Errorf(expectedFormat, "", actual)
+	}
+	if _, err := f.GobEncode(); err == nil {
+		t.Error("GobEncode() was expected to return an error.")
+	}
+}
+
+// marshalXML calls MarshalXML directly, because xml.Marshal omits nil pointers itself.
+func marshalXML(t *testing.T, m xml.Marshaler) string {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := xml.NewEncoder(&buf)
+	if err := m.MarshalXML(enc, xml.StartElement{Name: xml.Name{Local: "fault"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
@@ -0,0 +1,78 @@
+package fault
+
+import "errors"
+
+// WithField attaches a key value pair to the SystemError which provides
+// additional context for logging and debugging (e.g. a user or request ID).
+func (e *SystemError) WithField(key string, value interface{}) *SystemError {
+	if e.fields == nil {
+		e.fields = map[string]interface{}{}
+	}
+	e.fields[key] = value
+	return e
+}
+
+// WithFields attaches multiple key value pairs to the SystemError.
+func (e *SystemError) WithFields(fields map[string]interface{}) *SystemError {
+	for k, v := range fields {
+		e.WithField(k, v)
+	}
+	return e
+}
+
+// Annotate returns a SystemError which wraps the error transparently, so that fields
+// can be attached without modifying a SystemError which may be shared, e.g. a
+// package-level sentinel which is returned by concurrent requests. It returns nil
+// if the error is nil.
+//
+// The returned SystemError has the same message, stack trace and wrap sites as the
+// first SystemError in the error's chain and unwraps to the error, so that errors.Is
+// still matches the error itself. The stack trace of the caller gets captured if the
+// chain doesn't contain a SystemError.
+//
+//	Example:
+//	   err = fault.Annotate(err).WithField("request_id", requestID)
+func Annotate(err error) *SystemError {
+	if err == nil {
+		return nil
+	}
+	e := &SystemError{err: err, msgs: []string{err.Error()}}
+	var sysErr *SystemError
+	if !errors.As(err, &sysErr) || sysErr == nil {
+		e.stack = capturer().Capture()
+		return e
+	}
+	e.stack = sysErr.stack
+	e.stackText = sysErr.stackText
+	// nolint: errorlint // Only the outermost error carries the same messages:
+	if err == error(sysErr) {
+		e.msgs = sysErr.msgs
+		e.sites = sysErr.sites
+	}
+	return e
+}
+
+// Fields returns all key value pairs which have been attached to the SystemError
+// and the SystemErrors which it wraps. Fields of outer errors take precedence
+// over fields of inner errors with the same key.
+func (e *SystemError) Fields() map[string]interface{} {
+	if e == nil {
+		return map[string]interface{}{}
+	}
+	fields := map[string]interface{}{}
+	var chain []*SystemError
+	var err error = e
+	for err != nil {
+		// nolint: errorlint // Walking the chain manually:
+		if sysErr, ok := err.(*SystemError); ok {
+			chain = append(chain, sysErr)
+		}
+		err = errors.Unwrap(err)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		for k, v := range chain[i].fields {
+			fields[k] = v
+		}
+	}
+	return fields
+}
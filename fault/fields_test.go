@@ -0,0 +1,70 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_Fields_WithLayersOfSystemErrors(t *testing.T) {
+	f1 := System("c").WithField("user_id", 42).WithField("attempt", 1)
+	f2 := fmt.Errorf("f: %w", f1)
+	f3 := SystemWrap(f2, "i").WithFields(map[string]interface{}{
+		"attempt":    2,
+		"request_id": "abc",
+	})
+
+	actual := f3.Fields()
+
+	expected := map[string]interface{}{"user_id": 42, "attempt": 2, "request_id": "abc"}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Fields_WithoutFields(t *testing.T) {
+	f := SystemWrap(errors.New("foo bar"), "f")
+
+	actual := f.Fields()
+
+	if len(actual) != 0 {
+		t.Errorf(expectedFormat, map[string]interface{}{}, actual)
+	}
+}
+
+func Test_Annotate(t *testing.T) {
+	sentinel := SystemWrap(System("connection refused"), "failed to load user").WithField("a", 1)
+
+	err := Annotate(sentinel).WithField("b", 2)
+
+	if actual := sentinel.Fields(); fmt.Sprint(actual) != "map[a:1]" {
+		t.Errorf(expectedFormat, "map[a:1]", fmt.Sprint(actual))
+	}
+	if actual := err.Fields(); fmt.Sprint(actual) != "map[a:1 b:2]" {
+		t.Errorf(expectedFormat, "map[a:1 b:2]", fmt.Sprint(actual))
+	}
+	if actual := fmt.Sprintf("%+v", err); actual != fmt.Sprintf("%+v", sentinel) {
+		t.Errorf(expectedFormat, fmt.Sprintf("%+v", sentinel), actual)
+	}
+	if !errors.Is(err, sentinel) {
+		t.Errorf(expectedFormat, "errors.Is", "false")
+	}
+}
+
+func Test_Annotate_WithOtherErrors(t *testing.T) {
+	if Annotate(nil) != nil {
+		t.Errorf(expectedFormat, "nil", "not nil")
+	}
+	inner := System("connection refused")
+	wrapped := fmt.Errorf("failed to load user: %w", inner)
+
+	err := Annotate(wrapped)
+
+	if err.Error() != wrapped.Error() || err.StackTrace() != inner.StackTrace() {
+		t.Errorf(expectedFormat, fmt.Sprintf("%+v", inner), fmt.Sprintf("%+v", err))
+	}
+	if actual := fmt.Sprintf("%+v", Annotate(errors.New("a"))); !strings.HasPrefix(actual, "a") {
+		t.Errorf(expectedFormat, "a", actual)
+	}
+}
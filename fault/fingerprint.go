@@ -0,0 +1,101 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync/atomic"
+)
+
+// FingerprintMode decides which parts of the stack trace make up a fingerprint.
+type FingerprintMode int
+
+const (
+	// FingerprintLines computes fingerprints from the functions, files and line numbers
+	// of the stack trace. It is the default mode.
+	FingerprintLines FingerprintMode = iota
+
+	// FingerprintFunctions computes fingerprints from the function names of the stack trace
+	// only, so that the grouping of errors remains stable across code edits and deployments
+	// which shift line numbers. Errors which originate in different lines of the same
+	// function share a fingerprint.
+	FingerprintFunctions
+)
+
+var currentFingerprintMode atomic.Value
+
+func init() {
+	currentFingerprintMode.Store(FingerprintLines)
+}
+
+// SetFingerprintMode sets the mode in which Fingerprint computes fingerprints
+// and returns a function which restores the previous mode.
+//
+//	Example:
+//	   fault.SetFingerprintMode(fault.FingerprintFunctions)
+func SetFingerprintMode(mode FingerprintMode) (restore func()) {
+	previous := currentFingerprintMode.Swap(mode)
+	return func() {
+		currentFingerprintMode.Store(previous)
+	}
+}
+
+func fingerprintMode() FingerprintMode {
+	return currentFingerprintMode.Load().(FingerprintMode)
+}
+
+// Fingerprint returns a stable identifier which groups errors by their origin,
+// so that error reporters can deduplicate repeated occurrences of the same failure.
+//
+// The fingerprint is computed from the kind and the stack trace of the innermost
+// SystemError of the chain, regardless of the messages, which often contain IDs
+// or other variable data. Chains without a SystemError are fingerprinted by the
+// type and message of the error. It returns an empty string if the error is nil.
+//
+// Line numbers are only taken into account in the FingerprintLines mode (see SetFingerprintMode).
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	origin := originOf(err)
+	mode := fingerprintMode()
+	h := fnv.New64a()
+	switch {
+	case origin == nil:
+		fmt.Fprintf(h, "%T\n%s", err, err.Error())
+	case origin.stackText != "" && mode == FingerprintFunctions:
+		fmt.Fprintf(h, "%s\n%s", origin.Kind(), functionsOf(origin.stackText))
+	case origin.stackText != "":
+		fmt.Fprintf(h, "%s\n%s", origin.Kind(), origin.stackText)
+	case mode == FingerprintFunctions:
+		fmt.Fprintf(h, "%s\n%016x", origin.Kind(), origin.stack.HashFunctions())
+	default:
+		fmt.Fprintf(h, "%s\n%016x", origin.Kind(), origin.stack.Hash())
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// originOf returns the innermost SystemError of the chain, or nil if there is none.
+func originOf(err error) *SystemError {
+	var origin *SystemError
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		// nolint: errorlint // Walking the chain manually:
+		if sysErr, ok := e.(*SystemError); ok {
+			origin = sysErr
+		}
+	}
+	return origin
+}
+
+// functionsOf returns the function names of a formatted stack trace (see stack.Trace.String).
+func functionsOf(stackText string) string {
+	var names []string
+	for _, line := range strings.Split(stackText, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "--> ") {
+			names = append(names, strings.TrimPrefix(line, "--> "))
+		}
+	}
+	return strings.Join(names, "\n")
+}
@@ -0,0 +1,99 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+)
+
+func newFingerprintError(id int) error {
+	return SystemWrapf(errors.New("connection refused"), "failed to load user %d", id)
+}
+
+func Test_Fingerprint_Nil(t *testing.T) {
+	if actual := Fingerprint(nil); actual != "" {
+		t.Errorf(expectedFormat, "", actual)
+	}
+}
+
+func Test_Fingerprint_SameOrigin_IgnoresMessages(t *testing.T) {
+	var fingerprints []string
+	for id := 1; id <= 2; id++ {
+		fingerprints = append(fingerprints, Fingerprint(newFingerprintError(id)))
+	}
+	a, b := fingerprints[0], fingerprints[1]
+
+	if a != b {
+		t.Errorf(expectedFormat, a, b)
+	}
+	if len(a) != 16 {
+		t.Errorf(expectedFormat, "16 hex digits", a)
+	}
+}
+
+func Test_Fingerprint_DifferentOrigin(t *testing.T) {
+	a := Fingerprint(newFingerprintError(1))
+	b := Fingerprint(SystemWrap(System("connection refused"), "failed to load user 1"))
+
+	if a == b {
+		t.Errorf(expectedFormat, "different fingerprints", a)
+	}
+}
+
+func Test_Fingerprint_DifferentKind(t *testing.T) {
+	newError := func(kind Kind) error {
+		return System("boom").WithKind(kind)
+	}
+	a := Fingerprint(newError(Timeout))
+	b := Fingerprint(newError(Unavailable))
+
+	if a == b {
+		t.Errorf(expectedFormat, "different fingerprints", a)
+	}
+}
+
+func Test_Fingerprint_WithoutSystemError(t *testing.T) {
+	a := Fingerprint(errors.New("boom"))
+	b := Fingerprint(errors.New("boom"))
+
+	if a != b {
+		t.Errorf(expectedFormat, a, b)
+	}
+}
+
+func Test_Fingerprint_IgnoresOuterWraps(t *testing.T) {
+	var fingerprints []string
+	for i := 0; i < 2; i++ {
+		err := newFingerprintError(1)
+		if i == 1 {
+			err = SystemWrap(err, "failed to handle request")
+		}
+		fingerprints = append(fingerprints, Fingerprint(err))
+	}
+
+	if fingerprints[0] != fingerprints[1] {
+		t.Errorf(expectedFormat, fingerprints[0], fingerprints[1])
+	}
+}
+
+func Test_Fingerprint_WithFingerprintFunctions(t *testing.T) {
+	a := System("connection refused")
+	b := System("connection refused")
+
+	if Fingerprint(a) == Fingerprint(b) {
+		t.Errorf(expectedFormat, "different fingerprints", Fingerprint(a))
+	}
+
+	restore := SetFingerprintMode(FingerprintFunctions)
+	defer restore()
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Errorf(expectedFormat, Fingerprint(a), Fingerprint(b))
+	}
+	restoredA, restoredB := Decode(Encode(a)), Decode(Encode(b))
+	if Fingerprint(restoredA) != Fingerprint(restoredB) {
+		t.Errorf(expectedFormat, Fingerprint(restoredA), Fingerprint(restoredB))
+	}
+	if Fingerprint(a) == Fingerprint(System("connection refused").WithKind(Timeout)) {
+		t.Errorf(expectedFormat, "different fingerprints", Fingerprint(a))
+	}
+}
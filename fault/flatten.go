@@ -0,0 +1,103 @@
+package fault
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dusted-go/fault/stack"
+)
+
+// ChainEntry is a single error of an error's chain, as returned by Flatten.
+type ChainEntry struct {
+	// Depth is the distance of the entry from the error which
+	// has been flattened, which is 0 for the error itself.
+	Depth int
+
+	// Type is the type name of the error (e.g. *fault.SystemError).
+	Type string
+
+	// Message is the message of a SystemError itself (without the messages
+	// of the errors which it wraps), or the full message of any other error.
+	Message string
+
+	// Codes are the codes of a UserError.
+	Codes []Code
+
+	// Kind is the kind of a SystemError or UserError.
+	Kind Kind
+
+	// Fields are the fields which have been attached to a SystemError itself.
+	Fields map[string]interface{}
+
+	// Stack is the stack trace of a SystemError, or nil for any
+	// other error and for a restored SystemError.
+	Stack *stack.Trace
+}
+
+// Flatten returns the errors of the error's chain in order, starting with the error itself,
+// as the canonical representation for exporters and integrations. The causes of errors with
+// multiple causes (e.g. an Aggregate) follow each other depth-first. It returns nil if the
+// error is nil.
+//
+// The error which carries the message of a SystemError (e.g. the error created by System
+// or the one which SystemWrap wraps its cause in) is omitted. Messages and fields are
+// not scrubbed.
+func Flatten(err error) []ChainEntry {
+	var entries []ChainEntry
+	flattenInto(&entries, err, 0)
+	return entries
+}
+
+func flattenInto(entries *[]ChainEntry, err error, depth int) {
+	if err == nil {
+		return
+	}
+	entry := ChainEntry{Depth: depth, Type: fmt.Sprintf("%T", err)}
+	causes := unwrapAll(err)
+
+	// nolint: errorlint // Walking the chain manually:
+	switch e := err.(type) {
+	case *UserError:
+		entry.Message = e.Error()
+		entry.Codes = e.Codes()
+		entry.Kind = e.Kind()
+	case *SystemError:
+		if e == nil {
+			break
+		}
+		if msgs := e.Messages(); len(msgs) > 0 {
+			entry.Message = msgs[0]
+		}
+		entry.Kind = e.Kind()
+		if e.stack != nil && len(*e.stack) > 0 {
+			entry.Stack = e.stack
+		}
+		if len(e.fields) > 0 {
+			entry.Fields = make(map[string]interface{}, len(e.fields))
+			for k, v := range e.fields {
+				entry.Fields[k] = v
+			}
+		}
+		if len(causes) == 1 && isMessageCarrier(e, causes[0]) {
+			causes = unwrapAll(causes[0])
+		}
+	default:
+		entry.Message = err.Error()
+	}
+	*entries = append(*entries, entry)
+	for _, cause := range causes {
+		flattenInto(entries, cause, depth+1)
+	}
+}
+
+// isMessageCarrier reports whether the error only carries the message of the SystemError.
+func isMessageCarrier(sysErr *SystemError, err error) bool {
+	// nolint: errorlint // Only the error itself is of interest:
+	switch err.(type) {
+	case *UserError, *SystemError:
+		return false
+	}
+	line, _, _ := strings.Cut(err.Error(), "\n")
+	sysLine, _, _ := strings.Cut(sysErr.Error(), "\n")
+	return line == sysLine
+}
@@ -0,0 +1,53 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_Flatten_Nil(t *testing.T) {
+	if actual := Flatten(nil); actual != nil {
+		t.Errorf(expectedFormat, "nil", fmt.Sprint(actual))
+	}
+}
+
+func Test_Flatten(t *testing.T) {
+	cause := fmt.Errorf("failed to dial: %w", errors.New("connection refused"))
+	err := SystemWrap(SystemWrap(cause, "failed to connect").WithKind(Unavailable).WithField("host", "db-1"), "failed to load user")
+
+	entries := Flatten(err)
+	actual := make([]string, len(entries))
+	for i, e := range entries {
+		actual[i] = fmt.Sprintf("%d %s %q %s %v %t", e.Depth, e.Type, e.Message, e.Kind, e.Fields, e.Stack != nil)
+	}
+	expected := []string{
+		`0 *fault.SystemError "failed to load user" unavailable map[] true`,
+		`1 *fault.SystemError "failed to connect" unavailable map[host:db-1] true`,
+		`2 *fmt.wrapError "failed to dial: connection refused"  map[] false`,
+		`3 *errors.errorString "connection refused"  map[] false`,
+	}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf(expectedFormat, fmt.Sprint(expected), fmt.Sprint(actual))
+	}
+}
+
+func Test_Flatten_WithMultipleCauses(t *testing.T) {
+	agg := &Aggregate{}
+	agg.Add(0, System("failed to insert user"))
+	agg.Add(3, User("MISSING_NAME", "Please provide a name."))
+
+	entries := Flatten(agg)
+	actual := make([]string, len(entries))
+	for i, e := range entries {
+		actual[i] = fmt.Sprintf("%d %s %v", e.Depth, e.Type, e.Codes)
+	}
+	expected := []string{
+		"0 *fault.Aggregate []",
+		"1 *fault.SystemError []",
+		"1 *fault.UserError [MISSING_NAME]",
+	}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf(expectedFormat, fmt.Sprint(expected), fmt.Sprint(actual))
+	}
+}
@@ -0,0 +1,155 @@
+package fault
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// gobLink is an encodedLink which can be encoded with gob.
+type gobLink struct {
+	Type     string
+	Message  string
+	Messages []string
+	Errors   []encodedEntry
+	Kind     Kind
+	Op       Op
+	Severity Severity
+	Fields   map[string]interface{}
+	Stack    string
+	// Retryable is 1 if the error is retryable, -1 if it isn't and 0 if it hasn't been
+	// classified explicitly, because gob doesn't transmit pointers to zero values.
+	Retryable  int8
+	RetryAfter time.Duration
+}
+
+type gobChain struct {
+	Chain []gobLink
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+// The entire chain of the UserError gets encoded like by Encode.
+// Like gob itself, it returns an error if the UserError is nil.
+func (e *UserError) GobEncode() ([]byte, error) {
+	if e == nil {
+		return nil, errors.New("fault: can't gob encode a nil *UserError")
+	}
+	return gobEncodeChain(e)
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (e *UserError) GobDecode(data []byte) error {
+	var userErr *UserError
+	if err := gobDecodeAs(data, &userErr); err != nil {
+		return err
+	}
+	*e = *userErr
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+//
+// The entire chain of the SystemError gets encoded like by Encode, including wrapped
+// UserErrors and other errors. Messages, fields, params and stack traces are scrubbed by the
+// DefaultScrubber. Field and param values which are not of a basic type get encoded as strings.
+// Like gob itself, it returns an error if the SystemError is nil.
+func (e *SystemError) GobEncode() ([]byte, error) {
+	if e == nil {
+		return nil, errors.New("fault: can't gob encode a nil *SystemError")
+	}
+	return gobEncodeChain(e)
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+// The stack trace of a decoded SystemError is only available
+// as text (see RestoreSystem).
+func (e *SystemError) GobDecode(data []byte) error {
+	var sysErr *SystemError
+	if err := gobDecodeAs(data, &sysErr); err != nil {
+		return err
+	}
+	*e = *sysErr
+	return nil
+}
+
+func gobEncodeChain(err error) ([]byte, error) {
+	chain := encodeChain(err, gobValue)
+	g := gobChain{Chain: make([]gobLink, len(chain.Chain))}
+	for i, link := range chain.Chain {
+		g.Chain[i] = gobLink{
+			Type:       link.Type,
+			Message:    link.Message,
+			Messages:   link.Messages,
+			Errors:     link.Errors,
+			Kind:       link.Kind,
+			Op:         link.Op,
+			Severity:   link.Severity,
+			Fields:     link.Fields,
+			Stack:      link.Stack,
+			RetryAfter: link.RetryAfter,
+		}
+		if link.Retryable != nil {
+			g.Chain[i].Retryable = -1
+			if *link.Retryable {
+				g.Chain[i].Retryable = 1
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gobDecodeAs decodes a gob encoded chain whose outermost error must be of type T.
+func gobDecodeAs[T error](data []byte, target *T) error {
+	var g gobChain
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&g); err != nil {
+		return err
+	}
+	chain := encodedChain{Chain: make([]encodedLink, len(g.Chain))}
+	for i, link := range g.Chain {
+		chain.Chain[i] = encodedLink{
+			Type:       link.Type,
+			Message:    link.Message,
+			Messages:   link.Messages,
+			Errors:     link.Errors,
+			Kind:       link.Kind,
+			Op:         link.Op,
+			Severity:   link.Severity,
+			Fields:     link.Fields,
+			Stack:      link.Stack,
+			RetryAfter: link.RetryAfter,
+		}
+		if link.Retryable != 0 {
+			retryable := link.Retryable > 0
+			chain.Chain[i].Retryable = &retryable
+		}
+	}
+	return restoreAs(decodeChain(chain), target)
+}
+
+// gobValue returns the value if it's of a basic type, which is registered
+// with the gob package by default, or otherwise its string representation.
+func gobValue(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	t := reflect.TypeOf(v)
+	if t.PkgPath() != "" {
+		return fmt.Sprint(v)
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
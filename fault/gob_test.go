@@ -0,0 +1,162 @@
+package fault
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type gobEnvelope struct {
+	User   *UserError
+	System *SystemError
+}
+
+func gobRoundTrip(t *testing.T, in gobEnvelope) gobEnvelope {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out gobEnvelope
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func Test_Gob_WithUserError(t *testing.T) {
+	userErr := User("a", "aaa")
+	userErr.Add("b", "bbb")
+	userErr.WithRetryAfter(time.Minute)
+
+	out := gobRoundTrip(t, gobEnvelope{User: userErr})
+
+	if out.User.Error() != userErr.Error() {
+		t.Errorf(expectedFormat, userErr.Error(), out.User.Error())
+	}
+	if retryAfter, _ := out.User.RetryAfter(); retryAfter != time.Minute {
+		t.Errorf(expectedFormat, time.Minute.String(), retryAfter.String())
+	}
+}
+
+func Test_Gob_WithSystemError(t *testing.T) {
+	userErr := User("a", "aaa")
+	sysErr := SystemWrap(
+		SystemWrap(userErr, "b").WithField("inner", 1),
+		"c").
+		WithKind(Conflict).
+		WithField("time", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	out := gobRoundTrip(t, gobEnvelope{System: sysErr})
+
+	if out.System.String() != sysErr.String() {
+		t.Errorf(expectedFormat, sysErr.String(), out.System.String())
+	}
+	if out.System.Kind() != Conflict {
+		t.Errorf(expectedFormat, Conflict, out.System.Kind())
+	}
+	fields := out.System.Fields()
+	if fields["inner"] != 1 || fields["time"] != "2020-01-01 00:00:00 +0000 UTC" {
+		t.Errorf(expectedFormat, sysErr.Fields(), fields)
+	}
+	if out.System.Retryable() {
+		t.Error("The decoded SystemError was expected to not be retryable.")
+	}
+	var decodedUserErr *UserError
+	if !errors.As(out.System, &decodedUserErr) || !decodedUserErr.HasCode("a") {
+		t.Error("The decoded SystemError was expected to wrap the UserError.")
+	}
+}
+
+func Test_Gob_WithUserFieldsAndParams(t *testing.T) {
+	userErr := UserField("email", "EMAIL_TAKEN", "The email address is taken.")
+	userErr.AddParams("INVALID_ITEMS", "{count, plural, one {# item is} other {# items are}} invalid.", Params{"count": 2})
+
+	out := gobRoundTrip(t, gobEnvelope{User: userErr})
+
+	if actual := out.User.Field("EMAIL_TAKEN"); actual != "email" {
+		t.Errorf(expectedFormat, "email", actual)
+	}
+	if actual := out.User.Params("INVALID_ITEMS")["count"]; actual != 2 {
+		t.Errorf(expectedFormat, "2", fmt.Sprint(actual))
+	}
+	c := Catalog{"de": {"INVALID_ITEMS": "{count, plural, one {# Eintrag ist} other {# Einträge sind}} ungültig."}}
+	if actual := out.User.Localize(c, "de").ErrorMessages()[1]; actual != "2 Einträge sind ungültig." {
+		t.Errorf(expectedFormat, "2 Einträge sind ungültig.", actual)
+	}
+}
+
+func Test_Gob_WithOpAndOtherErrors(t *testing.T) {
+	sysErr := OpWrap("users.Get", fmt.Errorf("query failed: %w", User("a", "aaa")))
+
+	out := gobRoundTrip(t, gobEnvelope{System: sysErr})
+
+	if actual := out.System.Op(); actual != "users.Get" {
+		t.Errorf(expectedFormat, "users.Get", actual)
+	}
+	if out.System.String() != sysErr.String() {
+		t.Errorf(expectedFormat, sysErr.String(), out.System.String())
+	}
+	expected, actual := Flatten(sysErr), Flatten(out.System)
+	if len(actual) != len(expected) {
+		t.Fatalf(expectedFormat, fmt.Sprint(expected), fmt.Sprint(actual))
+	}
+	for i := range expected {
+		if actual[i].Message != expected[i].Message {
+			t.Errorf(expectedFormat, expected[i].Message, actual[i].Message)
+		}
+	}
+	var userErr *UserError
+	if !errors.As(out.System, &userErr) || !userErr.HasCode("a") {
+		t.Error("The decoded SystemError was expected to wrap the UserError.")
+	}
+}
+
+func Test_Gob_Scrubs(t *testing.T) {
+	previous := DefaultScrubber
+	DefaultScrubber = &Scrubber{}
+	defer func() { DefaultScrubber = previous }()
+	RegisterScrubRule(EmailRule)
+	userErr := UserParams("EMAIL_TAKEN", "{email} is taken.", Params{"email": "jane@example.com"})
+	sysErr := SystemWrap(userErr, "jane@example.com failed to sign up").
+		WithField("email", "jane@example.com")
+
+	out := gobRoundTrip(t, gobEnvelope{User: userErr, System: sysErr})
+
+	for _, actual := range []string{
+		out.User.Error(),
+		fmt.Sprint(out.User.Params("EMAIL_TAKEN")["email"]),
+		out.System.String(),
+		fmt.Sprint(out.System.Fields()["email"]),
+	} {
+		if strings.Contains(actual, "jane@example.com") {
+			t.Errorf(expectedFormat, Redacted, actual)
+		}
+	}
+}
+
+func Test_Gob_WithRetryable(t *testing.T) {
+	testCases := []struct {
+		sysErr              *SystemError
+		expected, reclassed bool
+	}{
+		{System("a").WithKind(Unavailable), true, false},
+		{System("a").WithKind(Unavailable).WithRetryable(false), false, false},
+		{System("a").WithRetryable(true), true, true},
+	}
+	for _, tc := range testCases {
+		out := gobRoundTrip(t, gobEnvelope{System: tc.sysErr})
+
+		if actual := out.System.Retryable(); actual != tc.expected {
+			t.Errorf(expectedFormat, fmt.Sprint(tc.expected), fmt.Sprint(actual))
+		}
+		// A retry classification which hasn't been set explicitly follows the kind:
+		if actual := out.System.WithKind(Internal).Retryable(); actual != tc.reclassed {
+			t.Errorf(expectedFormat, fmt.Sprint(tc.reclassed), fmt.Sprint(actual))
+		}
+	}
+}
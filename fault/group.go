@@ -0,0 +1,86 @@
+package fault
+
+import "sync"
+
+// Group runs functions concurrently and collects all of their failures.
+// Panics of the functions get recovered and converted into a SystemError.
+//
+// Unlike errgroup.Group, a Group doesn't stop at the first failure but
+// waits for all functions and returns an Aggregate of all failures,
+// keyed by the order in which the functions have been started.
+//
+// A zero Group is valid and doesn't limit the number of concurrent functions.
+//
+//	Example:
+//	   g := &fault.Group{}
+//	   g.SetLimit(4)
+//	   for _, id := range ids {
+//	      id := id
+//	      g.Go(func() error { return sync(id) })
+//	   }
+//	   return g.Wait()
+type Group struct {
+	wg  sync.WaitGroup
+	sem chan struct{}
+
+	mu  sync.Mutex
+	n   int
+	agg Aggregate
+}
+
+// SetLimit limits the number of functions which run concurrently.
+// A negative value removes the limit. It must not be called while functions are running.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go runs the function in a new goroutine. It blocks until the
+// function can be started without exceeding the limit.
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.mu.Lock()
+	index := g.n
+	g.n++
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+		if err := g.run(fn); err != nil {
+			g.mu.Lock()
+			g.agg.Add(index, err)
+			g.mu.Unlock()
+		}
+	}()
+}
+
+func (g *Group) run(fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = FromPanic(v)
+		}
+	}()
+	return fn()
+}
+
+// Wait blocks until all functions have returned. It returns an Aggregate
+// of all failures, or nil if all functions have succeeded.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.agg.Len() == 0 {
+		return nil
+	}
+	agg := g.agg
+	return &agg
+}
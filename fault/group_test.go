@@ -0,0 +1,66 @@
+package fault
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_Group_CollectsAllFailures(t *testing.T) {
+	userErr := User("a", "aaa")
+	g := &Group{}
+	g.Go(func() error { return nil })
+	g.Go(func() error { return userErr })
+	g.Go(func() error { panic("boom") })
+
+	err := g.Wait()
+
+	var agg *Aggregate
+	if !errors.As(err, &agg) {
+		t.Fatalf(expectedFormat, "*Aggregate", err)
+	}
+	failed := agg.Failed()
+	if len(failed) != 2 || failed[1] != userErr {
+		t.Errorf(expectedFormat, "2 failures", err)
+	}
+	if msg := failed[2].Error(); msg != "panic: boom" {
+		t.Errorf(expectedFormat, "panic: boom", msg)
+	}
+}
+
+func Test_Group_WithoutFailures(t *testing.T) {
+	g := &Group{}
+	g.Go(func() error { return nil })
+
+	if err := g.Wait(); err != nil {
+		t.Errorf(expectedFormat, "nil", err)
+	}
+}
+
+func Test_Group_SetLimit(t *testing.T) {
+	var running, maxRunning int32
+	g := &Group{}
+	g.SetLimit(2)
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 concurrent functions, got %d", maxRunning)
+	}
+}
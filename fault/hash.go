@@ -0,0 +1,38 @@
+package fault
+
+import (
+	"hash/fnv"
+	"sort"
+)
+
+// Hash returns a hash of the codes of the user errors, regardless of the order in which
+// they have been added, so that repeated identical validation failures can be deduplicated,
+// cached or grouped in metrics. The messages are included in the hash if includeMessages
+// is true, which distinguishes failures whose messages echo different input.
+func (e *UserError) Hash(includeMessages bool) uint64 {
+	if e == nil {
+		return fnv.New64a().Sum64()
+	}
+	entries := make([][2]string, len(e.codes))
+	for i, code := range e.codes {
+		entries[i][0] = code
+		if includeMessages {
+			entries[i][1] = e.messages[i]
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i][0] != entries[j][0] {
+			return entries[i][0] < entries[j][0]
+		}
+		return entries[i][1] < entries[j][1]
+	})
+	h := fnv.New64a()
+	for _, entry := range entries {
+		// Separate with NUL bytes, which don't occur in codes and messages:
+		_, _ = h.Write([]byte(entry[0]))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(entry[1]))
+		_, _ = h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,20 @@
+package fault
+
+import "testing"
+
+func Test_UserError_Hash(t *testing.T) {
+	a := User("MISSING_NAME", "Please provide a name.")
+	a.Add("INVALID_EMAIL", "'a@' is not a valid email address.")
+	b := User("INVALID_EMAIL", "'b@' is not a valid email address.")
+	b.Add("MISSING_NAME", "Please provide a name.")
+
+	if a.Hash(false) != b.Hash(false) {
+		t.Error("User errors with the same codes were expected to have the same hash.")
+	}
+	if a.Hash(true) == b.Hash(true) {
+		t.Error("User errors with different messages were expected to have different hashes.")
+	}
+	if a.Hash(false) == User("MISSING_NAME", "Please provide a name.").Hash(false) {
+		t.Error("User errors with different codes were expected to have different hashes.")
+	}
+}
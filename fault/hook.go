@@ -0,0 +1,44 @@
+package fault
+
+import "sync/atomic"
+
+// CreateHook is called for every SystemError which gets created together with
+// a stack trace, e.g. by System, SystemWrap, Errorf or FromPanic. It is called
+// synchronously on the error path and must therefore be fast.
+type CreateHook func(e *SystemError)
+
+type createHookHolder struct {
+	hook CreateHook
+}
+
+var currentCreateHook atomic.Value
+
+func init() {
+	currentCreateHook.Store(createHookHolder{})
+}
+
+// SetCreateHook sets the hook which gets called for every new SystemError and returns
+// a function which restores the previous hook. A nil hook removes the hook.
+//
+//	Example:
+//	   counter := fault.NewCallSiteCounter()
+//	   restore := fault.SetCreateHook(counter.Record)
+//	   defer restore()
+func SetCreateHook(hook CreateHook) (restore func()) {
+	previous := currentCreateHook.Swap(createHookHolder{hook})
+	return func() {
+		currentCreateHook.Store(previous)
+	}
+}
+
+// created attaches the environment fields (see SetEnvironmentFields)
+// to the new SystemError and passes it to the CreateHook, if any.
+func created(e *SystemError) *SystemError {
+	if fields := environmentFields(); len(fields) > 0 && originOf(e.err) == nil {
+		e.WithFields(fields)
+	}
+	if hook := currentCreateHook.Load().(createHookHolder).hook; hook != nil {
+		hook(e)
+	}
+	return e
+}
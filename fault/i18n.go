@@ -0,0 +1,94 @@
+package fault
+
+import "strings"
+
+// Catalog contains translated user error messages keyed by language tag and error code.
+// Language tags are matched case-insensitively (e.g. pt-br matches pt-BR).
+//
+//	Example:
+//	   fault.Catalog{
+//	      "en": {"MISSING_FIRST_NAME": "Please provide your first name"},
+//	      "de": {"MISSING_FIRST_NAME": "Bitte geben Sie Ihren Vornamen an"},
+//	   }
+type Catalog map[string]map[string]string
+
+// Message returns the translated message of the error code in the first
+// of the given languages for which a translation exists.
+//
+// A language tag with a region (e.g. de-CH) falls back to
+// its base language (e.g. de) if it has no translation itself.
+func (c Catalog) Message(code string, langs ...string) (string, bool) {
+	msg, _, ok := c.lookup(code, langs)
+	return msg, ok
+}
+
+// Format returns the translated message of the error code like Message,
+// formatted with the params using the plural rules of the language of the
+// translation (see FormatMessage).
+func (c Catalog) Format(code string, params Params, langs ...string) (string, bool) {
+	msg, lang, ok := c.lookup(code, langs)
+	if !ok {
+		return "", false
+	}
+	return FormatMessage(msg, lang, params), true
+}
+
+// lookup returns the translated message of the error code and the language of the translation.
+func (c Catalog) lookup(code string, langs []string) (string, string, bool) {
+	for _, lang := range langs {
+		if msg, key, ok := c.find(lang, code); ok {
+			return msg, key, true
+		}
+		if base, _, ok := strings.Cut(lang, "-"); ok {
+			if msg, key, ok := c.find(base, code); ok {
+				return msg, key, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// find returns the translated message of the error code and the catalog key
+// of the language, which is matched case-insensitively.
+func (c Catalog) find(lang, code string) (string, string, bool) {
+	if msg, ok := c[lang][code]; ok {
+		return msg, lang, true
+	}
+	for key, msgs := range c {
+		if !strings.EqualFold(key, lang) {
+			continue
+		}
+		if msg, ok := msgs[code]; ok {
+			return msg, key, true
+		}
+	}
+	return "", "", false
+}
+
+// Localize returns a copy of the UserError with all messages translated into the
+// first of the given languages for which a translation exists. Messages without
+// a translation remain unchanged. Translations of messages which have params
+// get formatted with them (see Catalog.Format).
+func (e *UserError) Localize(c Catalog, langs ...string) *UserError {
+	if e == nil {
+		return nil
+	}
+	localized := &UserError{
+		codes:      append([]string(nil), e.codes...),
+		messages:   e.ErrorMessages(),
+		retryAfter: e.retryAfter,
+		params:     e.params,
+	}
+	for code, field := range e.fields {
+		if localized.fields == nil {
+			localized.fields = map[string]string{}
+		}
+		localized.fields[code] = field
+	}
+	for i, code := range e.codes {
+		if translated, ok := c.Format(code, e.params[code], langs...); ok {
+			localized.messages[i] = translated
+		}
+	}
+	return localized
+}
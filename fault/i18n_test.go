@@ -0,0 +1,72 @@
+package fault
+
+import "testing"
+
+var catalog = Catalog{
+	"en": {"MISSING_FIRST_NAME": "Please provide your first name."},
+	"de": {"MISSING_FIRST_NAME": "Bitte geben Sie Ihren Vornamen an."},
+}
+
+func Test_Message_WithRegionalLanguage(t *testing.T) {
+	actual, ok := catalog.Message("MISSING_FIRST_NAME", "fr", "de-CH", "en")
+
+	expected := "Bitte geben Sie Ihren Vornamen an."
+	if !ok || actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Message_WithCaseInsensitiveLanguage(t *testing.T) {
+	c := Catalog{
+		"pt-BR":   {"MISSING_FIRST_NAME": "Informe seu nome."},
+		"zh-Hant": {"MISSING_FIRST_NAME": "請提供您的名字。"},
+	}
+
+	testCases := []struct {
+		lang, expected string
+	}{
+		{"pt-BR", "Informe seu nome."},
+		{"pt-br", "Informe seu nome."},
+		{"zh-Hant", "請提供您的名字。"},
+		{"ZH-HANT", "請提供您的名字。"},
+	}
+	for _, tc := range testCases {
+		actual, _ := c.Message("MISSING_FIRST_NAME", tc.lang)
+
+		if actual != tc.expected {
+			t.Errorf(expectedFormat, tc.expected, actual)
+		}
+	}
+}
+
+func Test_Message_WithUpperCaseLanguage(t *testing.T) {
+	actual, ok := catalog.Message("MISSING_FIRST_NAME", "DE-CH")
+
+	expected := "Bitte geben Sie Ihren Vornamen an."
+	if !ok || actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Message_WithoutTranslation(t *testing.T) {
+	_, ok := catalog.Message("MISSING_LAST_NAME", "de", "en")
+
+	if ok {
+		t.Error("Message() was expected to return false for a code without translation.")
+	}
+}
+
+func Test_Localize_WithMultipleUserErrors(t *testing.T) {
+	f := User("MISSING_FIRST_NAME", "first name")
+	f.Add("MISSING_LAST_NAME", "last name")
+
+	actual := f.Localize(catalog, "de").Error()
+
+	expected := "- Bitte geben Sie Ihren Vornamen an. (MISSING_FIRST_NAME)\n- last name (MISSING_LAST_NAME)"
+	if actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if f.ErrorMessages()[0] != "first name" {
+		t.Error("Localize() was not expected to modify the original UserError.")
+	}
+}
@@ -0,0 +1,85 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+var invariantPanics atomic.Bool
+
+// Invariant creates a new SystemError fault which represents a bug rather than
+// an environmental failure (e.g. an impossible state). It is classified as Bug,
+// has the SeverityCritical severity and always contains the stack trace.
+//
+// If invariant panics have been enabled (see SetInvariantPanics) then Invariant
+// panics with the SystemError instead of returning it.
+//
+//	Example:
+//	   default:
+//	      return fault.Invariant("impossible state: %v", state)
+func Invariant(format string, a ...interface{}) *SystemError {
+	return invariant(fmt.Sprintf(format, a...))
+}
+
+func invariant(msg string) *SystemError {
+	e := created(&SystemError{
+		err:      errors.New(msg),
+		msgs:     []string{msg},
+		stack:    capturer().Capture(),
+		kind:     Bug,
+		severity: SeverityCritical,
+	})
+	if invariantPanics.Load() {
+		panic(e)
+	}
+	return e
+}
+
+// SetInvariantPanics configures whether Invariant panics, which surfaces bugs
+// immediately in development builds and tests. It returns a function which
+// restores the previous configuration.
+//
+//	Example:
+//	   if os.Getenv("APP_ENV") == "development" {
+//	      fault.SetInvariantPanics(true)
+//	   }
+func SetInvariantPanics(enabled bool) (restore func()) {
+	previous := invariantPanics.Swap(enabled)
+	return func() {
+		invariantPanics.Store(previous)
+	}
+}
+
+// Unreachable creates a new SystemError fault for a code path which must never be reached.
+// It behaves like Invariant and prefixes the message with "unreachable: ".
+//
+//	Example:
+//	   switch kind {
+//	   ...
+//	   default:
+//	      return fault.Unreachable("unknown kind " + kind)
+//	   }
+func Unreachable(msg string) *SystemError {
+	return invariant("unreachable: " + msg)
+}
+
+// NotImplemented creates a new SystemError fault of the Unimplemented kind
+// for a feature which hasn't been implemented (yet).
+//
+//	Example:
+//	   return fault.NotImplemented("bulk export")
+func NotImplemented(feature string) *SystemError {
+	msg := feature + " is not implemented"
+	return created(&SystemError{
+		err:   errors.New(msg),
+		msgs:  []string{msg},
+		stack: capturer().Capture(),
+		kind:  Unimplemented,
+	})
+}
+
+// IsBug reports whether the error's chain has been classified as Bug.
+func IsBug(err error) bool {
+	return err != nil && KindOf(err) == Bug
+}
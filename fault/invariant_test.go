@@ -0,0 +1,57 @@
+package fault
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Invariant(t *testing.T) {
+	err := Invariant("impossible state: %v", 42)
+
+	if err.Error() != "impossible state: 42" {
+		t.Errorf(expectedFormat, "impossible state: 42", err.Error())
+	}
+	if !IsBug(err) || err.Severity() != SeverityCritical {
+		t.Errorf(expectedFormat, "bug critical", fmt.Sprint(err.Kind(), err.Severity()))
+	}
+	if len(err.Trace().Frames()) == 0 {
+		t.Error("The invariant was expected to have a stack trace.")
+	}
+	if IsBug(System("a")) || IsBug(nil) {
+		t.Error("Only invariants were expected to be bugs.")
+	}
+}
+
+func Test_Invariant_WithPanics(t *testing.T) {
+	restore := SetInvariantPanics(true)
+	defer restore()
+
+	defer func() {
+		r := recover()
+		err, ok := r.(*SystemError)
+		if !ok || !IsBug(err) {
+			t.Errorf(expectedFormat, "*SystemError (bug)", r)
+		}
+	}()
+	_ = Invariant("impossible")
+	t.Error("Invariant was expected to panic.")
+}
+
+func Test_Unreachable(t *testing.T) {
+	err := Unreachable("unknown state")
+
+	if err.Error() != "unreachable: unknown state" || !IsBug(err) {
+		t.Errorf(expectedFormat, "unreachable: unknown state (bug)", fmt.Sprint(err, err.Kind()))
+	}
+}
+
+func Test_NotImplemented(t *testing.T) {
+	err := NotImplemented("bulk export")
+
+	if err.Error() != "bulk export is not implemented" || err.Kind() != Unimplemented {
+		t.Errorf(expectedFormat, "bulk export is not implemented (unimplemented)", fmt.Sprint(err, err.Kind()))
+	}
+	if IsBug(err) || len(err.Trace().Frames()) == 0 {
+		t.Error("NotImplemented was expected to create a fault with a stack trace which is not a bug.")
+	}
+}
@@ -0,0 +1,112 @@
+package fault
+
+import "errors"
+
+// Kind classifies a fault by the nature of the underlying cause.
+//
+// The kind allows higher level application code (e.g. a HTTP or gRPC handler)
+// to decide how to deal with a SystemError without having to inspect the cause.
+type Kind string
+
+const (
+	// Internal is the kind of a SystemError which hasn't been classified otherwise.
+	Internal Kind = "internal"
+
+	// Canceled indicates that the operation was canceled, typically by the caller.
+	Canceled Kind = "canceled"
+
+	// Timeout indicates that the operation didn't complete in time.
+	Timeout Kind = "timeout"
+
+	// Unavailable indicates that a dependency is (temporarily) unavailable.
+	Unavailable Kind = "unavailable"
+
+	// NotFound indicates that a requested resource doesn't exist.
+	NotFound Kind = "not_found"
+
+	// Conflict indicates that the operation conflicts with the current state of a resource.
+	Conflict Kind = "conflict"
+
+	// PermissionDenied indicates that the caller isn't permitted to perform the operation.
+	PermissionDenied Kind = "permission_denied"
+
+	// Unauthenticated indicates that the caller couldn't be authenticated.
+	Unauthenticated Kind = "unauthenticated"
+
+	// ResourceExhausted indicates that a quota or rate limit has been exceeded.
+	ResourceExhausted Kind = "resource_exhausted"
+
+	// Unimplemented indicates that the operation hasn't been implemented (see NotImplemented).
+	Unimplemented Kind = "unimplemented"
+
+	// Bug indicates a programming error rather than an environmental failure (see Invariant).
+	Bug Kind = "bug"
+
+	// InvalidArgument indicates that the operation failed due to invalid input
+	// of the end user. It is the kind of every UserError.
+	InvalidArgument Kind = "invalid_argument"
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	return string(k)
+}
+
+// WithKind classifies the SystemError with the given kind.
+func (e *SystemError) WithKind(kind Kind) *SystemError {
+	e.kind = kind
+	return e
+}
+
+// Kind returns the kind of the SystemError.
+//
+// If the SystemError hasn't been classified itself then the kind of the
+// error which it wraps will be returned, or Internal if none has been classified.
+func (e *SystemError) Kind() Kind {
+	if e == nil {
+		return ""
+	}
+	if e.kind != "" {
+		return e.kind
+	}
+	return KindOf(e.err)
+}
+
+// Kind returns InvalidArgument, which is the kind of every UserError.
+func (e *UserError) Kind() Kind {
+	return InvalidArgument
+}
+
+// KindOf returns the kind of the first classified SystemError or UserError in the error's chain.
+// The causes of errors with multiple causes (e.g. an Aggregate) are searched depth-first.
+// It returns Internal if the chain doesn't contain any classified fault.
+func KindOf(err error) Kind {
+	if kind, ok := faultKind(err); ok {
+		return kind
+	}
+	return Internal
+}
+
+// faultKind returns the kind of the first classified SystemError or UserError in the error's chain.
+func faultKind(err error) (Kind, bool) {
+	for err != nil {
+		// nolint: errorlint // Walking the chain manually:
+		switch e := err.(type) {
+		case *SystemError:
+			if e.kind != "" {
+				return e.kind, true
+			}
+		case *UserError:
+			return InvalidArgument, true
+		case interface{ Unwrap() []error }:
+			for _, cause := range e.Unwrap() {
+				if kind, ok := faultKind(cause); ok {
+					return kind, true
+				}
+			}
+			return "", false
+		}
+		err = errors.Unwrap(err)
+	}
+	return "", false
+}
@@ -0,0 +1,61 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_Kind_WithoutClassification(t *testing.T) {
+	f := SystemWrap(errors.New("foo bar"), "f")
+
+	actual := f.Kind()
+
+	if actual != Internal {
+		t.Errorf(expectedFormat, Internal, actual)
+	}
+}
+
+func Test_Kind_WithClassifiedInnerError(t *testing.T) {
+	f1 := System("c").WithKind(Timeout)
+	f2 := SystemWrap(f1, "f")
+	f3 := SystemWrap(f2, "i")
+
+	actual := f3.Kind()
+
+	if actual != Timeout {
+		t.Errorf(expectedFormat, Timeout, actual)
+	}
+}
+
+func Test_Kind_WithReclassifiedOuterError(t *testing.T) {
+	f1 := System("c").WithKind(Timeout)
+	f2 := SystemWrap(f1, "f").WithKind(Unavailable)
+
+	actual := f2.Kind()
+
+	if actual != Unavailable {
+		t.Errorf(expectedFormat, Unavailable, actual)
+	}
+}
+
+func Test_KindOf_WithNonSystemErrorAtTheTop(t *testing.T) {
+	f1 := System("c").WithKind(NotFound)
+	f2 := fmt.Errorf("f: %w", f1)
+
+	actual := KindOf(f2)
+
+	if actual != NotFound {
+		t.Errorf(expectedFormat, NotFound, actual)
+	}
+}
+
+func Test_KindOf_WithWrappedUserError(t *testing.T) {
+	f := SystemWrap(User("a", "aaa"), "b")
+
+	actual := KindOf(f)
+
+	if actual != InvalidArgument {
+		t.Errorf(expectedFormat, InvalidArgument, actual)
+	}
+}
@@ -0,0 +1,80 @@
+package fault
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Limits protect log pipelines from pathological errors, e.g. errors which embed
+// a huge payload in their message or which have been wrapped hundreds of times.
+// A zero value of a limit means that it isn't limited.
+type Limits struct {
+	// MaxMessageLength is the maximum number of bytes of a single message.
+	// Longer messages are cut off and end with a truncation marker.
+	MaxMessageLength int
+
+	// MaxChainDepth is the maximum number of lines of a SystemError's message chain.
+	// The innermost message is always retained, since it usually describes the
+	// root cause, and the omitted messages are replaced by a truncation marker
+	// which counts towards the limit. With a limit of 1 only the innermost message remains.
+	MaxChainDepth int
+}
+
+var currentLimits atomic.Value
+
+func init() {
+	currentLimits.Store(Limits{})
+}
+
+// SetLimits sets the limits which apply when faults are rendered as text (e.g. by Error,
+// String and Markdown) and returns a function which restores the previous limits.
+// Serialized faults (e.g. by Encode) and the accessors (e.g. Messages) are not limited,
+// so that no information gets lost when a fault is transferred to another process.
+//
+//	Example:
+//	   fault.SetLimits(fault.Limits{MaxMessageLength: 4096, MaxChainDepth: 32})
+func SetLimits(l Limits) (restore func()) {
+	previous := currentLimits.Swap(l)
+	return func() {
+		currentLimits.Store(previous)
+	}
+}
+
+func limits() Limits {
+	return currentLimits.Load().(Limits)
+}
+
+// truncateMessage cuts off the message after the maximum number of bytes
+// without splitting a UTF-8 character.
+func truncateMessage(msg string, max int) string {
+	if max <= 0 || len(msg) <= max {
+		return msg
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s…[%d bytes truncated]", msg[:cut], len(msg)-cut)
+}
+
+// limitMessages applies the limits to a message chain, starting with the outermost message.
+func limitMessages(msgs []string) []string {
+	l := limits()
+	if l.MaxMessageLength > 0 {
+		for i, msg := range msgs {
+			msgs[i] = truncateMessage(msg, l.MaxMessageLength)
+		}
+	}
+	switch {
+	case l.MaxChainDepth <= 0 || len(msgs) <= l.MaxChainDepth:
+		return msgs
+	case l.MaxChainDepth == 1:
+		return msgs[len(msgs)-1:]
+	default:
+		kept := l.MaxChainDepth - 2
+		limited := append([]string(nil), msgs[:kept]...)
+		limited = append(limited, fmt.Sprintf("…[%d messages truncated]", len(msgs)-kept-1))
+		return append(limited, msgs[len(msgs)-1])
+	}
+}
@@ -0,0 +1,90 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_SetLimits_MaxMessageLength(t *testing.T) {
+	restore := SetLimits(Limits{MaxMessageLength: 10})
+	defer restore()
+
+	err := SystemWrap(errors.New(strings.Repeat("x", 100)), "failed to parse payload")
+
+	expected := "failed to …[13 bytes truncated]\n   xxxxxxxxxx…[90 bytes truncated]"
+	if actual := err.Error(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_SetLimits_MaxMessageLength_KeepsCharactersIntact(t *testing.T) {
+	expected := "gr…[5 bytes truncated]"
+	if actual := truncateMessage("grüße", 3); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_SetLimits_MaxMessageLength_UserError(t *testing.T) {
+	restore := SetLimits(Limits{MaxMessageLength: 5})
+	defer restore()
+
+	err := User("INVALID_NAME", "'aaaaaaaaaa' is not a valid name.")
+
+	expected := "'aaaa…[28 bytes truncated] (INVALID_NAME)"
+	if actual := err.Error(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_SetLimits_MaxChainDepth(t *testing.T) {
+	restore := SetLimits(Limits{MaxChainDepth: 3})
+	defer restore()
+
+	err := SystemWrap(errors.New("root cause"), "layer 1")
+	for i := 2; i <= 10; i++ {
+		err = SystemWrapf(err, "layer %d", i)
+	}
+
+	expected := "layer 10\n   …[9 messages truncated]\n      root cause"
+	if actual := err.Error(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := len(err.Messages()); actual != 11 {
+		t.Errorf(expectedFormat, "11 messages", fmt.Sprint(actual))
+	}
+}
+
+func Test_SetLimits_MaxChainDepth_One(t *testing.T) {
+	restore := SetLimits(Limits{MaxChainDepth: 1})
+	defer restore()
+
+	err := SystemWrap(SystemWrap(errors.New("root cause"), "layer 1"), "layer 2")
+
+	if actual := err.Error(); actual != "root cause" {
+		t.Errorf(expectedFormat, "root cause", actual)
+	}
+}
+
+func Test_SetLimits_DoesntLimitEncode(t *testing.T) {
+	restore := SetLimits(Limits{MaxMessageLength: 5, MaxChainDepth: 2})
+	defer restore()
+
+	err := SystemWrap(SystemWrap(errors.New("root cause"), "layer 1"), "layer 2")
+	decoded := Decode(Encode(err))
+	restore()
+
+	if decoded.Error() != err.Error() {
+		t.Errorf(expectedFormat, err.Error(), decoded.Error())
+	}
+}
+
+func Test_SetLimits_Restore(t *testing.T) {
+	restore := SetLimits(Limits{MaxMessageLength: 1})
+	restore()
+
+	if actual := System("unlimited").Error(); actual != "unlimited" {
+		t.Errorf(expectedFormat, "unlimited", actual)
+	}
+}
@@ -0,0 +1,197 @@
+package fault
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Listener receives the errors which are published to a Bus (e.g. a logger,
+// a metrics recorder or an error reporter).
+//
+// Errors are published once they are complete, i.e. where they get logged rather than
+// where they get created, so that Listeners see the kind, severity and fields which have
+// been attached on the way up. The httpfault.Responder and the gRPC, Connect, Twirp and
+// GraphQL integrations publish the server side errors which they log to the DefaultBus.
+type Listener func(err error)
+
+// DropPolicy decides which error is discarded when the queue of a Subscription is full.
+type DropPolicy int
+
+const (
+	// DropNewest discards the error which is being published.
+	DropNewest DropPolicy = iota
+
+	// DropOldest discards the oldest queued error in favour of the one which is being published.
+	DropOldest
+)
+
+// DefaultQueueSize is the queue size of a Subscription if none has been set (see WithQueueSize).
+const DefaultQueueSize = 64
+
+type listenerConfig struct {
+	queueSize int
+	policy    DropPolicy
+}
+
+// ListenerOption configures a Subscription.
+type ListenerOption func(*listenerConfig)
+
+// WithQueueSize sets the number of errors which can be queued for the Listener
+// before the drop policy applies.
+func WithQueueSize(size int) ListenerOption {
+	return func(c *listenerConfig) {
+		c.queueSize = size
+	}
+}
+
+// WithDropPolicy sets the policy which applies when the queue of the Listener is full.
+func WithDropPolicy(policy DropPolicy) ListenerOption {
+	return func(c *listenerConfig) {
+		c.policy = policy
+	}
+}
+
+// Subscription delivers the published errors to a Listener on a dedicated goroutine.
+type Subscription struct {
+	bus      *Bus
+	listener Listener
+	policy   DropPolicy
+	queue    chan error
+	done     chan struct{}
+	once     sync.Once
+	dropped  atomic.Uint64
+}
+
+func (s *Subscription) run() {
+	defer close(s.done)
+	for err := range s.queue {
+		s.listener(err)
+	}
+}
+
+func (s *Subscription) enqueue(err error) {
+	select {
+	case s.queue <- err:
+		return
+	default:
+	}
+	if s.policy == DropOldest {
+		select {
+		case <-s.queue:
+			s.dropped.Add(1)
+		default:
+		}
+		select {
+		case s.queue <- err:
+			return
+		default:
+		}
+	}
+	s.dropped.Add(1)
+}
+
+// Dropped returns the number of errors which have been discarded
+// because the queue of the Listener was full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close unsubscribes the Listener and waits until all queued errors have been delivered.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.bus.remove(s)
+		close(s.queue)
+	})
+	<-s.done
+}
+
+// Bus fans out errors to multiple Listeners asynchronously.
+//
+// Every Listener has its own bounded queue, so that a slow Listener
+// neither delays the error path nor the other Listeners.
+// When a queue is full the error gets discarded according to the drop policy.
+type Bus struct {
+	mu   sync.RWMutex
+	subs []*Subscription
+}
+
+// Subscribe registers a Listener which receives all errors which
+// are published after the subscription (see Listener).
+//
+//	Example:
+//	   sub := fault.Subscribe(func(err error) {
+//	      errorCounter.WithLabelValues(fault.KindOf(err).String()).Inc()
+//	   }, fault.WithQueueSize(256), fault.WithDropPolicy(fault.DropOldest))
+//	   defer sub.Close()
+func (b *Bus) Subscribe(listener Listener, opts ...ListenerOption) *Subscription {
+	cfg := listenerConfig{queueSize: DefaultQueueSize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.queueSize <= 0 {
+		cfg.queueSize = DefaultQueueSize
+	}
+	s := &Subscription{
+		bus:      b,
+		listener: listener,
+		policy:   cfg.policy,
+		queue:    make(chan error, cfg.queueSize),
+		done:     make(chan struct{}),
+	}
+	go s.run()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, s)
+	return s
+}
+
+// Publish queues the error for all Listeners without blocking.
+// Nothing will be published if the error is nil.
+//
+// The error must not be modified anymore once it has been published
+// (e.g. by attaching fields), since the Listeners read it concurrently.
+func (b *Bus) Publish(err error) {
+	if err == nil {
+		return
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, s := range b.subs {
+		s.enqueue(err)
+	}
+}
+
+// Close closes all subscriptions and waits until all queued errors have been delivered.
+func (b *Bus) Close() {
+	b.mu.RLock()
+	subs := append([]*Subscription(nil), b.subs...)
+	b.mu.RUnlock()
+	for _, s := range subs {
+		s.Close()
+	}
+}
+
+func (b *Bus) remove(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, sub := range b.subs {
+		if sub == s {
+			b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// DefaultBus is the Bus which is used by Subscribe and Publish.
+var DefaultBus = &Bus{}
+
+// Subscribe registers a Listener with the DefaultBus.
+func Subscribe(listener Listener, opts ...ListenerOption) *Subscription {
+	return DefaultBus.Subscribe(listener, opts...)
+}
+
+// Publish queues the error for all Listeners of the DefaultBus without blocking.
+func Publish(err error) {
+	DefaultBus.Publish(err)
+}
@@ -0,0 +1,104 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func Test_Bus_Publish_FansOut(t *testing.T) {
+	bus := &Bus{}
+	var mu sync.Mutex
+	var received []string
+	for _, name := range []string{"logger", "metrics"} {
+		name := name
+		bus.Subscribe(func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, name+": "+err.Error())
+		})
+	}
+
+	bus.Publish(System("boom"))
+	bus.Publish(nil)
+	bus.Close()
+
+	expected := 2
+	if actual := len(received); actual != expected {
+		t.Errorf(expectedFormat, fmt.Sprint(expected), fmt.Sprint(actual))
+	}
+}
+
+func Test_Bus_Publish_DropNewest(t *testing.T) {
+	bus := &Bus{}
+	started := make(chan struct{}, 3)
+	block := make(chan struct{})
+	var received []string
+	sub := bus.Subscribe(func(err error) {
+		started <- struct{}{}
+		<-block
+		received = append(received, err.Error())
+	}, WithQueueSize(1))
+
+	bus.Publish(errors.New("a"))
+	// Wait until the listener has dequeued a:
+	<-started
+	bus.Publish(errors.New("b"))
+	bus.Publish(errors.New("c"))
+	close(block)
+	sub.Close()
+
+	expected := "[a b]"
+	if actual := fmt.Sprint(received); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := sub.Dropped(); actual != 1 {
+		t.Errorf(expectedFormat, "1", fmt.Sprint(actual))
+	}
+}
+
+func Test_Bus_Publish_DropOldest(t *testing.T) {
+	bus := &Bus{}
+	started := make(chan struct{}, 3)
+	block := make(chan struct{})
+	var received []string
+	sub := bus.Subscribe(func(err error) {
+		started <- struct{}{}
+		<-block
+		received = append(received, err.Error())
+	}, WithQueueSize(1), WithDropPolicy(DropOldest))
+
+	bus.Publish(errors.New("a"))
+	// Wait until the listener has dequeued a:
+	<-started
+	bus.Publish(errors.New("b"))
+	bus.Publish(errors.New("c"))
+	close(block)
+	sub.Close()
+
+	expected := "[a c]"
+	if actual := fmt.Sprint(received); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := sub.Dropped(); actual != 1 {
+		t.Errorf(expectedFormat, "1", fmt.Sprint(actual))
+	}
+}
+
+func Test_Subscription_Close_Unsubscribes(t *testing.T) {
+	bus := &Bus{}
+	count := 0
+	sub := bus.Subscribe(func(err error) {
+		count++
+	})
+
+	bus.Publish(System("first"))
+	sub.Close()
+	sub.Close()
+	bus.Publish(System("second"))
+
+	if count != 1 {
+		t.Errorf(expectedFormat, "1", fmt.Sprint(count))
+	}
+}
@@ -0,0 +1,104 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// markdownEscaper escapes the characters which have a special meaning in Markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `|`, `\|`, `#`, `\#`,
+)
+
+// Markdown returns the user errors as a bulleted list in Markdown.
+//
+//	Example:
+//	   - Please provide a name. (`MISSING_NAME`)
+//	   - Please provide an email address. (`MISSING_EMAIL`)
+func (e *UserError) Markdown() string {
+	if e == nil {
+		return ""
+	}
+	sb := strings.Builder{}
+	for i, code := range e.codes {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("- %s (`%s`)", markdownEscaper.Replace(Scrub(e.messages[i])), code))
+	}
+	return sb.String()
+}
+
+// Markdown returns the SystemError in Markdown, e.g. for an issue or a chat message:
+// the outermost message as the title, followed by the messages of its causes,
+// the kind, the fields and a collapsible section with the stack trace.
+//
+//	Example:
+//	   **failed to handle request**
+//
+//	   - failed to load user
+//	   - connection refused
+//
+//	   Kind: `unavailable`
+//
+//	   <details>
+//	   <summary>Stack trace</summary>
+//	   ...
+//	   </details>
+func (e *SystemError) Markdown() string {
+	if e == nil {
+		return ""
+	}
+	msgs := limitMessages(e.Messages())
+	sb := strings.Builder{}
+	if len(msgs) > 0 {
+		sb.WriteString("**" + markdownEscaper.Replace(Scrub(msgs[0])) + "**\n\n")
+		for _, msg := range msgs[1:] {
+			sb.WriteString("- " + markdownEscaper.Replace(Scrub(msg)) + "\n")
+		}
+		if len(msgs) > 1 {
+			sb.WriteString("\n")
+		}
+	}
+	sb.WriteString(fmt.Sprintf("Kind: `%s`\n", e.Kind()))
+
+	fields := ScrubFields(e.Fields())
+	if len(fields) > 0 {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		sb.WriteString("\n| Field | Value |\n| --- | --- |\n")
+		for _, k := range keys {
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n",
+				markdownEscaper.Replace(k), markdownEscaper.Replace(fmt.Sprint(fields[k]))))
+		}
+	}
+
+	sb.WriteString("\n<details>\n<summary>Stack trace</summary>\n\n```\n")
+	sb.WriteString(strings.TrimPrefix(e.StackTrace(), "\n"))
+	sb.WriteString("\n```\n\n</details>")
+	return sb.String()
+}
+
+// Markdown renders the first SystemError or UserError of the error's chain in Markdown.
+// Other errors are rendered as their escaped message. Messages and fields are scrubbed
+// by the DefaultScrubber. It returns an empty string if the error is nil.
+func Markdown(err error) string {
+	if err == nil {
+		return ""
+	}
+	var sysErr *SystemError
+	if errors.As(err, &sysErr) {
+		return sysErr.Markdown()
+	}
+	var userErr *UserError
+	if errors.As(err, &userErr) {
+		return userErr.Markdown()
+	}
+	return markdownEscaper.Replace(Scrub(err.Error()))
+}
@@ -0,0 +1,57 @@
+package fault
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/dusted-go/fault/stack"
+)
+
+func Test_UserError_Markdown(t *testing.T) {
+	err := User("INVALID_NAME", "'<b>' is not a valid name.")
+	err.Add("MISSING_EMAIL", "Please provide an email address.")
+
+	expected := "- '\\<b\\>' is not a valid name. (`INVALID_NAME`)\n" +
+		"- Please provide an email address. (`MISSING_EMAIL`)"
+	if actual := err.Markdown(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_SystemError_Markdown(t *testing.T) {
+	restore := SetCapturer(fixedCapturer{trace: stack.Synthetic(
+		runtime.Frame{Function: "main.main", File: "/app/main.go", Line: 12},
+	)})
+	defer restore()
+	err := SystemWrap(errors.New("connection refused"), "failed to load user").
+		WithKind(Unavailable).
+		WithField("user_id", 42)
+
+	expected := "**failed to load user**\n\n" +
+		"- connection refused\n\n" +
+		"Kind: `unavailable`\n\n" +
+		"| Field | Value |\n| --- | --- |\n| user\\_id | 42 |\n\n" +
+		"<details>\n<summary>Stack trace</summary>\n\n" +
+		"```\nat /app/main.go:12\n   --> main.main\n```\n\n" +
+		"</details>"
+	if actual := err.Markdown(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Markdown(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"nil":        {nil, ""},
+		"user error": {User("MISSING_NAME", "Please provide a name."), "- Please provide a name. (`MISSING_NAME`)"},
+		"other":      {errors.New("file_not_found"), "file\\_not\\_found"},
+	}
+	for name, tc := range testCases {
+		if actual := Markdown(tc.err); actual != tc.expected {
+			t.Errorf(expectedFormat, name+": "+tc.expected, name+": "+actual)
+		}
+	}
+}
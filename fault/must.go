@@ -0,0 +1,48 @@
+package fault
+
+// Must returns the value if the error is nil and panics with a SystemError otherwise.
+// A SystemError gets panicked as is, any other error gets wrapped into a SystemError
+// whose stack trace points to the caller of Must.
+//
+// Must is intended for initialization code and for panic-based control
+// flow in combination with Try.
+//
+//	Example:
+//	   var tmpl = fault.Must(template.ParseFS(fs, "*.html"))
+func Must[T any](value T, err error) T {
+	if err == nil {
+		return value
+	}
+	// nolint: errorlint // Only the outermost error is panicked as is:
+	if sysErr, ok := err.(*SystemError); ok {
+		panic(sysErr)
+	}
+	panic(created(&SystemError{
+		err:   err,
+		msgs:  []string{err.Error()},
+		stack: capturer().Capture(),
+	}))
+}
+
+// Try invokes the function and converts a panic back into an error.
+// A panicked SystemError (e.g. by Must) gets returned as is and any other
+// panic value gets converted by FromPanic.
+//
+//	Example:
+//	   err := fault.Try(func() error {
+//	      cfg := fault.Must(loadConfig())
+//	      return run(cfg)
+//	   })
+func Try(fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			// nolint: errorlint // Only a panicked SystemError is returned as is:
+			if sysErr, ok := v.(*SystemError); ok {
+				err = sysErr
+				return
+			}
+			err = FromPanic(v)
+		}
+	}()
+	return fn()
+}
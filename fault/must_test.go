@@ -0,0 +1,72 @@
+package fault
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_Must_WithoutError(t *testing.T) {
+	actual := Must(strconv.Atoi("42"))
+
+	if actual != 42 {
+		t.Errorf(expectedFormat, "42", strconv.Itoa(actual))
+	}
+}
+
+func Test_Try_WithMust(t *testing.T) {
+	err := Try(func() error {
+		Must(strconv.Atoi("a"))
+		return nil
+	})
+
+	var sysErr *SystemError
+	if !errors.As(err, &sysErr) {
+		t.Fatalf(expectedFormat, "*SystemError", err)
+	}
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Errorf(expectedFormat, "*strconv.NumError", err)
+	}
+	frames := sysErr.Trace().Frames()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "Test_Try_WithMust.func1") {
+		t.Errorf(expectedFormat, "Test_Try_WithMust.func1", sysErr.StackTrace())
+	}
+}
+
+func Test_Try_WithSystemError(t *testing.T) {
+	sysErr := System("a")
+
+	err := Try(func() error {
+		Must("", sysErr)
+		return nil
+	})
+
+	if err != sysErr {
+		t.Errorf(expectedFormat, sysErr, err)
+	}
+}
+
+func Test_Try_WithPanic(t *testing.T) {
+	err := Try(func() error {
+		panic("boom")
+	})
+
+	expected := "panic: boom"
+	if err == nil || err.Error() != expected {
+		t.Errorf(expectedFormat, expected, err)
+	}
+}
+
+func Test_Try_WithoutPanic(t *testing.T) {
+	userErr := User("a", "aaa")
+
+	err := Try(func() error {
+		return userErr
+	})
+
+	if err != userErr {
+		t.Errorf(expectedFormat, userErr, err)
+	}
+}
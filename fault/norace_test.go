@@ -0,0 +1,6 @@
+//go:build !race
+
+package fault
+
+// raceEnabled reports whether the race detector is enabled, which adds allocations of its own.
+const raceEnabled = false
@@ -0,0 +1,71 @@
+package fault
+
+import (
+	"errors"
+	"strings"
+)
+
+// Op is the name of a logical operation, conventionally the package
+// and method name (e.g. "billing.Charge").
+type Op string
+
+// String returns the name of the operation.
+func (o Op) String() string {
+	return string(o)
+}
+
+// OpWrap creates a new SystemError which wraps an existing error and is annotated
+// with the operation during which the error occurred. The name of the operation
+// becomes the message of the SystemError, so that the error message of a chain
+// which has been wrapped by each layer renders as the path of operations.
+//
+//	Example:
+//	   const op fault.Op = "billing.Charge"
+//	   if err := payments.Authorize(ctx, amount); err != nil {
+//	      return fault.OpWrap(op, err)
+//	   }
+//
+// renders as:
+//
+//	billing.Charge
+//	   payments.Authorize
+//	      connection refused
+func OpWrap(op Op, err error) *SystemError {
+	e := systemWrap(err, string(op), callSite(0))
+	e.op = op
+	return e
+}
+
+// Op returns the operation of the SystemError, or an empty string
+// if it hasn't been created by OpWrap.
+func (e *SystemError) Op() Op {
+	if e == nil {
+		return ""
+	}
+	return e.op
+}
+
+// Ops returns the operations of all SystemErrors in the error's
+// chain which have been created by OpWrap, starting with the outermost.
+func Ops(err error) []Op {
+	var ops []Op
+	for err != nil {
+		// nolint: errorlint // Walking the chain manually:
+		if sysErr, ok := err.(*SystemError); ok && sysErr.op != "" {
+			ops = append(ops, sysErr.op)
+		}
+		err = errors.Unwrap(err)
+	}
+	return ops
+}
+
+// OpPath returns the operations of the error's chain as a single line
+// (e.g. "billing.Charge: payments.Authorize").
+func OpPath(err error) string {
+	ops := Ops(err)
+	names := make([]string, len(ops))
+	for i, op := range ops {
+		names[i] = string(op)
+	}
+	return strings.Join(names, ": ")
+}
@@ -0,0 +1,50 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_OpWrap(t *testing.T) {
+	inner := OpWrap("payments.Authorize", errors.New("connection refused"))
+	outer := OpWrap("billing.Charge", fmt.Errorf("charging: %w", inner))
+
+	expected := "billing.Charge\n   charging: payments.Authorize\n   connection refused"
+	if outer.Error() != expected {
+		t.Errorf(expectedFormat, expected, outer.Error())
+	}
+	if outer.Op() != "billing.Charge" {
+		t.Errorf(expectedFormat, "billing.Charge", outer.Op())
+	}
+
+	expected = "billing.Charge: payments.Authorize"
+	if actual := OpPath(outer); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_OpWrap_WithLayersOfOps(t *testing.T) {
+	err := OpWrap("billing.Charge", OpWrap("payments.Authorize", errors.New("connection refused")))
+
+	expected := "billing.Charge\n   payments.Authorize\n      connection refused"
+	if err.Error() != expected {
+		t.Errorf(expectedFormat, expected, err.Error())
+	}
+}
+
+func Test_Ops_WithoutOps(t *testing.T) {
+	if ops := Ops(System("a")); len(ops) != 0 {
+		t.Errorf(expectedFormat, "[]", fmt.Sprint(ops))
+	}
+}
+
+func Test_OpWrap_EncodeDecode(t *testing.T) {
+	err := OpWrap("billing.Charge", errors.New("connection refused"))
+
+	actual := OpPath(Decode(Encode(err)))
+
+	if actual != "billing.Charge" {
+		t.Errorf(expectedFormat, "billing.Charge", actual)
+	}
+}
@@ -0,0 +1,42 @@
+package fault
+
+// UserParams creates a new UserError fault whose message contains arguments
+// (see FormatMessage) which get formatted with the params using the plural rules
+// of English. The params are retained, so that the translations of the message
+// get formatted with them as well (see Localize and Catalog.Format).
+//
+//	Example:
+//	   fault.UserParams("INVALID_ITEMS",
+//	      "{count, plural, one {# item} other {# items}} failed validation.",
+//	      fault.Params{"count": len(invalid)})
+func UserParams(code Code, msg string, params Params) *UserError {
+	e := &UserError{}
+	e.AddParams(code, msg, params)
+	return e
+}
+
+// AddParams appends an additional user error whose message contains arguments
+// which get formatted with the params (see UserParams).
+func (e *UserError) AddParams(code Code, msg string, params Params) {
+	e.Add(code, FormatMessage(msg, "en", params))
+	e.setParams(string(code), params)
+}
+
+// Params returns the params of the error with the given code,
+// or nil if it has been created without params.
+func (e *UserError) Params(code Code) Params {
+	if e == nil {
+		return nil
+	}
+	return e.params[string(code)]
+}
+
+func (e *UserError) setParams(code string, params Params) {
+	if params == nil {
+		return
+	}
+	if e.params == nil {
+		e.params = map[string]Params{}
+	}
+	e.params[code] = params
+}
@@ -0,0 +1,20 @@
+package fault
+
+import "testing"
+
+func Test_UserParams_WithLocalize(t *testing.T) {
+	c := Catalog{
+		"de": {"INVALID_ITEMS": "{count, plural, one {# Eintrag ist} other {# Einträge sind}} ungültig."},
+	}
+	err := UserParams("INVALID_ITEMS", "{count, plural, one {# item is} other {# items are}} invalid.", Params{"count": 2})
+
+	if actual := err.FriendlyError(); actual != "2 items are invalid." {
+		t.Errorf(expectedFormat, "2 items are invalid.", actual)
+	}
+	if actual := err.Localize(c, "de-AT").FriendlyError(); actual != "2 Einträge sind ungültig." {
+		t.Errorf(expectedFormat, "2 Einträge sind ungültig.", actual)
+	}
+	if err.Params("INVALID_ITEMS")["count"] != 2 {
+		t.Error("The params were expected to be retained.")
+	}
+}
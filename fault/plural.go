@@ -0,0 +1,178 @@
+package fault
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Params are the named arguments of a user error message (see FormatMessage).
+type Params map[string]interface{}
+
+// PluralRules returns the CLDR plural category ("zero", "one", "two", "few",
+// "many" or "other") of the count in the language (e.g. de or pt-BR).
+//
+// The faulttext package provides the plural rules of all CLDR languages.
+type PluralRules func(lang string, n int) string
+
+// EnglishPluralRules are the default PluralRules, which apply the
+// rules of the English language to all languages.
+func EnglishPluralRules(_ string, n int) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+var currentPluralRules atomic.Value
+
+func init() {
+	currentPluralRules.Store(PluralRules(EnglishPluralRules))
+}
+
+// SetPluralRules sets the plural rules which are used by FormatMessage
+// and returns a function which restores the previous rules.
+func SetPluralRules(r PluralRules) (restore func()) {
+	previous := currentPluralRules.Swap(r)
+	return func() {
+		currentPluralRules.Store(previous)
+	}
+}
+
+func pluralRules() PluralRules {
+	return currentPluralRules.Load().(PluralRules)
+}
+
+// FormatMessage replaces the arguments of a message with the params,
+// using the plural rules of the language.
+//
+// A simple argument is replaced by the value of the param:
+//
+//	Example:
+//	   The name {name} is already taken.
+//
+// A plural argument selects the case of an integer param by its exact value (=N),
+// by its plural category in the language or falls back to the other case.
+// A # in the selected case is replaced by the value of the param:
+//
+//	Example:
+//	   {count, plural, =0 {No items} one {# item} other {# items}} failed validation.
+//
+// Arguments which refer to a missing param remain unchanged.
+func FormatMessage(msg string, lang string, params Params) string {
+	if len(params) == 0 {
+		return msg
+	}
+	sb := strings.Builder{}
+	for {
+		start := strings.IndexByte(msg, '{')
+		if start < 0 {
+			break
+		}
+		end := closingBrace(msg, start)
+		if end < 0 {
+			break
+		}
+		sb.WriteString(msg[:start])
+		sb.WriteString(formatArgument(msg[start:end+1], lang, params))
+		msg = msg[end+1:]
+	}
+	sb.WriteString(msg)
+	return sb.String()
+}
+
+// closingBrace returns the index of the brace which closes the brace at the
+// start index, or -1 if the brace isn't closed.
+func closingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// formatArgument formats a single argument including its braces.
+// The argument is returned unchanged if it can't be formatted.
+func formatArgument(arg string, lang string, params Params) string {
+	parts := strings.SplitN(arg[1:len(arg)-1], ",", 3)
+	value, ok := params[strings.TrimSpace(parts[0])]
+	if !ok {
+		return arg
+	}
+	if len(parts) == 1 {
+		return fmt.Sprint(value)
+	}
+	n, ok := toInt(value)
+	if len(parts) != 3 || strings.TrimSpace(parts[1]) != "plural" || !ok {
+		return arg
+	}
+	cases := pluralCases(parts[2])
+	text, ok := cases["="+strconv.Itoa(n)]
+	if !ok {
+		text, ok = cases[pluralRules()(lang, n)]
+	}
+	if !ok {
+		text, ok = cases["other"]
+	}
+	if !ok {
+		return arg
+	}
+	return FormatMessage(strings.ReplaceAll(text, "#", strconv.Itoa(n)), lang, params)
+}
+
+// pluralCases parses the cases of a plural argument (e.g. "one {# item} other {# items}").
+func pluralCases(s string) map[string]string {
+	cases := map[string]string{}
+	for {
+		start := strings.IndexByte(s, '{')
+		if start < 0 {
+			return cases
+		}
+		end := closingBrace(s, start)
+		if end < 0 {
+			return cases
+		}
+		cases[strings.TrimSpace(s[:start])] = s[start+1 : end]
+		s = s[end+1:]
+	}
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case uint:
+		return int(n), true
+	case uint8:
+		return int(n), true
+	case uint16:
+		return int(n), true
+	case uint32:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	case float64:
+		// Decoded JSON numbers (see Decode) are float64.
+		return int(n), n == math.Trunc(n)
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,46 @@
+package fault
+
+import "testing"
+
+func Test_FormatMessage(t *testing.T) {
+	const msg = "{count, plural, =0 {No items} one {# item} other {# items}} of {owner} failed validation."
+	testCases := map[int]string{
+		0: "No items of Ann failed validation.",
+		1: "1 item of Ann failed validation.",
+		3: "3 items of Ann failed validation.",
+	}
+	for count, expected := range testCases {
+		actual := FormatMessage(msg, "en", Params{"count": count, "owner": "Ann"})
+		if actual != expected {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
+
+func Test_FormatMessage_WithMissingParams(t *testing.T) {
+	const msg = "{count, plural, one {# item} other {# items}} of {owner} failed."
+
+	actual := FormatMessage(msg, "en", Params{"count": "many"})
+
+	if actual != msg {
+		t.Errorf(expectedFormat, msg, actual)
+	}
+}
+
+func Test_SetPluralRules(t *testing.T) {
+	restore := SetPluralRules(func(lang string, n int) string {
+		if lang == "pl" && n%10 >= 2 && n%10 <= 4 {
+			return "few"
+		}
+		return EnglishPluralRules(lang, n)
+	})
+	defer restore()
+	const msg = "{count, plural, one {# plik} few {# pliki} other {# plików}}"
+
+	if actual := FormatMessage(msg, "pl", Params{"count": 3}); actual != "3 pliki" {
+		t.Errorf(expectedFormat, "3 pliki", actual)
+	}
+	if actual := FormatMessage(msg, "pl", Params{"count": 5}); actual != "5 plików" {
+		t.Errorf(expectedFormat, "5 plików", actual)
+	}
+}
@@ -0,0 +1,73 @@
+package fault
+
+import "sync/atomic"
+
+// Profile bundles the settings which usually differ between development and
+// production environments, so that they can be switched coherently (see SetProfile).
+type Profile struct {
+	// Name identifies the profile, e.g. in logs.
+	Name string
+
+	// StackDepth is the maximum number of frames which are captured for a SystemError.
+	StackDepth int
+
+	// SourceSnippets shows the source code around each frame of a stack trace on debug pages.
+	SourceSnippets bool
+
+	// Color enables ANSI colors when faults are written to a terminal.
+	Color bool
+
+	// MaskMessages replaces the messages of SystemErrors in responses to clients
+	// by generic messages which don't leak any internal details.
+	MaskMessages bool
+
+	// DebugAttachments attaches internal details to responses to clients,
+	// e.g. the debug page of httpfault or the DebugInfo detail of faultgrpc.
+	DebugAttachments bool
+}
+
+var (
+	// Development exposes as many details as possible.
+	Development = Profile{
+		Name:             "development",
+		StackDepth:       64,
+		SourceSnippets:   true,
+		Color:            true,
+		MaskMessages:     false,
+		DebugAttachments: true,
+	}
+
+	// Production doesn't expose any internal details and is the default profile.
+	Production = Profile{
+		Name:             "production",
+		StackDepth:       32,
+		SourceSnippets:   false,
+		Color:            false,
+		MaskMessages:     true,
+		DebugAttachments: false,
+	}
+)
+
+var currentProfile atomic.Value
+
+func init() {
+	currentProfile.Store(Production)
+}
+
+// SetProfile switches to the given profile and returns a function which restores the previous one.
+//
+//	Example:
+//	   if os.Getenv("APP_ENV") == "development" {
+//	      fault.SetProfile(fault.Development)
+//	   }
+func SetProfile(p Profile) (restore func()) {
+	previous := currentProfile.Swap(p)
+	return func() {
+		currentProfile.Store(previous)
+	}
+}
+
+// CurrentProfile returns the profile which is in effect.
+func CurrentProfile() Profile {
+	return currentProfile.Load().(Profile)
+}
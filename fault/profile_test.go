@@ -0,0 +1,37 @@
+package fault
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_CurrentProfile_DefaultsToProduction(t *testing.T) {
+	if actual := CurrentProfile().Name; actual != Production.Name {
+		t.Errorf(expectedFormat, Production.Name, actual)
+	}
+}
+
+func Test_SetProfile_Restore(t *testing.T) {
+	restore := SetProfile(Development)
+	if actual := CurrentProfile().Name; actual != Development.Name {
+		t.Errorf(expectedFormat, Development.Name, actual)
+	}
+	restore()
+
+	if actual := CurrentProfile().Name; actual != Production.Name {
+		t.Errorf(expectedFormat, Production.Name, actual)
+	}
+}
+
+func Test_SetProfile_StackDepth(t *testing.T) {
+	profile := Production
+	profile.StackDepth = 2
+	restore := SetProfile(profile)
+	defer restore()
+
+	err := System("boom")
+
+	if actual := len(*err.Trace()); actual != 2 {
+		t.Errorf(expectedFormat, "2", fmt.Sprint(actual))
+	}
+}
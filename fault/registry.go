@@ -0,0 +1,77 @@
+package fault
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// CodeInfo describes a user error code which an application can return.
+type CodeInfo struct {
+	// Code is the user error code (e.g. MISSING_FIRST_NAME).
+	Code Code
+
+	// Description explains when the error code is being returned.
+	Description string
+
+	// Status is the HTTP status code which should be returned alongside the error code.
+	// Zero means the default status code for user errors.
+	Status int
+
+	// GRPCCode is the gRPC status code (a google.golang.org/grpc/codes.Code)
+	// which should be returned alongside the error code, e.g. uint32(codes.NotFound).
+	// Zero means the default status code for user errors.
+	GRPCCode uint32
+
+	// DocURL links to the documentation of the error code.
+	DocURL string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[Code]CodeInfo{}
+)
+
+// RegisterCode registers user error codes with their metadata.
+// Registering the same code twice panics, since codes must be unique in an application.
+//
+// Codes are typically registered during initialization:
+//
+//	func init() {
+//	   fault.RegisterCode(fault.CodeInfo{
+//	      Code:        "MISSING_FIRST_NAME",
+//	      Description: "The first name has not been provided.",
+//	   })
+//	}
+func RegisterCode(infos ...CodeInfo) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, info := range infos {
+		if _, ok := registry[info.Code]; ok {
+			panic(fmt.Sprintf("fault: error code %q has already been registered", info.Code))
+		}
+		registry[info.Code] = info
+	}
+}
+
+// LookupCode returns the metadata of a registered error code.
+func LookupCode(code Code) (CodeInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	info, ok := registry[code]
+	return info, ok
+}
+
+// RegisteredCodes returns the metadata of all registered error codes sorted by code.
+func RegisteredCodes() []CodeInfo {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	infos := make([]CodeInfo, 0, len(registry))
+	for _, info := range registry {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Code < infos[j].Code
+	})
+	return infos
+}
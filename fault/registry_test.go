@@ -0,0 +1,40 @@
+package fault
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_RegisterCode(t *testing.T) {
+	RegisterCode(
+		CodeInfo{Code: "TEST_REGISTRY_B", Description: "b"},
+		CodeInfo{Code: "TEST_REGISTRY_A", Description: "a", Status: 404},
+	)
+
+	info, ok := LookupCode("TEST_REGISTRY_A")
+	if !ok || info.Description != "a" || info.Status != 404 {
+		t.Errorf("LookupCode() returned an unexpected result: %v", info)
+	}
+
+	var codes []Code
+	for _, info := range RegisteredCodes() {
+		codes = append(codes, info.Code)
+	}
+	actual := fmt.Sprint(codes)
+	expected := "[TEST_REGISTRY_A TEST_REGISTRY_B]"
+	if !strings.Contains(actual, "TEST_REGISTRY_A TEST_REGISTRY_B") {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_RegisterCode_WithDuplicateCode(t *testing.T) {
+	RegisterCode(CodeInfo{Code: "TEST_REGISTRY_DUPLICATE"})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterCode() was expected to panic for a duplicate code.")
+		}
+	}()
+	RegisterCode(CodeInfo{Code: "TEST_REGISTRY_DUPLICATE"})
+}
@@ -0,0 +1,98 @@
+package fault
+
+// Result holds the value of an operation together with its error.
+//
+// A Result can accumulate user errors while still carrying the value (see Check),
+// so that all validation errors of a pipeline can be reported at once.
+// Any other error stops the pipeline: Map and Then don't invoke their functions
+// for a Result which holds an error.
+//
+//	Example:
+//	   r := fault.Then(fault.Ok(req), parse)
+//	   r = r.Check(validateName).Check(validateEmail)
+//	   user, err := fault.Then(r, save).Unwrap()
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok creates a Result which holds the value.
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err creates a Result which holds the error.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// ResultOf creates a Result from the return values of a function.
+//
+//	Example:
+//	   r := fault.ResultOf(strconv.Atoi(s))
+func ResultOf[T any](value T, err error) Result[T] {
+	return Result[T]{value: value, err: err}
+}
+
+// Value returns the value of the Result.
+func (r Result[T]) Value() T {
+	return r.value
+}
+
+// Err returns the error of the Result.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// IsOk reports whether the Result doesn't hold an error.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Unwrap returns the value and the error of the Result.
+func (r Result[T]) Unwrap() (T, error) {
+	return r.value, r.err
+}
+
+// Check validates the value of the Result. The user errors which are returned by
+// the validation function are accumulated with the user errors of the Result.
+// The validation function is not invoked if the Result holds any other error.
+func (r Result[T]) Check(validate func(T) *UserError) Result[T] {
+	var acc *UserError
+	if r.err != nil {
+		// nolint: errorlint // Only an accumulated UserError can be extended:
+		userErr, ok := r.err.(*UserError)
+		if !ok {
+			return r
+		}
+		acc = userErr
+	}
+	userErr := validate(r.value)
+	if userErr == nil {
+		return r
+	}
+	if acc == nil {
+		r.err = userErr
+		return r
+	}
+	r.err = mergeUserErrors(acc, userErr)
+	return r
+}
+
+// Map transforms the value of the Result.
+// The function is not invoked if the Result holds an error.
+func Map[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+	return Result[U]{value: f(r.value)}
+}
+
+// Then passes the value of the Result to the next operation of a pipeline.
+// The operation is not invoked if the Result holds an error.
+func Then[T, U any](r Result[T], f func(T) (U, error)) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+	return ResultOf(f(r.value))
+}
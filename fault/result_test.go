@@ -0,0 +1,64 @@
+package fault
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func Test_Result_Then(t *testing.T) {
+	r := Then(Ok("42"), strconv.Atoi)
+	r = Map(r, func(i int) int { return i * 2 })
+
+	value, err := r.Unwrap()
+
+	if err != nil || value != 84 {
+		t.Errorf(expectedFormat, "84", err)
+	}
+}
+
+func Test_Result_Then_WithError(t *testing.T) {
+	sysErr := System("a")
+	invoked := false
+
+	r := Then(Err[string](sysErr), func(s string) (int, error) {
+		invoked = true
+		return 0, nil
+	})
+
+	if invoked {
+		t.Error("Then was not expected to invoke the function for a Result with an error.")
+	}
+	if !errors.Is(r.Err(), sysErr) {
+		t.Errorf(expectedFormat, sysErr, r.Err())
+	}
+}
+
+func Test_Result_Check_AccumulatesUserErrors(t *testing.T) {
+	r := Ok("").
+		Check(func(s string) *UserError { return User("a", "aaa") }).
+		Check(func(s string) *UserError { return nil }).
+		Check(func(s string) *UserError { return User("b", "bbb") })
+
+	expected := "- aaa (a)\n- bbb (b)"
+	if r.IsOk() || r.Err().Error() != expected {
+		t.Errorf(expectedFormat, expected, r.Err())
+	}
+}
+
+func Test_Result_Check_WithSystemError(t *testing.T) {
+	sysErr := System("a")
+	invoked := false
+
+	r := Err[string](sysErr).Check(func(s string) *UserError {
+		invoked = true
+		return User("b", "bbb")
+	})
+
+	if invoked {
+		t.Error("Check was not expected to invoke the function for a Result with a system error.")
+	}
+	if r.Err() != sysErr {
+		t.Errorf(expectedFormat, sysErr, r.Err())
+	}
+}
@@ -0,0 +1,256 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// WithRetryAfter sets a hint after which duration the user may retry the failed operation
+// (e.g. after hitting a rate limit). It also classifies the UserError as retryable.
+func (e *UserError) WithRetryAfter(d time.Duration) *UserError {
+	e.retryAfter = d
+	return e
+}
+
+// RetryAfter returns the hint after which duration the operation may be retried.
+func (e *UserError) RetryAfter() (time.Duration, bool) {
+	if e == nil {
+		return 0, false
+	}
+	return e.retryAfter, e.retryAfter > 0
+}
+
+// Retryable reports whether the failed operation may succeed when being retried,
+// which is only the case if the UserError contains a retry hint.
+func (e *UserError) Retryable() bool {
+	if e == nil {
+		return false
+	}
+	return IsRetryable(e)
+}
+
+// WithRetryable explicitly classifies the SystemError as retryable or not retryable.
+func (e *SystemError) WithRetryable(retryable bool) *SystemError {
+	e.retryable = &retryable
+	return e
+}
+
+// WithRetryAfter sets a hint after which duration the failed operation may be retried
+// (e.g. when a dependency is temporarily unavailable). It also classifies the SystemError
+// as retryable.
+func (e *SystemError) WithRetryAfter(d time.Duration) *SystemError {
+	e.retryAfter = d
+	return e
+}
+
+// RetryAfter returns the hint after which duration the operation may be retried.
+// If the SystemError doesn't have a hint itself then the hint of the error which
+// it wraps will be returned.
+func (e *SystemError) RetryAfter() (time.Duration, bool) {
+	if e == nil {
+		return 0, false
+	}
+	if e.retryAfter > 0 {
+		return e.retryAfter, true
+	}
+	return RetryAfter(e.err)
+}
+
+// Retryable reports whether the failed operation may succeed when being retried.
+// See IsRetryable for more details.
+func (e *SystemError) Retryable() bool {
+	if e == nil {
+		return false
+	}
+	return IsRetryable(e)
+}
+
+// RetryAfter returns the first hint after which duration the
+// failed operation may be retried in the error's chain.
+// The causes of errors with multiple causes (e.g. an Aggregate) are searched depth-first.
+func RetryAfter(err error) (time.Duration, bool) {
+	for err != nil {
+		// nolint: errorlint // Walking the chain manually:
+		switch e := err.(type) {
+		case *SystemError:
+			if e.retryAfter > 0 {
+				return e.retryAfter, true
+			}
+		case *UserError:
+			if e.retryAfter > 0 {
+				return e.retryAfter, true
+			}
+		case interface{ Unwrap() []error }:
+			for _, cause := range e.Unwrap() {
+				if d, ok := RetryAfter(cause); ok {
+					return d, true
+				}
+			}
+			return 0, false
+		}
+		err = errors.Unwrap(err)
+	}
+	return 0, false
+}
+
+// IsRetryable reports whether the failed operation may succeed when being retried.
+//
+// The first explicit classification (WithRetryable or WithRetryAfter) in the error's chain wins,
+// searching the causes of errors with multiple causes (e.g. an Aggregate) depth-first.
+// Otherwise an error is considered retryable if it has been classified with the
+// Timeout, Unavailable or ResourceExhausted kind.
+func IsRetryable(err error) bool {
+	if retryable, ok := explicitRetryable(err); ok {
+		return retryable
+	}
+	if err == nil {
+		return false
+	}
+	switch KindOf(err) {
+	case Timeout, Unavailable, ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// ------
+// Retry
+// ------
+
+// RetryOption configures Retry.
+type RetryOption func(*retryOptions)
+
+type retryOptions struct {
+	attempts int
+	backoff  func(attempt int) time.Duration
+}
+
+// WithAttempts sets the maximum number of attempts (including the first one).
+// Defaults to 3.
+func WithAttempts(n int) RetryOption {
+	return func(o *retryOptions) {
+		o.attempts = n
+	}
+}
+
+// WithBackoff sets the function which returns the delay before the given
+// retry attempt (starting with 1). Defaults to ExponentialBackoff(100ms, 10s).
+func WithBackoff(backoff func(attempt int) time.Duration) RetryOption {
+	return func(o *retryOptions) {
+		o.backoff = backoff
+	}
+}
+
+// ExponentialBackoff returns a backoff which doubles the delay with every attempt,
+// starting with initial and capped at max. A random jitter of up to 50% of the delay
+// is subtracted, so that concurrent callers don't retry in lockstep.
+func ExponentialBackoff(initial, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := initial
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		if d <= 1 {
+			return d
+		}
+		// nolint: gosec // Jitter doesn't require a secure random number:
+		return d - time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+}
+
+// Retry calls fn until it succeeds, returns an error which is not retryable
+// (see IsRetryable) or the maximum number of attempts has been reached.
+//
+// The delay between attempts is the retry hint of the error (see RetryAfter)
+// or otherwise the delay of the backoff. Retry stops waiting when the context is done.
+//
+// If fn has been retried then the final error gets wrapped into a SystemError
+// with the number of attempts and the messages of all failed attempts as the
+// attempts and attempt_errors fields.
+//
+//	Example:
+//	   err := fault.Retry(ctx, func(ctx context.Context) error {
+//	      return client.Send(ctx, msg)
+//	   }, fault.WithAttempts(5))
+func Retry(ctx context.Context, fn func(ctx context.Context) error, opts ...RetryOption) error {
+	o := &retryOptions{
+		attempts: 3,
+		backoff:  ExponentialBackoff(100*time.Millisecond, 10*time.Second),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var history []string
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		history = append(history, err.Error())
+		if !IsRetryable(err) || attempt >= o.attempts {
+			return retryFailure(err, history, nil)
+		}
+
+		delay, ok := RetryAfter(err)
+		if !ok {
+			delay = o.backoff(attempt)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return retryFailure(err, history, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func retryFailure(err error, history []string, ctxErr error) error {
+	if len(history) == 1 && ctxErr == nil {
+		return err
+	}
+	sysErr := SystemWrapf(err, "failed after %d attempts", len(history)).
+		WithField("attempts", len(history)).
+		WithField("attempt_errors", history)
+	if ctxErr != nil {
+		kind, _ := ClassifyContext(ctxErr)
+		sysErr.WithKind(kind)
+	}
+	return sysErr
+}
+
+// explicitRetryable returns the first explicit classification in the error's chain.
+func explicitRetryable(err error) (bool, bool) {
+	for err != nil {
+		// nolint: errorlint // Walking the chain manually:
+		switch e := err.(type) {
+		case *SystemError:
+			if e.retryable != nil {
+				return *e.retryable, true
+			}
+			if e.retryAfter > 0 {
+				return true, true
+			}
+		case *UserError:
+			if e.retryAfter > 0 {
+				return true, true
+			}
+		case interface{ Unwrap() []error }:
+			for _, cause := range e.Unwrap() {
+				if retryable, ok := explicitRetryable(cause); ok {
+					return retryable, true
+				}
+			}
+			return false, false
+		}
+		err = errors.Unwrap(err)
+	}
+	return false, false
+}
@@ -0,0 +1,140 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_RetryAfter_WithLayersOfSystemErrors(t *testing.T) {
+	f1 := System("c").WithRetryAfter(5 * time.Second)
+	f2 := fmt.Errorf("f: %w", f1)
+	f3 := SystemWrap(f2, "i")
+
+	actual, ok := f3.RetryAfter()
+
+	if !ok || actual != 5*time.Second {
+		t.Errorf(expectedFormat, 5*time.Second, actual)
+	}
+	if !f3.Retryable() {
+		t.Error("An error with a retry hint was expected to be retryable.")
+	}
+}
+
+func Test_RetryAfter_WithUserError(t *testing.T) {
+	f := User("TOO_MANY_REQUESTS", "Please slow down.").WithRetryAfter(time.Minute)
+
+	actual, ok := RetryAfter(SystemWrap(f, "f"))
+
+	if !ok || actual != time.Minute {
+		t.Errorf(expectedFormat, time.Minute, actual)
+	}
+}
+
+func Test_IsRetryable(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{errors.New("foo bar"), false},
+		{System("c"), false},
+		{System("c").WithKind(Timeout), true},
+		{System("c").WithKind(NotFound), false},
+		{SystemWrap(System("c").WithKind(Unavailable), "f"), true},
+		{SystemWrap(System("c").WithKind(Unavailable), "f").WithRetryable(false), false},
+		{SystemWrap(System("c").WithRetryable(true), "f"), true},
+		{User("X", "x"), false},
+	}
+
+	for i, tc := range testCases {
+		actual := IsRetryable(tc.err)
+		if actual != tc.expected {
+			t.Errorf("test case %d: IsRetryable() was expected to return %t", i, tc.expected)
+		}
+	}
+}
+
+func noBackoff(int) time.Duration {
+	return 0
+}
+
+func Test_Retry_SucceedsAfterRetryableErrors(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return System("unavailable").WithKind(Unavailable)
+		}
+		return nil
+	}, WithBackoff(noBackoff))
+
+	if err != nil || calls != 3 {
+		t.Errorf(expectedFormat, "<nil> 3", fmt.Sprint(err, calls))
+	}
+}
+
+func Test_Retry_StopsAtNonRetryableError(t *testing.T) {
+	calls := 0
+	expected := User("INVALID", "Invalid input.")
+	err := Retry(context.Background(), func(ctx context.Context) error {
+		calls++
+		return expected
+	}, WithBackoff(noBackoff))
+
+	if err != expected || calls != 1 {
+		t.Errorf(expectedFormat, fmt.Sprint(expected, 1), fmt.Sprint(err, calls))
+	}
+}
+
+func Test_Retry_WrapsFinalFailureWithHistory(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), func(ctx context.Context) error {
+		calls++
+		return Systemf("attempt %d", calls).WithRetryAfter(time.Millisecond)
+	}, WithAttempts(2), WithBackoff(func(int) time.Duration {
+		t.Error("The backoff was expected to be skipped in favour of the retry hint.")
+		return 0
+	}))
+
+	var sysErr *SystemError
+	if !errors.As(err, &sysErr) {
+		t.Fatalf(expectedFormat, "*SystemError", err)
+	}
+	if expected := "failed after 2 attempts\n   attempt 2"; sysErr.Error() != expected {
+		t.Errorf(expectedFormat, expected, sysErr.Error())
+	}
+	actual := fmt.Sprint(sysErr.Fields()["attempts"], sysErr.Fields()["attempt_errors"])
+	if expected := "2 [attempt 1 attempt 2]"; actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Retry_StopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := Retry(ctx, func(ctx context.Context) error {
+		cancel()
+		return System("a").WithKind(Unavailable)
+	}, WithBackoff(func(int) time.Duration { return time.Hour }))
+
+	if !IsCanceled(err) {
+		t.Errorf(expectedFormat, Canceled, KindOf(err))
+	}
+}
+
+func Test_ExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+	tests := map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		5: time.Second,
+	}
+	for attempt, max := range tests {
+		actual := backoff(attempt)
+		if actual < max/2 || actual > max {
+			t.Errorf(expectedFormat, fmt.Sprintf("%s-%s", max/2, max), actual)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package fault
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the personally identifiable information which has been scrubbed.
+const Redacted = "[REDACTED]"
+
+// ScrubRule identifies personally identifiable information (PII) in the messages
+// and fields of faults, which must not leave the process.
+type ScrubRule struct {
+	// Name identifies the rule in the ScrubEvents of the audit hook.
+	Name string
+
+	// Pattern matches the sensitive parts of messages and field values.
+	Pattern *regexp.Regexp
+
+	// Fields are the keys of fields whose values are redacted entirely.
+	// Keys are matched case-insensitively.
+	Fields []string
+
+	// Validate reports whether a match of the Pattern is sensitive.
+	// Matches which aren't get kept. If nil then all matches are redacted.
+	Validate func(match string) bool
+}
+
+// Rules for common kinds of personally identifiable information and secrets.
+var (
+	EmailRule = ScrubRule{
+		Name:    "email",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+		Fields:  []string{"email"},
+	}
+	CardNumberRule = ScrubRule{
+		Name:     "card_number",
+		Pattern:  regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Fields:   []string{"card_number"},
+		Validate: luhn,
+	}
+	BearerTokenRule = ScrubRule{
+		Name:    "bearer_token",
+		Pattern: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`),
+		Fields:  []string{"authorization"},
+	}
+)
+
+// ScrubEvent describes information which has been scrubbed.
+type ScrubEvent struct {
+	// Rule is the name of the rule which applied.
+	Rule string
+
+	// Location is either "message" or "field:" followed by the key of the field.
+	Location string
+}
+
+// Scrubber redacts personally identifiable information from the messages and fields
+// of faults before they get rendered for an external system (e.g. encoded as JSON,
+// sent to an error reporter or written into a HTTP response).
+// A zero Scrubber is valid and has no rules.
+type Scrubber struct {
+	mu    sync.RWMutex
+	rules []ScrubRule
+	audit func(ScrubEvent)
+}
+
+// DefaultScrubber is the Scrubber which is applied by all renderers of this module.
+var DefaultScrubber = &Scrubber{}
+
+// Register appends rules to the Scrubber.
+func (s *Scrubber) Register(rules ...ScrubRule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = append(s.rules, rules...)
+}
+
+// SetAudit sets a hook which gets called whenever information has been scrubbed.
+// The hook receives where the information was found, but not the information itself.
+func (s *Scrubber) SetAudit(audit func(ScrubEvent)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.audit = audit
+}
+
+// Message returns the message with all matches of the rules redacted.
+func (s *Scrubber) Message(msg string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scrub("message", msg)
+}
+
+// Fields returns a copy of the fields with all matches of the rules redacted.
+// Values which contain sensitive information are converted into strings.
+func (s *Scrubber) Fields(fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	scrubbed := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		scrubbed[k] = s.scrubField(k, v)
+	}
+	return scrubbed
+}
+
+func (s *Scrubber) scrubField(key string, value interface{}) interface{} {
+	location := "field:" + key
+	for _, rule := range s.rules {
+		for _, field := range rule.Fields {
+			if strings.EqualFold(field, key) {
+				s.report(rule, location)
+				return Redacted
+			}
+		}
+	}
+	if len(s.rules) == 0 {
+		return value
+	}
+	str, isString := value.(string)
+	if !isString {
+		str = fmt.Sprint(value)
+	}
+	if scrubbed := s.scrub(location, str); scrubbed != str {
+		return scrubbed
+	}
+	return value
+}
+
+func (s *Scrubber) scrub(location string, str string) string {
+	for _, rule := range s.rules {
+		if rule.Pattern == nil || !rule.Pattern.MatchString(str) {
+			continue
+		}
+		scrubbed := rule.Pattern.ReplaceAllStringFunc(str, func(match string) string {
+			if rule.Validate != nil && !rule.Validate(match) {
+				return match
+			}
+			return Redacted
+		})
+		if scrubbed != str {
+			str = scrubbed
+			s.report(rule, location)
+		}
+	}
+	return str
+}
+
+// luhn reports whether the digits of the number (ignoring spaces and dashes) have a
+// valid Luhn checksum, which tells card numbers apart from other long numbers
+// (e.g. timestamps or IDs).
+func luhn(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+		digit := int(c - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func (s *Scrubber) report(rule ScrubRule, location string) {
+	if s.audit != nil {
+		s.audit(ScrubEvent{Rule: rule.Name, Location: location})
+	}
+}
+
+// RegisterScrubRule appends rules to the DefaultScrubber.
+//
+//	Example:
+//	   fault.RegisterScrubRule(fault.EmailRule, fault.CardNumberRule, fault.BearerTokenRule)
+//	   fault.SetScrubAudit(func(e fault.ScrubEvent) {
+//	      scrubCounter.WithLabelValues(e.Rule).Inc()
+//	   })
+func RegisterScrubRule(rules ...ScrubRule) {
+	DefaultScrubber.Register(rules...)
+}
+
+// SetScrubAudit sets the audit hook of the DefaultScrubber.
+func SetScrubAudit(audit func(ScrubEvent)) {
+	DefaultScrubber.SetAudit(audit)
+}
+
+// Scrub redacts personally identifiable information from the message using the DefaultScrubber.
+func Scrub(msg string) string {
+	return DefaultScrubber.Message(msg)
+}
+
+// ScrubFields redacts personally identifiable information from the fields using the DefaultScrubber.
+func ScrubFields(fields map[string]interface{}) map[string]interface{} {
+	return DefaultScrubber.Fields(fields)
+}
+
+// Scrub returns a copy of the UserError whose messages have been scrubbed by the DefaultScrubber.
+func (e *UserError) Scrub() *UserError {
+	if e == nil {
+		return nil
+	}
+	scrubbed := &UserError{
+		codes:      append([]string(nil), e.codes...),
+		messages:   make([]string, len(e.messages)),
+		retryAfter: e.retryAfter,
+		fields:     e.fields,
+		params:     e.params,
+	}
+	for i, msg := range e.messages {
+		scrubbed.messages[i] = Scrub(msg)
+	}
+	return scrubbed
+}
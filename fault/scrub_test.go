@@ -0,0 +1,147 @@
+package fault
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func useScrubber(t *testing.T, s *Scrubber) {
+	previous := DefaultScrubber
+	DefaultScrubber = s
+	t.Cleanup(func() { DefaultScrubber = previous })
+}
+
+func Test_Scrubber_Message(t *testing.T) {
+	s := &Scrubber{}
+	s.Register(EmailRule, CardNumberRule, BearerTokenRule)
+
+	actual := s.Message("failed to charge jane@example.com with card 4111 1111 1111 1111 (Bearer abc.def-123)")
+
+	expected := "failed to charge [REDACTED] with card [REDACTED] ([REDACTED])"
+	if actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Scrubber_Message_WithTimestamp(t *testing.T) {
+	s := &Scrubber{}
+	s.Register(CardNumberRule)
+
+	msg := "job 4012-8888-8888-1881 expired at 1718035200000"
+	actual := s.Message(msg)
+
+	expected := "job [REDACTED] expired at 1718035200000"
+	if actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Scrubber_Message_WithValidate(t *testing.T) {
+	s := &Scrubber{}
+	var events int
+	s.SetAudit(func(e ScrubEvent) { events++ })
+	s.Register(ScrubRule{
+		Name:     "order_id",
+		Pattern:  regexp.MustCompile(`ord_\w+`),
+		Validate: func(match string) bool { return strings.HasSuffix(match, "_live") },
+	})
+
+	msg := "order ord_123_test"
+	if actual := s.Message(msg); actual != msg {
+		t.Errorf(expectedFormat, msg, actual)
+	}
+	if events != 0 {
+		t.Errorf(expectedFormat, "0", fmt.Sprint(events))
+	}
+	expected := "order [REDACTED]"
+	if actual := s.Message("order ord_123_live"); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Scrubber_Message_WithoutRules(t *testing.T) {
+	msg := "failed to notify jane@example.com"
+	if actual := (&Scrubber{}).Message(msg); actual != msg {
+		t.Errorf(expectedFormat, msg, actual)
+	}
+}
+
+func Test_Scrubber_Fields(t *testing.T) {
+	s := &Scrubber{}
+	s.Register(EmailRule, CardNumberRule)
+
+	actual := s.Fields(map[string]interface{}{
+		"Email":   42,
+		"card":    int64(4111111111111111),
+		"contact": "jane@example.com or 555-1234",
+		"user_id": 42,
+	})
+
+	expected := map[string]interface{}{
+		"Email":   Redacted,
+		"card":    Redacted,
+		"contact": "[REDACTED] or 555-1234",
+		"user_id": 42,
+	}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf(expectedFormat, fmt.Sprint(expected), fmt.Sprint(actual))
+	}
+}
+
+func Test_Scrubber_Audit(t *testing.T) {
+	s := &Scrubber{}
+	s.Register(EmailRule)
+	var events []string
+	s.SetAudit(func(e ScrubEvent) {
+		events = append(events, e.Rule+"@"+e.Location)
+	})
+
+	s.Message("jane@example.com")
+	s.Fields(map[string]interface{}{"email": "x", "note": "jane@example.com", "id": 1})
+
+	actual := strings.Join(events, ",")
+	for _, expected := range []string{"email@message", "email@field:email", "email@field:note"} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+	if len(events) != 3 {
+		t.Errorf(expectedFormat, "3 events", actual)
+	}
+}
+
+func Test_UserError_Scrub(t *testing.T) {
+	useScrubber(t, &Scrubber{})
+	RegisterScrubRule(EmailRule)
+	err := UserField("email", "EMAIL_TAKEN", "jane@example.com is already registered.")
+
+	scrubbed := err.Scrub()
+
+	expected := "[REDACTED] is already registered. (EMAIL_TAKEN)"
+	if actual := scrubbed.Error(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := scrubbed.Field("EMAIL_TAKEN"); actual != "email" {
+		t.Errorf(expectedFormat, "email", actual)
+	}
+	if actual := err.Error(); actual == expected {
+		t.Error("The original UserError was expected to remain unchanged.")
+	}
+}
+
+func Test_Encode_Scrubs(t *testing.T) {
+	useScrubber(t, &Scrubber{})
+	RegisterScrubRule(EmailRule)
+	err := Systemf("failed to notify %s", "jane@example.com").WithField("recipient", "jane@example.com")
+
+	actual := string(Encode(err))
+
+	if strings.Contains(actual, "jane@example.com") {
+		t.Errorf(expectedFormat, "no email address", actual)
+	}
+	if !strings.Contains(actual, `"failed to notify [REDACTED]"`) {
+		t.Errorf(expectedFormat, "failed to notify [REDACTED]", actual)
+	}
+}
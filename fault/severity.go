@@ -0,0 +1,97 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Severity indicates how urgently a fault requires attention when it gets logged.
+// The zero value means that no severity has been assigned.
+type Severity int
+
+const (
+	// SeverityInfo is the severity of a UserError.
+	SeverityInfo Severity = iota + 1
+
+	// SeverityWarning indicates a fault which is worth looking at, but doesn't require action.
+	SeverityWarning
+
+	// SeverityError is the default severity of a SystemError.
+	SeverityError
+
+	// SeverityCritical indicates a bug (see Invariant) which must be fixed.
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityError:    "error",
+	SeverityCritical: "critical",
+}
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *Severity) UnmarshalText(text []byte) error {
+	for severity, name := range severityNames {
+		if name == string(text) {
+			*s = severity
+			return nil
+		}
+	}
+	if len(text) == 0 {
+		*s = 0
+		return nil
+	}
+	return fmt.Errorf("fault: unknown severity %q", text)
+}
+
+// WithSeverity sets the severity of the SystemError.
+func (e *SystemError) WithSeverity(severity Severity) *SystemError {
+	e.severity = severity
+	return e
+}
+
+// Severity returns the severity of the SystemError.
+// See SeverityOf for more details.
+func (e *SystemError) Severity() Severity {
+	if e == nil {
+		return 0
+	}
+	return SeverityOf(e)
+}
+
+// Severity returns SeverityInfo, which is the severity of every UserError.
+func (e *UserError) Severity() Severity {
+	return SeverityInfo
+}
+
+// SeverityOf returns the first severity which has been set in the error's chain.
+// A UserError has the SeverityInfo and any other error the SeverityError severity.
+// It returns zero if the error is nil.
+func SeverityOf(err error) Severity {
+	if err == nil {
+		return 0
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		// nolint: errorlint // Walking the chain manually:
+		switch e := e.(type) {
+		case *SystemError:
+			if e.severity != 0 {
+				return e.severity
+			}
+		case *UserError:
+			return SeverityInfo
+		}
+	}
+	return SeverityError
+}
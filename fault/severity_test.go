@@ -0,0 +1,52 @@
+package fault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_SeverityOf(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected Severity
+	}{
+		{nil, 0},
+		{errors.New("a"), SeverityError},
+		{System("a"), SeverityError},
+		{User("A", "a"), SeverityInfo},
+		{SystemWrap(System("a").WithSeverity(SeverityWarning), "b"), SeverityWarning},
+		{SystemWrap(System("a").WithSeverity(SeverityWarning), "b").WithSeverity(SeverityCritical), SeverityCritical},
+	}
+	for _, test := range tests {
+		if actual := SeverityOf(test.err); actual != test.expected {
+			t.Errorf(expectedFormat, test.expected, actual)
+		}
+	}
+}
+
+func Test_Severity_Text(t *testing.T) {
+	data, err := json.Marshal(SeverityCritical)
+	if err != nil || string(data) != `"critical"` {
+		t.Errorf(expectedFormat, `"critical"`, fmt.Sprint(string(data), err))
+	}
+
+	var actual Severity
+	if err := json.Unmarshal([]byte(`"warning"`), &actual); err != nil || actual != SeverityWarning {
+		t.Errorf(expectedFormat, SeverityWarning, fmt.Sprint(actual, err))
+	}
+	if err := json.Unmarshal([]byte(`"fatal"`), &actual); err == nil {
+		t.Error("Unmarshalling an unknown severity was expected to fail.")
+	}
+}
+
+func Test_Severity_RoundTrip(t *testing.T) {
+	err := System("a").WithSeverity(SeverityWarning)
+
+	decoded := Decode(Encode(err))
+
+	if actual := SeverityOf(decoded); actual != SeverityWarning {
+		t.Errorf(expectedFormat, SeverityWarning, actual)
+	}
+}
@@ -0,0 +1,111 @@
+package fault
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Stats counts errors by user error code, kind and fingerprint, so that operators
+// can inspect the error distribution of a live instance without querying logs.
+//
+// Stats implements the expvar.Var interface. Errors are recorded by passing them to
+// Record, e.g. the errors of all HTTP responses via the Stats of the httpfault.Responder:
+//
+//	Example:
+//	   stats := fault.NewStats()
+//	   httpfault.DefaultResponder.Stats = stats
+//	   expvar.Publish("faults", stats)
+//
+// Record is a Listener as well, but the Bus only delivers SystemErrors (see Subscribe),
+// which means that no user error codes would be counted.
+type Stats struct {
+	mu           sync.Mutex
+	since        time.Time
+	total        uint64
+	codes        map[Code]uint64
+	kinds        map[Kind]uint64
+	fingerprints map[string]uint64
+}
+
+// StatsSnapshot is the state of a Stats at a point in time.
+type StatsSnapshot struct {
+	// Since is the time at which the Stats has been created.
+	Since time.Time `json:"since"`
+
+	// Total is the number of recorded errors.
+	Total uint64 `json:"total"`
+
+	// Codes are the numbers of recorded user errors by code.
+	// A UserError with multiple codes is counted once per code.
+	Codes map[Code]uint64 `json:"codes"`
+
+	// Kinds are the numbers of recorded errors by kind (see KindOf).
+	Kinds map[Kind]uint64 `json:"kinds"`
+
+	// Fingerprints are the numbers of recorded errors by fingerprint (see Fingerprint).
+	Fingerprints map[string]uint64 `json:"fingerprints"`
+}
+
+// NewStats creates an empty Stats.
+func NewStats() *Stats {
+	return &Stats{
+		since:        time.Now(),
+		codes:        map[Code]uint64{},
+		kinds:        map[Kind]uint64{},
+		fingerprints: map[string]uint64{},
+	}
+}
+
+// Record counts the error. Nothing will be recorded if the error is nil.
+func (s *Stats) Record(err error) {
+	if err == nil {
+		return
+	}
+	var codes []Code
+	var userErr *UserError
+	if errors.As(err, &userErr) {
+		codes = userErr.Codes()
+	}
+	kind := KindOf(err)
+	fingerprint := Fingerprint(err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.total++
+	for _, code := range codes {
+		s.codes[code]++
+	}
+	s.kinds[kind]++
+	s.fingerprints[fingerprint]++
+}
+
+// Snapshot returns a copy of the current counts.
+func (s *Stats) Snapshot() StatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := StatsSnapshot{
+		Since:        s.since,
+		Total:        s.total,
+		Codes:        make(map[Code]uint64, len(s.codes)),
+		Kinds:        make(map[Kind]uint64, len(s.kinds)),
+		Fingerprints: make(map[string]uint64, len(s.fingerprints)),
+	}
+	for k, v := range s.codes {
+		snapshot.Codes[k] = v
+	}
+	for k, v := range s.kinds {
+		snapshot.Kinds[k] = v
+	}
+	for k, v := range s.fingerprints {
+		snapshot.Fingerprints[k] = v
+	}
+	return snapshot
+}
+
+// String returns the snapshot of the Stats as JSON, which implements the expvar.Var interface.
+func (s *Stats) String() string {
+	data, _ := json.Marshal(s.Snapshot())
+	return string(data)
+}
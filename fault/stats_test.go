@@ -0,0 +1,41 @@
+package fault
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+)
+
+var _ expvar.Var = (*Stats)(nil)
+
+func Test_Stats(t *testing.T) {
+	stats := NewStats()
+	userErr := User("MISSING_NAME", "Please provide a name.")
+	userErr.Add("INVALID_EMAIL", "Please provide a valid email address.")
+	stats.Record(userErr)
+	stats.Record(User("MISSING_NAME", "Please provide a name."))
+	stats.Record(System("failed to connect").WithKind(Unavailable))
+	stats.Record(nil)
+
+	snapshot := stats.Snapshot()
+	if snapshot.Total != 3 {
+		t.Errorf(expectedFormat, "3", fmt.Sprint(snapshot.Total))
+	}
+	expected := "map[INVALID_EMAIL:1 MISSING_NAME:2]"
+	if actual := fmt.Sprint(snapshot.Codes); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	expected = "map[invalid_argument:2 unavailable:1]"
+	if actual := fmt.Sprint(snapshot.Kinds); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if len(snapshot.Fingerprints) != 3 {
+		t.Errorf(expectedFormat, "3 fingerprints", fmt.Sprint(snapshot.Fingerprints))
+	}
+
+	var decoded StatsSnapshot
+	if err := json.Unmarshal([]byte(stats.String()), &decoded); err != nil || decoded.Total != 3 {
+		t.Errorf(expectedFormat, "3", stats.String())
+	}
+}
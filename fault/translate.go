@@ -0,0 +1,111 @@
+package fault
+
+import (
+	"errors"
+	"sync"
+)
+
+// Rule translates an error into a fault.
+// It returns nil if the rule doesn't apply to the error.
+type Rule func(err error) error
+
+// Translator translates third-party errors into domain faults by applying
+// registered rules, so that the mapping is centralized rather than repeated
+// at every layer boundary. A zero Translator is valid and has no rules.
+type Translator struct {
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+// DefaultTranslator is the Translator used by Register and Translate.
+var DefaultTranslator = &Translator{}
+
+// Register appends rules to the Translator.
+// Rules are applied in the order in which they have been registered.
+func (t *Translator) Register(rules ...Rule) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules = append(t.rules, rules...)
+}
+
+// Translate returns the result of the first rule which applies to the error.
+// The error is returned unchanged if no rule applies.
+func (t *Translator) Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, rule := range t.rules {
+		if translated := rule(err); translated != nil {
+			return translated
+		}
+	}
+	return err
+}
+
+// Register appends rules to the DefaultTranslator.
+//
+//	Example:
+//	   fault.Register(
+//	      fault.WhenIs(sql.ErrNoRows, fault.ToKind(fault.NotFound)),
+//	      fault.WhenIs(context.DeadlineExceeded, fault.ToKind(fault.Timeout)),
+//	      fault.WhenIs(ErrDuplicateEmail, fault.ToUser("EMAIL_TAKEN", "The email address is already in use.")),
+//	   )
+func Register(rules ...Rule) {
+	DefaultTranslator.Register(rules...)
+}
+
+// Translate translates the error using the DefaultTranslator.
+func Translate(err error) error {
+	return DefaultTranslator.Translate(err)
+}
+
+// WhenIs returns a Rule which applies the translation if errors.Is(err, target) reports true.
+func WhenIs(target error, translate func(err error) error) Rule {
+	return func(err error) error {
+		if errors.Is(err, target) {
+			return translate(err)
+		}
+		return nil
+	}
+}
+
+// WhenAs returns a Rule which applies the translation to the first error
+// in the chain which is of type T.
+//
+//	Example:
+//	   fault.WhenAs(func(err *pgconn.PgError) error {
+//	      if err.Code == "23505" {
+//	         return fault.User("ALREADY_EXISTS", "The resource already exists.")
+//	      }
+//	      return nil
+//	   })
+func WhenAs[T error](translate func(err T) error) Rule {
+	return func(err error) error {
+		var target T
+		if errors.As(err, &target) {
+			return translate(target)
+		}
+		return nil
+	}
+}
+
+// ToKind returns a translation which wraps the error into a SystemError of the given kind.
+func ToKind(kind Kind) func(err error) error {
+	return func(err error) error {
+		return created(&SystemError{
+			err:   err,
+			msgs:  []string{err.Error()},
+			stack: capturer().Capture(),
+			kind:  kind,
+		})
+	}
+}
+
+// ToUser returns a translation which replaces the error with a UserError.
+func ToUser(code Code, msg string) func(err error) error {
+	return func(err error) error {
+		return User(code, msg)
+	}
+}
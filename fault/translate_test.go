@@ -0,0 +1,69 @@
+package fault
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+)
+
+var errDuplicate = errors.New("duplicate key")
+
+func newTranslator() *Translator {
+	tr := &Translator{}
+	tr.Register(
+		WhenIs(fs.ErrNotExist, ToKind(NotFound)),
+		WhenIs(errDuplicate, ToUser("ALREADY_EXISTS", "The resource already exists.")),
+		WhenAs(func(err *fs.PathError) error {
+			if err.Op == "chmod" {
+				return ToKind(PermissionDenied)(err)
+			}
+			return nil
+		}),
+	)
+	return tr
+}
+
+func Test_Translate_WithKindRule(t *testing.T) {
+	err := &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrNotExist}
+
+	actual := newTranslator().Translate(err)
+
+	if KindOf(actual) != NotFound {
+		t.Errorf(expectedFormat, NotFound, KindOf(actual))
+	}
+	if !errors.Is(actual, fs.ErrNotExist) {
+		t.Error("The translated error was expected to wrap the original error.")
+	}
+	if actual.Error() != err.Error() {
+		t.Errorf(expectedFormat, err.Error(), actual.Error())
+	}
+}
+
+func Test_Translate_WithUserRule(t *testing.T) {
+	actual := newTranslator().Translate(errDuplicate)
+
+	if !IsUser(actual) || actual.Error() != "The resource already exists. (ALREADY_EXISTS)" {
+		t.Errorf(expectedFormat, "The resource already exists. (ALREADY_EXISTS)", actual)
+	}
+}
+
+func Test_Translate_WithAsRule(t *testing.T) {
+	err := &fs.PathError{Op: "chmod", Path: "a.txt", Err: fs.ErrPermission}
+
+	actual := newTranslator().Translate(err)
+
+	if KindOf(actual) != PermissionDenied {
+		t.Errorf(expectedFormat, PermissionDenied, KindOf(actual))
+	}
+}
+
+func Test_Translate_WithoutMatchingRule(t *testing.T) {
+	err := errors.New("a")
+
+	if actual := newTranslator().Translate(err); actual != err {
+		t.Errorf(expectedFormat, err, actual)
+	}
+	if actual := newTranslator().Translate(nil); actual != nil {
+		t.Errorf(expectedFormat, "nil", actual)
+	}
+}
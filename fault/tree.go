@@ -0,0 +1,137 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// treeNode is an error of a cause tree.
+type treeNode struct {
+	label      string
+	suppressed []string
+	children   []treeNode
+}
+
+// buildTree converts the error's chain into a tree, in which errors
+// with multiple causes (e.g. an Aggregate) have a branch per cause.
+func buildTree(err error) treeNode {
+	// nolint: errorlint // Walking the chain manually:
+	switch e := err.(type) {
+	case *Aggregate:
+		node := treeNode{label: strings.TrimSuffix(strings.SplitN(e.Error(), "\n", 2)[0], ":")}
+		for _, entry := range e.entries {
+			child := buildTree(entry.err)
+			child.label = fmt.Sprintf("item %s: %s", entry.key(), child.label)
+			node.children = append(node.children, child)
+		}
+		return withSuppressed(err, node)
+	case *UserError:
+		if len(e.codes) == 1 {
+			return treeNode{label: e.Error()}
+		}
+		node := treeNode{label: fmt.Sprintf("%d user errors", len(e.codes))}
+		for i, code := range e.codes {
+			node.children = append(node.children, treeNode{label: fmt.Sprintf("%s (%s)", e.messages[i], code)})
+		}
+		return node
+	case *SystemError:
+		causes := unwrapAll(e.err)
+		label := e.Error()
+		if msgs := e.Messages(); len(msgs) > 0 {
+			label = msgs[0]
+		}
+		return withSuppressed(err, newTreeNode(label, causes))
+	}
+	return withSuppressed(err, newTreeNode(err.Error(), unwrapAll(err)))
+}
+
+// newTreeNode returns the node of an error with the given causes. The messages of
+// the causes are trimmed from the label, since fmt.Errorf appends them to the message.
+// A node without a label of its own collapses into its only cause.
+func newTreeNode(label string, causes []error) treeNode {
+	node := treeNode{}
+	for _, cause := range causes {
+		node.children = append(node.children, buildTree(cause))
+	}
+	switch len(causes) {
+	case 0:
+	case 1:
+		label = strings.TrimSuffix(label, causes[0].Error())
+		label = strings.TrimRight(label, ": \n")
+		if label == "" {
+			return node.children[0]
+		}
+	default:
+		msgs := make([]string, len(causes))
+		for i, cause := range causes {
+			msgs[i] = cause.Error()
+		}
+		if label == strings.Join(msgs, "\n") {
+			label = fmt.Sprintf("%d errors", len(causes))
+		}
+	}
+	node.label = strings.SplitN(label, "\n", 2)[0]
+	return node
+}
+
+func withSuppressed(err error, node treeNode) treeNode {
+	// nolint: errorlint // Only the error itself is asked for its suppressed errors:
+	if s, ok := err.(interface{ Suppressed() []error }); ok {
+		for _, suppressed := range s.Suppressed() {
+			if suppressed != nil {
+				node.suppressed = append(node.suppressed, strings.ReplaceAll(suppressed.Error(), "\n", " "))
+			}
+		}
+	}
+	return node
+}
+
+// unwrapAll returns the causes of the error, supporting
+// both Unwrap() error and Unwrap() []error.
+func unwrapAll(err error) []error {
+	// nolint: errorlint // Unwrapping a single level:
+	if multi, ok := err.(interface{ Unwrap() []error }); ok {
+		return multi.Unwrap()
+	}
+	if cause := errors.Unwrap(err); cause != nil {
+		return []error{cause}
+	}
+	return nil
+}
+
+// RenderTree draws the full cause tree of the error with box-drawing characters.
+//
+// Errors with multiple causes, such as an Aggregate or the errors of errors.Join,
+// have a branch per cause. Errors which implement Suppressed() []error (e.g. a failure
+// to clean up after the operation had already failed) are annotated with the
+// suppressed errors. It returns an empty string if the error is nil.
+//
+//	Example:
+//	   failed to import users
+//	   └── 2 items failed
+//	       ├── item 0: failed to insert user
+//	       │   └── duplicate key value
+//	       └── item 3: Please provide a name. (MISSING_NAME)
+func RenderTree(err error) string {
+	if err == nil {
+		return ""
+	}
+	sb := strings.Builder{}
+	renderTreeNode(&sb, buildTree(err), "", "")
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func renderTreeNode(sb *strings.Builder, node treeNode, prefix string, childPrefix string) {
+	sb.WriteString(prefix + node.label + "\n")
+	for _, s := range node.suppressed {
+		sb.WriteString(childPrefix + "┆ suppressed: " + s + "\n")
+	}
+	for i, child := range node.children {
+		if i == len(node.children)-1 {
+			renderTreeNode(sb, child, childPrefix+"└── ", childPrefix+"    ")
+		} else {
+			renderTreeNode(sb, child, childPrefix+"├── ", childPrefix+"│   ")
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type suppressingError struct {
+	error
+	suppressed []error
+}
+
+func (e suppressingError) Suppressed() []error {
+	return e.suppressed
+}
+
+type joinedError []error
+
+func (e joinedError) Error() string {
+	return fmt.Sprintf("%s\n%s", e[0], e[1])
+}
+
+func (e joinedError) Unwrap() []error {
+	return e
+}
+
+func Test_RenderTree_Nil(t *testing.T) {
+	if actual := RenderTree(nil); actual != "" {
+		t.Errorf(expectedFormat, "", actual)
+	}
+}
+
+func Test_RenderTree_LinearChain(t *testing.T) {
+	err := SystemWrap(Errorf("failed to open config: %w", errors.New("permission denied")), "failed to start")
+
+	expected := "failed to start\n" +
+		"└── failed to open config\n" +
+		"    └── permission denied"
+	if actual := RenderTree(err); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_RenderTree_Aggregate(t *testing.T) {
+	agg := &Aggregate{}
+	agg.Add(0, SystemWrap(errors.New("duplicate key value"), "failed to insert user"))
+	agg.AddID("a1b2", User("MISSING_NAME", "Please provide a name."))
+	err := SystemWrap(agg, "failed to import users")
+
+	expected := "failed to import users\n" +
+		"└── 2 items failed\n" +
+		"    ├── item 0: failed to insert user\n" +
+		"    │   └── duplicate key value\n" +
+		"    └── item a1b2: Please provide a name. (MISSING_NAME)"
+	if actual := RenderTree(err); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_RenderTree_JoinedErrors(t *testing.T) {
+	err := joinedError{errors.New("a"), System("b")}
+
+	expected := "2 errors\n" +
+		"├── a\n" +
+		"└── b"
+	if actual := RenderTree(err); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_RenderTree_UserErrors(t *testing.T) {
+	err := User("MISSING_NAME", "Please provide a name.")
+	err.Add("MISSING_EMAIL", "Please provide an email address.")
+
+	expected := "2 user errors\n" +
+		"├── Please provide a name. (MISSING_NAME)\n" +
+		"└── Please provide an email address. (MISSING_EMAIL)"
+	if actual := RenderTree(err); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_RenderTree_Suppressed(t *testing.T) {
+	err := SystemWrap(suppressingError{
+		error:      errors.New("failed to write file"),
+		suppressed: []error{errors.New("failed to close file")},
+	}, "failed to save report")
+
+	expected := "failed to save report\n" +
+		"└── failed to write file\n" +
+		"    ┆ suppressed: failed to close file"
+	if actual := RenderTree(err); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
@@ -0,0 +1,68 @@
+package fault
+
+// UserField creates a new UserError fault which refers to an input field (e.g. of a form).
+func UserField(field string, code Code, msg string) *UserError {
+	e := User(code, msg)
+	e.fields = map[string]string{string(code): field}
+	return e
+}
+
+// AddField appends an additional user error which refers to an input field (e.g. of a form),
+// so that the message can be displayed next to the field.
+func (e *UserError) AddField(field string, code Code, msg string) {
+	e.Add(code, msg)
+	if e.fields == nil {
+		e.fields = map[string]string{}
+	}
+	e.fields[string(code)] = field
+}
+
+// Field returns the input field which the error with the given code refers to,
+// or an empty string if the error doesn't refer to a field.
+func (e *UserError) Field(code Code) string {
+	if e == nil {
+		return ""
+	}
+	return e.fields[string(code)]
+}
+
+// FieldErrors returns the messages of the errors which refer to the
+// given input field, in the order in which they were added.
+func (e *UserError) FieldErrors(field string) []string {
+	if e == nil {
+		return nil
+	}
+	var messages []string
+	for i, code := range e.codes {
+		if e.fields[code] == field {
+			messages = append(messages, e.messages[i])
+		}
+	}
+	return messages
+}
+
+// FieldMap returns the messages of all errors keyed by the input field which they refer to,
+// or by their code if they don't refer to a field. The messages of each key are in the
+// order in which they were added. This is the shape which server-rendered form templates
+// and many web frameworks expect for redisplaying a form.
+//
+//	Example:
+//	   fault.UserField("email", "INVALID_EMAIL", "Please provide a valid email address.")
+//
+//	becomes:
+//
+//	   map[email:[Please provide a valid email address.]]
+func (e *UserError) FieldMap() map[string][]string {
+	if e == nil {
+		return map[string][]string{}
+	}
+	m := make(map[string][]string, len(e.codes))
+	for i, code := range e.codes {
+		key := code
+		if field, ok := e.fields[code]; ok {
+			key = field
+		}
+		m[key] = append(m[key], e.messages[i])
+	}
+	return m
+}
@@ -0,0 +1,48 @@
+package fault
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_UserField(t *testing.T) {
+	err := UserField("email", "INVALID_EMAIL", "Please provide a valid email address.")
+	err.Add("TERMS_NOT_ACCEPTED", "Please accept the terms.")
+	err.AddField("email", "EMAIL_TAKEN", "The email address is already registered.")
+
+	if actual := err.Field("INVALID_EMAIL"); actual != "email" {
+		t.Errorf(expectedFormat, "email", actual)
+	}
+	if actual := err.Field("TERMS_NOT_ACCEPTED"); actual != "" {
+		t.Errorf(expectedFormat, "", actual)
+	}
+	expected := "[Please provide a valid email address. The email address is already registered.]"
+	if actual := fmt.Sprint(err.FieldErrors("email")); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_AddField_ToUserError(t *testing.T) {
+	err := User("TERMS_NOT_ACCEPTED", "Please accept the terms.")
+	err.AddField("name", "MISSING_NAME", "Please provide a name.")
+
+	expected := "[TERMS_NOT_ACCEPTED MISSING_NAME]"
+	if actual := fmt.Sprint(err.Codes()); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if actual := err.Field("MISSING_NAME"); actual != "name" {
+		t.Errorf(expectedFormat, "name", actual)
+	}
+}
+
+func Test_FieldMap(t *testing.T) {
+	err := UserField("email", "INVALID_EMAIL", "Please provide a valid email address.")
+	err.Add("TERMS_NOT_ACCEPTED", "Please accept the terms.")
+	err.AddField("email", "EMAIL_TAKEN", "The email address is already registered.")
+
+	expected := "map[TERMS_NOT_ACCEPTED:[Please accept the terms.] " +
+		"email:[Please provide a valid email address. The email address is already registered.]]"
+	if actual := fmt.Sprint(err.FieldMap()); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
@@ -0,0 +1,32 @@
+package fault
+
+import "fmt"
+
+// Warning represents a condition which is worth surfacing to the user,
+// but which doesn't fail the operation (e.g. a deprecated parameter has been
+// used or the result is incomplete due to a partial degradation).
+//
+// Warnings are typically reported to the Collector of a request (see Collector.AddWarning)
+// and attached to the successful response by the transport layer.
+type Warning struct {
+	// Code is a machine readable warning code (e.g. DEPRECATED_PARAMETER).
+	Code Code
+
+	// Message is a human readable description of the warning.
+	Message string
+}
+
+// Warn creates a new Warning.
+func Warn(code Code, msg string) Warning {
+	return Warning{Code: code, Message: msg}
+}
+
+// Warnf creates a new Warning.
+func Warnf(code Code, format string, a ...interface{}) Warning {
+	return Warn(code, fmt.Sprintf(format, a...))
+}
+
+// String returns the message followed by the code of the warning.
+func (w Warning) String() string {
+	return fmt.Sprintf("%s (%s)", w.Message, w.Code)
+}
@@ -0,0 +1,11 @@
+package fault
+
+import "testing"
+
+func Test_Warning_String(t *testing.T) {
+	w := Warnf("DEPRECATED_PARAMETER", "The parameter %s is deprecated.", "sort")
+
+	if expected := "The parameter sort is deprecated. (DEPRECATED_PARAMETER)"; w.String() != expected {
+		t.Errorf(expectedFormat, expected, w.String())
+	}
+}
@@ -0,0 +1,62 @@
+package fault
+
+import "encoding/xml"
+
+type xmlUserEntry struct {
+	Code    string `xml:"code,attr"`
+	Field   string `xml:"field,attr,omitempty"`
+	Message string `xml:",chardata"`
+}
+
+type xmlUserError struct {
+	Errors []xmlUserEntry `xml:"error"`
+}
+
+type xmlSystemError struct {
+	Kind     Kind     `xml:"kind,attr"`
+	Messages []string `xml:"message"`
+}
+
+// MarshalXML implements the xml.Marshaler interface, e.g. for SOAP fault details.
+// Each user error is an error element with its code (and field, if any) as attributes.
+// Messages are scrubbed by the DefaultScrubber. Nothing is written if the UserError is nil.
+//
+//	Example:
+//	   <UserError>
+//	      <error code="MISSING_NAME" field="name">Please provide a name.</error>
+//	   </UserError>
+func (e *UserError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if e == nil {
+		return nil
+	}
+	v := xmlUserError{}
+	for i, code := range e.codes {
+		v.Errors = append(v.Errors, xmlUserEntry{
+			Code:    code,
+			Field:   e.fields[code],
+			Message: Scrub(e.messages[i]),
+		})
+	}
+	return enc.EncodeElement(v, start)
+}
+
+// MarshalXML implements the xml.Marshaler interface, e.g. for SOAP fault details.
+// The SystemError is sanitized: the kind is an attribute followed by the scrubbed
+// message chain, whereas the stack trace and fields are omitted.
+// Nothing is written if the SystemError is nil.
+//
+//	Example:
+//	   <SystemError kind="unavailable">
+//	      <message>failed to load user</message>
+//	      <message>connection refused</message>
+//	   </SystemError>
+func (e *SystemError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if e == nil {
+		return nil
+	}
+	v := xmlSystemError{Kind: e.Kind(), Messages: e.Messages()}
+	for i, msg := range v.Messages {
+		v.Messages[i] = Scrub(msg)
+	}
+	return enc.EncodeElement(v, start)
+}
@@ -0,0 +1,45 @@
+package fault
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+func Test_UserError_MarshalXML(t *testing.T) {
+	userErr := UserField("name", "MISSING_NAME", "Please provide a name.")
+	userErr.Add("INVALID_EMAIL", "'a&b' is not a valid email address.")
+
+	data, err := xml.Marshal(userErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<UserError>` +
+		`<error code="MISSING_NAME" field="name">Please provide a name.</error>` +
+		`<error code="INVALID_EMAIL">&#39;a&amp;b&#39; is not a valid email address.</error>` +
+		`</UserError>`
+	if actual := string(data); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_SystemError_MarshalXML(t *testing.T) {
+	sysErr := SystemWrap(errors.New("connection refused"), "failed to load user").
+		WithKind(Unavailable).
+		WithField("password", "secret")
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name     `xml:"detail"`
+		Fault   *SystemError `xml:"fault"`
+	}{Fault: sysErr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<detail><fault kind="unavailable">` +
+		`<message>failed to load user</message>` +
+		`<message>connection refused</message>` +
+		`</fault></detail>`
+	if actual := string(data); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
@@ -0,0 +1,179 @@
+// Package faultaws classifies errors of the AWS SDK for Go v2 as faults.
+//
+// Errors are recognized by the smithy.APIError interface, which is implemented by all
+// modeled and generic API errors, and by the HTTP status code of the response errors
+// of the SDK.
+package faultaws
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/aws/smithy-go"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+type classification struct {
+	kind      fault.Kind
+	retryable bool
+}
+
+var (
+	throttled   = classification{fault.ResourceExhausted, true}
+	notFound    = classification{fault.NotFound, false}
+	conflict    = classification{fault.Conflict, false}
+	timeout     = classification{fault.Timeout, true}
+	unavailable = classification{fault.Unavailable, true}
+
+	// internal classifies the failures which are caused by the service itself,
+	// e.g. its own invalid or expired credentials, missing permissions or invalid
+	// requests, which mustn't be surfaced to clients as unlogged 4xx responses.
+	internal = classification{fault.Internal, false}
+)
+
+// errorCodes classifies the error codes which are shared by many AWS services.
+var errorCodes = map[string]classification{
+	"Throttling":                             throttled,
+	"ThrottlingException":                    throttled,
+	"ThrottledException":                     throttled,
+	"RequestThrottledException":              throttled,
+	"TooManyRequestsException":               throttled,
+	"ProvisionedThroughputExceededException": throttled,
+	"TransactionInProgressException":         throttled,
+	"RequestLimitExceeded":                   throttled,
+	"BandwidthLimitExceeded":                 throttled,
+	"LimitExceededException":                 throttled,
+	"RequestThrottled":                       throttled,
+	"SlowDown":                               throttled,
+	"PriorRequestNotComplete":                throttled,
+	"EC2ThrottledException":                  throttled,
+	"AccessDenied":                           internal,
+	"AccessDeniedException":                  internal,
+	"UnauthorizedOperation":                  internal,
+	"UnrecognizedClientException":            internal,
+	"InvalidClientTokenId":                   internal,
+	"InvalidAccessKeyId":                     internal,
+	"ExpiredToken":                           internal,
+	"ExpiredTokenException":                  internal,
+	"SignatureDoesNotMatch":                  internal,
+	"NotFound":                               notFound,
+	"NoSuchKey":                              notFound,
+	"NoSuchBucket":                           notFound,
+	"NoSuchEntity":                           notFound,
+	"ResourceNotFoundException":              notFound,
+	"ConflictException":                      conflict,
+	"ConditionalCheckFailedException":        conflict,
+	"ResourceInUseException":                 conflict,
+	"BucketAlreadyExists":                    conflict,
+	"BucketAlreadyOwnedByYou":                conflict,
+	"ValidationException":                    internal,
+	"ValidationError":                        internal,
+	"InvalidParameterException":              internal,
+	"InvalidParameterValue":                  internal,
+	"RequestTimeout":                         timeout,
+	"RequestTimeoutException":                timeout,
+	"ServiceUnavailable":                     unavailable,
+	"ServiceUnavailableException":            unavailable,
+	"InternalError":                          unavailable,
+	"InternalFailure":                        unavailable,
+	"InternalServerError":                    unavailable,
+}
+
+// statusCodes classifies errors which don't have a known error code by their HTTP status code.
+var statusCodes = map[int]classification{
+	http.StatusBadRequest:          internal,
+	http.StatusUnauthorized:        internal,
+	http.StatusForbidden:           internal,
+	http.StatusNotFound:            notFound,
+	http.StatusConflict:            conflict,
+	http.StatusPreconditionFailed:  conflict,
+	http.StatusTooManyRequests:     throttled,
+	http.StatusInternalServerError: unavailable,
+	http.StatusBadGateway:          unavailable,
+	http.StatusServiceUnavailable:  unavailable,
+	http.StatusGatewayTimeout:      timeout,
+}
+
+// ErrorCode returns the AWS error code of the first smithy.APIError in the error's chain.
+func ErrorCode(err error) (string, bool) {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode(), true
+	}
+	return "", false
+}
+
+// Classify returns the kind and retryability of an AWS SDK error.
+// It reports false if the error can't be classified.
+//
+// Errors are classified by their AWS error code (e.g. ThrottlingException as retryable
+// ResourceExhausted, AccessDenied as Internal and codes ending in NotFound as NotFound)
+// and otherwise by the HTTP status code of the response. Canceled operations are
+// classified as Canceled.
+func Classify(err error) (kind fault.Kind, retryable bool, ok bool) {
+	if err == nil {
+		return "", false, false
+	}
+	var canceled *smithy.CanceledError
+	if errors.As(err, &canceled) {
+		return fault.Canceled, false, true
+	}
+	if code, found := ErrorCode(err); found {
+		if c, found := errorCodes[code]; found {
+			return c.kind, c.retryable, true
+		}
+		if strings.HasSuffix(code, "NotFound") || strings.HasSuffix(code, "NotFoundException") {
+			return fault.NotFound, false, true
+		}
+	}
+	var respErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &respErr) {
+		if c, found := statusCodes[respErr.HTTPStatusCode()]; found {
+			return c.kind, c.retryable, true
+		}
+	}
+	return "", false, false
+}
+
+// adapter classifies AWS SDK errors and attaches their error code and request ID.
+var adapter = fault.Adapter{Classify: Classify, Annotate: annotate}
+
+// Wrap wraps an error which has been returned by an AWS SDK v2 client into a SystemError
+// whose kind and retryability follow from the AWS error code or the HTTP status (e.g.
+// throttling as retryable ResourceExhausted). The AWS error code and the request ID are
+// attached as the aws_error_code and aws_request_id fields, so that a failure can be
+// looked up with AWS support. Errors of other origins are wrapped without a kind,
+// and a nil error results in an Invariant fault (see fault.SystemWrap).
+//
+//	Example:
+//	   out, err := client.GetObject(ctx, input)
+//	   if err != nil {
+//	      return faultaws.Wrap(err, "failed to download report")
+//	   }
+func Wrap(err error, msg string) *fault.SystemError {
+	return adapter.WrapSkip(err, msg, 1)
+}
+
+// Rule is a fault.Rule which translates the errors of AWS SDK operations into
+// SystemErrors with the kind, error code and request ID of Wrap, whose message is the
+// one of the SDK (including the service and operation). Errors with unknown codes
+// and statuses are left to the other rules.
+//
+//	Example:
+//	   fault.Register(faultaws.Rule)
+func Rule(err error) error {
+	return adapter.Rule(err)
+}
+
+// annotate attaches the AWS error code and the request ID of the error.
+func annotate(sysErr *fault.SystemError, err error) {
+	if code, ok := ErrorCode(err); ok {
+		sysErr.WithField("aws_error_code", code)
+	}
+	var reqErr interface{ ServiceRequestID() string }
+	if errors.As(err, &reqErr) && reqErr.ServiceRequestID() != "" {
+		sysErr.WithField("aws_request_id", reqErr.ServiceRequestID())
+	}
+}
@@ -0,0 +1,111 @@
+package faultaws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func apiError(code string) error {
+	return &smithy.OperationError{
+		ServiceID:     "S3",
+		OperationName: "GetObject",
+		Err:           &smithy.GenericAPIError{Code: code, Message: "a"},
+	}
+}
+
+func responseError(status int) error {
+	return &smithyhttp.ResponseError{
+		Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+		Err:      errors.New("a"),
+	}
+}
+
+type requestIDError struct {
+	error
+}
+
+func (e *requestIDError) ServiceRequestID() string { return "req-1" }
+
+func (e *requestIDError) Unwrap() error { return e.error }
+
+func Test_Classify(t *testing.T) {
+	tests := []struct {
+		err       error
+		kind      fault.Kind
+		retryable bool
+		ok        bool
+	}{
+		{nil, "", false, false},
+		{errors.New("a"), "", false, false},
+		{apiError("ThrottlingException"), fault.ResourceExhausted, true, true},
+		{apiError("AccessDenied"), fault.Internal, false, true},
+		{apiError("ExpiredToken"), fault.Internal, false, true},
+		{apiError("SignatureDoesNotMatch"), fault.Internal, false, true},
+		{apiError("ValidationException"), fault.Internal, false, true},
+		{responseError(http.StatusForbidden), fault.Internal, false, true},
+		{apiError("NoSuchKey"), fault.NotFound, false, true},
+		{apiError("ParameterNotFound"), fault.NotFound, false, true},
+		{apiError("ConditionalCheckFailedException"), fault.Conflict, false, true},
+		{apiError("Unknown"), "", false, false},
+		{responseError(http.StatusServiceUnavailable), fault.Unavailable, true, true},
+		{&smithy.CanceledError{Err: context.Canceled}, fault.Canceled, false, true},
+	}
+	for _, test := range tests {
+		kind, retryable, ok := Classify(test.err)
+		actual := fmt.Sprint(kind, retryable, ok)
+		expected := fmt.Sprint(test.kind, test.retryable, test.ok)
+		if actual != expected {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
+
+func Test_Wrap(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	sysErr := Wrap(&requestIDError{apiError("SlowDown")}, "failed to upload report")
+
+	if sysErr.Kind() != fault.ResourceExhausted || !sysErr.Retryable() {
+		t.Errorf(expectedFormat, "resource_exhausted true", fmt.Sprint(sysErr.Kind(), sysErr.Retryable()))
+	}
+	fields := sysErr.Fields()
+	if fields["aws_error_code"] != "SlowDown" || fields["aws_request_id"] != "req-1" {
+		t.Errorf(expectedFormat, "SlowDown req-1", fields)
+	}
+	if expected := fmt.Sprintf("%s:%d: failed to upload report", file, line+1); !strings.HasSuffix(sysErr.StackTrace(), expected) {
+		t.Errorf(expectedFormat, expected, sysErr.StackTrace())
+	}
+	if actual := Wrap(nil, "a").Kind(); actual != fault.Bug {
+		t.Errorf(expectedFormat, fault.Bug, actual)
+	}
+}
+
+func Test_Rule(t *testing.T) {
+	tr := &fault.Translator{}
+	tr.Register(Rule)
+
+	translated := tr.Translate(apiError("NoSuchBucket"))
+	if actual := fault.KindOf(translated); actual != fault.NotFound {
+		t.Errorf(expectedFormat, fault.NotFound, actual)
+	}
+	if expected := apiError("NoSuchBucket").Error(); translated.Error() != expected {
+		t.Errorf(expectedFormat, expected, translated.Error())
+	}
+	err := errors.New("a")
+	if actual := tr.Translate(err); actual != err {
+		t.Errorf(expectedFormat, err, actual)
+	}
+}
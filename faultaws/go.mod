@@ -0,0 +1,10 @@
+module github.com/dusted-go/fault/faultaws
+
+go 1.19
+
+require (
+	github.com/aws/smithy-go v1.20.3
+	github.com/dusted-go/fault v1.5.0
+)
+
+replace github.com/dusted-go/fault => ../
@@ -0,0 +1,27 @@
+// Package faultcbor encodes and decodes fault chains as CBOR (RFC 8949), with the same
+// schema as fault.Encode. Compared to JSON the encoding is more compact and preserves
+// integer field values, which makes it suitable for constrained devices and message buses.
+//
+//	Example:
+//	   payload := faultcbor.Encode(err)
+//	   ...
+//	   err := faultcbor.Decode(payload)
+package faultcbor
+
+import (
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// Encode serializes the entire chain of the error as CBOR.
+// See fault.Encode for more details. It returns nil if the error is nil.
+func Encode(err error) []byte {
+	return fault.EncodeWith(err, cbor.Marshal)
+}
+
+// Decode restores an error chain which has been serialized by Encode.
+// See fault.Decode for more details. It returns nil if the data is empty.
+func Decode(data []byte) error {
+	return fault.DecodeWith(data, cbor.Unmarshal)
+}
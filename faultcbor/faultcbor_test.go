@@ -0,0 +1,62 @@
+package faultcbor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func Test_EncodeDecode(t *testing.T) {
+	userErr := fault.User("MISSING_NAME", "Please provide a name.")
+	err := fault.SystemWrap(userErr, "failed to register device").
+		WithKind(fault.NotFound).
+		WithSeverity(fault.SeverityWarning).
+		WithField("attempt", 3).
+		WithRetryAfter(time.Second)
+
+	decoded := Decode(Encode(err))
+
+	if decoded.Error() != err.Error() {
+		t.Errorf(expectedFormat, err.Error(), decoded.Error())
+	}
+	var sysErr *fault.SystemError
+	if !errors.As(decoded, &sysErr) {
+		t.Fatalf(expectedFormat, "*fault.SystemError", decoded)
+	}
+	if sysErr.StackTrace() != err.StackTrace() {
+		t.Errorf(expectedFormat, err.StackTrace(), sysErr.StackTrace())
+	}
+	if sysErr.Kind() != fault.NotFound || sysErr.Severity() != fault.SeverityWarning {
+		t.Errorf(expectedFormat, "not_found warning", sysErr.Kind().String()+" "+sysErr.Severity().String())
+	}
+	if retryAfter, _ := sysErr.RetryAfter(); retryAfter != time.Second {
+		t.Errorf(expectedFormat, time.Second, retryAfter)
+	}
+	if actual := sysErr.Fields()["attempt"]; actual != uint64(3) {
+		t.Errorf(expectedFormat, uint64(3), actual)
+	}
+	if !fault.Equal(userErr, errors.Unwrap(decoded)) {
+		t.Errorf(expectedFormat, userErr, errors.Unwrap(decoded))
+	}
+}
+
+func Test_EncodeDecode_WithNil(t *testing.T) {
+	if data := Encode(nil); data != nil {
+		t.Errorf(expectedFormat, nil, data)
+	}
+	if err := Decode(nil); err != nil {
+		t.Errorf(expectedFormat, nil, err)
+	}
+}
+
+func Test_Decode_WithInvalidData(t *testing.T) {
+	if err := Decode([]byte{0xff}); !fault.IsSystem(err) {
+		t.Errorf(expectedFormat, "*fault.SystemError", err)
+	}
+}
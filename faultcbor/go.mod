@@ -0,0 +1,12 @@
+module github.com/dusted-go/fault/faultcbor
+
+go 1.19
+
+require (
+	github.com/dusted-go/fault v1.5.0
+	github.com/fxamacker/cbor/v2 v2.7.0
+)
+
+require github.com/x448/float16 v0.8.4 // indirect
+
+replace github.com/dusted-go/fault => ../
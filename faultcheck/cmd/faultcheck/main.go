@@ -0,0 +1,14 @@
+// Command faultcheck runs the faultcheck analyzer.
+//
+//	go vet -vettool=$(which faultcheck) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/dusted-go/fault/faultcheck"
+)
+
+func main() {
+	singlechecker.Main(faultcheck.Analyzer)
+}
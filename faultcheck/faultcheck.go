@@ -0,0 +1,341 @@
+// Package faultcheck provides an analyzer which enforces the conventions of the fault package.
+//
+// The analyzer reports:
+//
+//   - user error codes which are registered more than once via fault.RegisterCode,
+//     which would panic at runtime
+//   - calls to fault.SystemWrap, fault.SystemWrapf and fault.OpWrap whose error
+//     hasn't been checked for nil before
+//   - exported functions which return an error of another package without wrapping it,
+//     so that the error leaves the package without a stack trace
+//
+// It can be run with go vet:
+//
+//	go install github.com/dusted-go/fault/faultcheck/cmd/faultcheck@latest
+//	go vet -vettool=$(which faultcheck) ./...
+package faultcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	faultModule = "github.com/dusted-go/fault"
+	faultPkg    = faultModule + "/fault"
+)
+
+// Analyzer reports violations of the conventions of the fault package.
+var Analyzer = &analysis.Analyzer{
+	Name:     "faultcheck",
+	Doc:      "check that errors are created, wrapped and registered as the fault package encourages",
+	URL:      "https://pkg.go.dev/github.com/dusted-go/fault/faultcheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	checkDuplicateCodes(pass, insp)
+	checkUncheckedWraps(pass, insp)
+	checkUnwrappedReturns(pass, insp)
+	return nil, nil
+}
+
+// faultFunc returns the name of the function of the fault package which is being called.
+func faultFunc(pass *analysis.Pass, call *ast.CallExpr) string {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != faultPkg {
+		return ""
+	}
+	return fn.Name()
+}
+
+// ------
+// Duplicate Codes
+// ------
+
+func checkDuplicateCodes(pass *analysis.Pass, insp *inspector.Inspector) {
+	registered := map[string]token.Pos{}
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if faultFunc(pass, call) != "RegisterCode" {
+			return
+		}
+		for _, arg := range call.Args {
+			lit, ok := arg.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			codeExpr := codeInfoCode(lit)
+			if codeExpr == nil {
+				continue
+			}
+			tv, ok := pass.TypesInfo.Types[codeExpr]
+			if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+				continue
+			}
+			code := constant.StringVal(tv.Value)
+			if pos, ok := registered[code]; ok {
+				pass.Reportf(codeExpr.Pos(), "error code %q has already been registered at %s",
+					code, pass.Fset.Position(pos))
+				continue
+			}
+			registered[code] = codeExpr.Pos()
+		}
+	})
+}
+
+// codeInfoCode returns the expression of the Code field of a fault.CodeInfo literal.
+func codeInfoCode(lit *ast.CompositeLit) ast.Expr {
+	for i, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			if i == 0 {
+				return elt
+			}
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Code" {
+			return kv.Value
+		}
+	}
+	return nil
+}
+
+// ------
+// Unchecked Wraps
+// ------
+
+func checkUncheckedWraps(pass *analysis.Pass, insp *inspector.Inspector) {
+	insp.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		var errArg ast.Expr
+		switch name := faultFunc(pass, call); name {
+		case "SystemWrap", "SystemWrapf":
+			errArg = call.Args[0]
+		case "OpWrap":
+			errArg = call.Args[1]
+		default:
+			return true
+		}
+		switch arg := ast.Unparen(errArg).(type) {
+		case *ast.CallExpr:
+			pass.Reportf(arg.Pos(), "the error is wrapped without checking it for nil")
+		case *ast.Ident:
+			obj := pass.TypesInfo.Uses[arg]
+			if obj != nil && !checkedBefore(pass, stack, obj, call.Pos()) {
+				pass.Reportf(arg.Pos(), "%s is wrapped without checking it for nil", arg.Name)
+			}
+		}
+		return true
+	})
+}
+
+// checkedBefore reports whether a condition of the enclosing function
+// refers to the object before the given position.
+func checkedBefore(pass *analysis.Pass, stack []ast.Node, obj types.Object, pos token.Pos) bool {
+	body := enclosingBody(stack)
+	if body == nil {
+		return true
+	}
+	checked := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if checked || n == nil || n.Pos() >= pos {
+			return false
+		}
+		var cond ast.Expr
+		switch n := n.(type) {
+		case *ast.IfStmt:
+			cond = n.Cond
+		case *ast.ForStmt:
+			cond = n.Cond
+		case *ast.CaseClause:
+			for _, expr := range n.List {
+				checked = checked || refersTo(pass, expr, obj)
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				cond = n
+			}
+		}
+		if cond != nil && refersTo(pass, cond, obj) {
+			checked = true
+		}
+		return !checked
+	})
+	return checked
+}
+
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncDecl:
+			return n.Body
+		case *ast.FuncLit:
+			return n.Body
+		}
+	}
+	return nil
+}
+
+func refersTo(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[id] == obj {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// ------
+// Unwrapped Returns
+// ------
+
+// ioMethods are methods which must return the errors of the
+// io interfaces unwrapped, since callers compare them with io.EOF.
+var ioMethods = map[string]bool{
+	"Read":     true,
+	"ReadAt":   true,
+	"ReadFrom": true,
+	"Write":    true,
+	"WriteAt":  true,
+	"WriteTo":  true,
+	"Seek":     true,
+	"Close":    true,
+}
+
+// passthroughPkgs are packages whose errors don't need to be wrapped,
+// since they create or wrap errors themselves.
+var passthroughPkgs = map[string]bool{
+	"errors": true,
+	"fmt":    true,
+}
+
+func checkUnwrappedReturns(pass *analysis.Pass, insp *inspector.Inspector) {
+	if pass.Pkg.Name() == "main" || inFaultModule(pass.Pkg.Path()) {
+		return
+	}
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		fn := n.(*ast.FuncDecl)
+		if fn.Body == nil || !fn.Name.IsExported() || (fn.Recv != nil && ioMethods[fn.Name.Name]) {
+			return
+		}
+		if fn.Recv != nil && !exportedReceiver(fn.Recv) {
+			return
+		}
+		checkFuncReturns(pass, fn.Body)
+	})
+}
+
+func exportedReceiver(recv *ast.FieldList) bool {
+	if len(recv.List) == 0 {
+		return false
+	}
+	expr := recv.List[0].Type
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.IsExported()
+		default:
+			return false
+		}
+	}
+}
+
+func checkFuncReturns(pass *analysis.Pass, body *ast.BlockStmt) {
+	// foreign tracks which error variables have last been assigned from a call to another package.
+	foreign := map[types.Object]string{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			trackAssign(pass, n, foreign)
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				if !isError(pass.TypesInfo.TypeOf(result)) {
+					continue
+				}
+				switch r := ast.Unparen(result).(type) {
+				case *ast.CallExpr:
+					if pkg := foreignCall(pass, r); pkg != "" {
+						pass.Reportf(r.Pos(), "error returned from %s is not wrapped", pkg)
+					}
+				case *ast.Ident:
+					if pkg, ok := foreign[pass.TypesInfo.Uses[r]]; ok {
+						pass.Reportf(r.Pos(), "error returned from %s is not wrapped", pkg)
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+func trackAssign(pass *analysis.Pass, assign *ast.AssignStmt, foreign map[types.Object]string) {
+	pkg := ""
+	if len(assign.Rhs) == 1 {
+		if call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr); ok {
+			pkg = foreignCall(pass, call)
+		}
+	}
+	for _, lhs := range assign.Lhs {
+		id, ok := lhs.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		obj := pass.TypesInfo.ObjectOf(id)
+		if obj == nil || !isError(obj.Type()) {
+			continue
+		}
+		if pkg != "" {
+			foreign[obj] = pkg
+		} else {
+			delete(foreign, obj)
+		}
+	}
+}
+
+// foreignCall returns the path of the package of the called function
+// if its errors must be wrapped before being returned.
+func foreignCall(pass *analysis.Pass, call *ast.CallExpr) string {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg() == pass.Pkg {
+		return ""
+	}
+	path := fn.Pkg().Path()
+	if passthroughPkgs[path] || inFaultModule(path) {
+		return ""
+	}
+	return path
+}
+
+func inFaultModule(path string) bool {
+	return path == faultModule || strings.HasPrefix(path, faultModule+"/")
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+func isError(t types.Type) bool {
+	return t != nil && types.Identical(t, errorType)
+}
@@ -0,0 +1,91 @@
+package a
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const codeNotFound = "NOT_FOUND"
+
+func init() {
+	fault.RegisterCode(
+		fault.CodeInfo{Code: codeNotFound},
+		fault.CodeInfo{Code: "INVALID"},
+	)
+	fault.RegisterCode(
+		fault.CodeInfo{Code: "NOT_FOUND"},    // want `error code "NOT_FOUND" has already been registered at .*a.go:16:24`
+		fault.CodeInfo{"INVALID", "", 0, ""}, // want `error code "INVALID" has already been registered`
+	)
+}
+
+func Checked(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fault.SystemWrap(err, "failed to open file")
+	}
+	if err := f.Close(); err != nil {
+		return fault.OpWrap("close", err)
+	}
+	return nil
+}
+
+func Unchecked(path string) error {
+	f, err := os.Open(path)
+	_ = f
+	return fault.SystemWrapf(err, "failed to open %s", path) // want `err is wrapped without checking it for nil`
+}
+
+func UncheckedCall(f *os.File) error {
+	return fault.OpWrap("close", f.Close()) // want `the error is wrapped without checking it for nil`
+}
+
+func Unwrapped(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err // want `error returned from os is not wrapped`
+	}
+	return f.Close() // want `error returned from os is not wrapped`
+}
+
+func UnwrappedIf(s string) (int, error) {
+	if _, err := strconv.Atoi(s); err != nil {
+		return 0, err // want `error returned from strconv is not wrapped`
+	}
+	return 0, nil
+}
+
+func Passthrough(err error) error {
+	if err == nil {
+		return errors.New("missing error")
+	}
+	return fmt.Errorf("failed: %w", err)
+}
+
+func Reassigned(path string) error {
+	_, err := os.Stat(path)
+	if err != nil {
+		err = fault.SystemWrap(err, "failed to stat file")
+	}
+	return err
+}
+
+func unexported(path string) error {
+	_, err := os.Stat(path)
+	return err
+}
+
+type File struct {
+	f *os.File
+}
+
+func (f *File) Close() error {
+	return f.f.Close()
+}
+
+func (f *File) Sync() error {
+	return f.f.Sync() // want `error returned from os is not wrapped`
+}
@@ -0,0 +1,24 @@
+package fault
+
+type SystemError struct{}
+
+func (e *SystemError) Error() string { return "" }
+
+type Op string
+
+type Code string
+
+type CodeInfo struct {
+	Code        Code
+	Description string
+	Status      int
+	DocURL      string
+}
+
+func RegisterCode(infos ...CodeInfo) {}
+
+func SystemWrap(err error, msg string) *SystemError { return nil }
+
+func SystemWrapf(err error, format string, a ...interface{}) *SystemError { return nil }
+
+func OpWrap(op Op, err error) *SystemError { return nil }
@@ -0,0 +1,50 @@
+// Package faultchi integrates the fault package with the chi router.
+package faultchi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dusted-go/fault/httpfault"
+)
+
+// Responder is the httpfault.Responder used by this package.
+// It resolves the route pattern of a request from chi's routing context.
+// Its other fields can be configured as with any other httpfault.Responder.
+var Responder = &httpfault.Responder{RoutePattern: RoutePattern}
+
+// RoutePattern returns the chi route pattern which matched the request (e.g. /users/{id}).
+func RoutePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		return rctx.RoutePattern()
+	}
+	return ""
+}
+
+// Respond writes the error as a HTTP response.
+//
+//	if err != nil {
+//	   faultchi.Respond(w, r, err)
+//	   return
+//	}
+func Respond(w http.ResponseWriter, r *http.Request, err error) {
+	Responder.WriteError(w, r, err)
+}
+
+// Recoverer is a chi middleware which recovers panics into a
+// SystemError and writes a safe 500 response.
+//
+//	r := chi.NewRouter()
+//	r.Use(faultchi.Recoverer)
+func Recoverer(next http.Handler) http.Handler {
+	return Responder.Recover(next)
+}
+
+// Handle converts a handler which returns an error into a http.HandlerFunc
+// which can be registered with a chi router.
+//
+//	r.Get("/users/{id}", faultchi.Handle(getUser))
+func Handle(h httpfault.HandlerFunc) http.HandlerFunc {
+	return Responder.Handle(h).ServeHTTP
+}
@@ -0,0 +1,83 @@
+package faultchi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func newRouter(logged *error) http.Handler {
+	Responder.Logger = func(r *http.Request, err error) {
+		*logged = err
+	}
+	r := chi.NewRouter()
+	r.Use(Recoverer)
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		Respond(w, r, fault.User("INVALID_ID", "Invalid ID."))
+	})
+	r.Get("/orders/{id}", Handle(func(w http.ResponseWriter, r *http.Request) error {
+		return fault.System("connection refused")
+	}))
+	r.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("something went wrong")
+	})
+	return r
+}
+
+func Test_Respond_WithUserError(t *testing.T) {
+	var logged error
+	w := httptest.NewRecorder()
+
+	newRouter(&logged).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/x", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf(expectedFormat, http.StatusBadRequest, w.Code)
+	}
+	expected := `{"message":"Bad Request","errors":[{"code":"INVALID_ID","message":"Invalid ID."}]}`
+	if actual := strings.TrimSpace(w.Body.String()); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Handle_WithSystemError(t *testing.T) {
+	var logged error
+	w := httptest.NewRecorder()
+
+	newRouter(&logged).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, w.Code)
+	}
+	var sysErr *fault.SystemError
+	if !errors.As(logged, &sysErr) {
+		t.Fatal("System errors were expected to be logged.")
+	}
+	if actual := sysErr.Fields()["route"]; actual != "/orders/{id}" {
+		t.Errorf(expectedFormat, "/orders/{id}", actual)
+	}
+}
+
+func Test_Recoverer_WithPanic(t *testing.T) {
+	var logged error
+	w := httptest.NewRecorder()
+
+	newRouter(&logged).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, w.Code)
+	}
+	expected := "panic: something went wrong"
+	if logged == nil || logged.Error() != expected {
+		t.Errorf(expectedFormat, expected, logged)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/dusted-go/fault/faultchi
+
+go 1.19
+
+require (
+	github.com/dusted-go/fault v1.5.0
+	github.com/go-chi/chi/v5 v5.1.0
+)
+
+replace github.com/dusted-go/fault => ../
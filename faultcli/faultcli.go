@@ -0,0 +1,118 @@
+// Package faultcli integrates the fault package with cobra CLIs.
+package faultcli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// VerboseFlag is the name of the persistent flag which enables
+// printing the stack traces of system errors.
+const VerboseFlag = "verbose"
+
+// InvalidFlagCode is the user error code of invalid command line flags.
+const InvalidFlagCode = "INVALID_FLAG"
+
+// Option configures how errors are handled.
+type Option func(*options)
+
+type options struct {
+	mapper *fault.ExitCodeMapper
+}
+
+// WithExitCodeMapper sets the ExitCodeMapper which decides the exit code of an error.
+// By default the fault.DefaultExitCodeMapper will be used.
+func WithExitCodeMapper(mapper *fault.ExitCodeMapper) Option {
+	return func(o *options) {
+		o.mapper = mapper
+	}
+}
+
+// Setup prepares the root command for centralized error handling.
+//
+// It silences cobra's own error and usage output, converts invalid flags into
+// a UserError and registers the persistent --verbose flag.
+func Setup(root *cobra.Command) {
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return fault.Userf(InvalidFlagCode, "%s\nRun '%s --help' for usage.", err, cmd.CommandPath())
+	})
+	if root.PersistentFlags().Lookup(VerboseFlag) == nil {
+		root.PersistentFlags().Bool(VerboseFlag, false, "print stack traces of internal errors")
+	}
+}
+
+// Run sets up and executes the root command. Errors are printed with PrintError.
+// It returns the exit code of the error, which is 0 if the command succeeded.
+func Run(root *cobra.Command, opts ...Option) int {
+	o := &options{mapper: fault.DefaultExitCodeMapper}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	Setup(root)
+	cmd, err := root.ExecuteC()
+	if err == nil {
+		return 0
+	}
+	PrintError(cmd, err)
+	return o.mapper.ExitCode(err)
+}
+
+// Execute runs the root command and terminates the program with the exit code of the error.
+//
+//	func main() {
+//	   faultcli.Execute(rootCmd)
+//	}
+func Execute(root *cobra.Command, opts ...Option) {
+	os.Exit(Run(root, opts...))
+}
+
+// PrintError writes the error to the error output of the command.
+//
+// A UserError is written tersely as its friendly message. Any other error is written
+// as its message, followed by the stack trace of a SystemError if the --verbose flag is set.
+// The output is colored if the error output supports it (see fault.ColorEnabled).
+func PrintError(cmd *cobra.Command, err error) {
+	w := cmd.ErrOrStderr()
+	color := fault.ColorEnabled(w)
+	prefix := paint("Error:", ansiBoldRed, color)
+
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		fmt.Fprintf(w, "%s %s\n", prefix, userErr.FriendlyError())
+		return
+	}
+
+	fmt.Fprintf(w, "%s %s\n", prefix, err.Error())
+	var sysErr *fault.SystemError
+	if verbose(cmd) && errors.As(err, &sysErr) {
+		fmt.Fprintf(w, "%s\n", paint(sysErr.StackTrace(), ansiDim, color))
+	}
+}
+
+// ANSI escape codes of colored output.
+const (
+	ansiReset   = "\x1b[0m"
+	ansiDim     = "\x1b[2m"
+	ansiBoldRed = "\x1b[1;31m"
+)
+
+// paint wraps the text in the ANSI escape code if color is true.
+func paint(s, code string, color bool) string {
+	if !color {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+func verbose(cmd *cobra.Command) bool {
+	v, err := cmd.Flags().GetBool(VerboseFlag)
+	return err == nil && v
+}
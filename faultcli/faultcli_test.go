@@ -0,0 +1,118 @@
+package faultcli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func newCommand(err error, args ...string) (*cobra.Command, *bytes.Buffer) {
+	root := &cobra.Command{Use: "app"}
+	root.AddCommand(&cobra.Command{
+		Use: "run",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return err
+		},
+	})
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(buf)
+	root.SetArgs(args)
+	return root, buf
+}
+
+func Test_Run_WithoutError(t *testing.T) {
+	root, buf := newCommand(nil, "run")
+
+	if actual := Run(root); actual != 0 || buf.Len() != 0 {
+		t.Errorf(expectedFormat, 0, fmt.Sprintf("%d %s", actual, buf))
+	}
+}
+
+func Test_Run_WithUserError(t *testing.T) {
+	root, buf := newCommand(fault.User("MISSING_NAME", "Please provide a name."), "run")
+
+	actual := Run(root)
+
+	if actual != 2 {
+		t.Errorf(expectedFormat, 2, actual)
+	}
+	if expected := "Error: Please provide a name.\n"; buf.String() != expected {
+		t.Errorf(expectedFormat, expected, buf.String())
+	}
+}
+
+func Test_Run_WithSystemError(t *testing.T) {
+	err := fault.SystemWrap(errors.New("connection refused"), "failed to load config").WithKind(fault.Unavailable)
+	root, buf := newCommand(err, "run")
+
+	actual := Run(root)
+
+	if actual != 69 {
+		t.Errorf(expectedFormat, 69, actual)
+	}
+	if expected := "Error: failed to load config\n   connection refused\n"; buf.String() != expected {
+		t.Errorf(expectedFormat, expected, buf.String())
+	}
+}
+
+func Test_Run_WithSystemErrorAndVerboseFlag(t *testing.T) {
+	root, buf := newCommand(fault.System("a"), "run", "--verbose")
+
+	actual := Run(root, WithExitCodeMapper(&fault.ExitCodeMapper{Fallback: 3}))
+
+	if actual != 3 {
+		t.Errorf(expectedFormat, 3, actual)
+	}
+	if expected := "Error: a\n\nat "; !strings.HasPrefix(buf.String(), expected) {
+		t.Errorf(expectedFormat, expected, buf.String())
+	}
+}
+
+func Test_Run_WithInvalidFlag(t *testing.T) {
+	root, buf := newCommand(nil, "run", "--unknown")
+
+	actual := Run(root)
+
+	if actual != 2 {
+		t.Errorf(expectedFormat, 2, actual)
+	}
+	expected := "Error: unknown flag: --unknown\nRun 'app run --help' for usage.\n"
+	if buf.String() != expected {
+		t.Errorf(expectedFormat, expected, buf.String())
+	}
+}
+
+func Test_Run_WithColor(t *testing.T) {
+	t.Setenv("FORCE_COLOR", "1")
+	t.Setenv("NO_COLOR", "")
+	root, buf := newCommand(fault.User("MISSING_NAME", "Please provide a name."), "run")
+
+	Run(root)
+
+	if expected := "\x1b[1;31mError:\x1b[0m Please provide a name.\n"; buf.String() != expected {
+		t.Errorf(expectedFormat, expected, buf.String())
+	}
+}
+
+func Test_Run_WithNoColor(t *testing.T) {
+	t.Setenv("FORCE_COLOR", "1")
+	t.Setenv("NO_COLOR", "1")
+	root, buf := newCommand(fault.User("MISSING_NAME", "Please provide a name."), "run")
+
+	Run(root)
+
+	if expected := "Error: Please provide a name.\n"; buf.String() != expected {
+		t.Errorf(expectedFormat, expected, buf.String())
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/dusted-go/fault/faultcli
+
+go 1.19
+
+require (
+	github.com/dusted-go/fault v1.5.0
+	github.com/spf13/cobra v1.8.1
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+)
+
+replace github.com/dusted-go/fault => ../
@@ -0,0 +1,314 @@
+// Package faultconnect integrates the fault package with Connect (connectrpc.com/connect).
+package faultconnect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// Option configures the interceptor.
+type Option func(*Interceptor)
+
+// WithLogger sets the function which receives the internal details of all errors
+// which have been converted into a server side error (e.g. connect.CodeInternal).
+// By default the error including its stack trace will be written to the standard logger.
+// The errors are published to the fault.DefaultBus as well.
+func WithLogger(logger func(ctx context.Context, err error)) Option {
+	return func(i *Interceptor) {
+		i.logger = logger
+	}
+}
+
+// Interceptor converts errors at the boundary of a Connect service.
+//
+// On the handler side it recovers panics into a SystemError and converts all
+// returned errors into a *connect.Error (see ToError). On the client side it converts
+// all returned *connect.Error values back into faults (see FromError).
+//
+// Example:
+//
+//	path, handler := usersv1connect.NewUsersServiceHandler(
+//	   svc, connect.WithInterceptors(faultconnect.NewInterceptor()))
+type Interceptor struct {
+	logger func(ctx context.Context, err error)
+}
+
+// NewInterceptor creates a new Interceptor.
+func NewInterceptor(opts ...Option) *Interceptor {
+	i := &Interceptor{
+		logger: func(ctx context.Context, err error) {
+			log.Printf("%+v", err)
+		},
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// WrapUnary implements connect.Interceptor.
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (resp connect.AnyResponse, err error) {
+		if req.Spec().IsClient {
+			resp, err = next(ctx, req)
+			return resp, FromError(req.Spec().Procedure, err)
+		}
+		defer func() {
+			if v := recover(); v != nil {
+				err = i.convert(ctx, fault.FromPanic(v))
+			}
+		}()
+		resp, err = next(ctx, req)
+		if err != nil {
+			return resp, i.convert(ctx, err)
+		}
+		return resp, nil
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor.
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		return &clientConn{StreamingClientConn: next(ctx, spec)}
+	}
+}
+
+// WrapStreamingHandler implements connect.Interceptor.
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = i.convert(ctx, fault.FromPanic(v))
+			}
+		}()
+		if err := next(ctx, conn); err != nil {
+			return i.convert(ctx, err)
+		}
+		return nil
+	}
+}
+
+func (i *Interceptor) convert(ctx context.Context, err error) error {
+	connectErr := ToError(err)
+	if isServerSide(connectErr.Code()) {
+		i.logger(ctx, err)
+		fault.Publish(err)
+	}
+	return connectErr
+}
+
+type clientConn struct {
+	connect.StreamingClientConn
+}
+
+func (c *clientConn) Send(m any) error {
+	return c.convert(c.StreamingClientConn.Send(m))
+}
+
+func (c *clientConn) Receive(m any) error {
+	return c.convert(c.StreamingClientConn.Receive(m))
+}
+
+func (c *clientConn) CloseResponse() error {
+	return c.convert(c.StreamingClientConn.CloseResponse())
+}
+
+func (c *clientConn) convert(err error) error {
+	if errors.Is(err, io.EOF) {
+		return err
+	}
+	return FromError(c.Spec().Procedure, err)
+}
+
+// ToError converts an error into a *connect.Error.
+//
+// A UserError gets converted into an error with the friendly error message and a
+// errdetails.BadRequest detail, which contains a field violation for each user error
+// (the field being the error code and the description being the message). The error
+// has the GRPCCode of the first user error code which has one in the fault code registry
+// (see fault.CodeInfo), or connect.CodeInvalidArgument otherwise.
+//
+// A SystemError gets converted into an error with the code of its kind and a generic
+// message which doesn't leak any internal details. If the SystemError contains a retry hint
+// then the error contains a errdetails.RetryInfo detail.
+//
+// A *connect.Error (which isn't wrapped by a fault) is returned as is.
+// Any other error gets converted into a connect.CodeInternal error.
+func ToError(err error) *connect.Error {
+	if err == nil {
+		return nil
+	}
+
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		userErr = userErr.Scrub()
+		msgs := userErr.ErrorMessages()
+		badRequest := &errdetails.BadRequest{}
+		for i, code := range userErr.Codes() {
+			badRequest.FieldViolations = append(badRequest.FieldViolations,
+				&errdetails.BadRequest_FieldViolation{Field: code.String(), Description: msgs[i]})
+		}
+		connectErr := connect.NewError(userCode(userErr), errors.New(userErr.FriendlyError()))
+		addDetail(connectErr, badRequest)
+		return connectErr
+	}
+
+	var sysErr *fault.SystemError
+	if !errors.As(err, &sysErr) {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			return connectErr
+		}
+	}
+
+	code := CodeOf(fault.KindOf(err))
+	connectErr := connect.NewError(code, errors.New(code.String()))
+	if retryAfter, ok := fault.RetryAfter(err); ok {
+		addDetail(connectErr, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	}
+	return connectErr
+}
+
+func addDetail(err *connect.Error, msg proto.Message) {
+	if detail, detailErr := connect.NewErrorDetail(msg); detailErr == nil {
+		err.AddDetail(detail)
+	}
+}
+
+// FromError converts an error which has been returned by a Connect call back into a fault.
+//
+// An error with a client-side code and a errdetails.BadRequest detail (as created by
+// ToError) gets converted into a UserError with the same codes and messages.
+// Any other *connect.Error gets converted into a SystemError which wraps the original
+// error (so that its metadata remains accessible via errors.As) and has the kind which
+// corresponds to the error code. A errdetails.RetryInfo detail gets converted into a retry hint.
+//
+// Errors which are not a *connect.Error are returned as is.
+func FromError(procedure string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		return err
+	}
+
+	var badRequest *errdetails.BadRequest
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range connectErr.Details() {
+		value, valueErr := detail.Value()
+		if valueErr != nil {
+			continue
+		}
+		switch d := value.(type) {
+		case *errdetails.BadRequest:
+			badRequest = d
+		case *errdetails.RetryInfo:
+			retryInfo = d
+		}
+	}
+
+	if !isServerSide(connectErr.Code()) && badRequest != nil && len(badRequest.FieldViolations) > 0 {
+		var userErr *fault.UserError
+		for _, v := range badRequest.FieldViolations {
+			if userErr == nil {
+				userErr = fault.User(fault.Code(v.Field), v.Description)
+			} else {
+				userErr.Add(fault.Code(v.Field), v.Description)
+			}
+		}
+		if retryInfo != nil {
+			userErr.WithRetryAfter(retryInfo.RetryDelay.AsDuration())
+		}
+		return userErr
+	}
+
+	sysErr := fault.SystemWrap(err, fmt.Sprintf("calling %s", procedure)).WithKind(KindOf(connectErr.Code()))
+	if retryInfo != nil {
+		sysErr.WithRetryAfter(retryInfo.RetryDelay.AsDuration())
+	}
+	return sysErr
+}
+
+var kindCodes = map[fault.Kind]connect.Code{
+	fault.Internal:          connect.CodeInternal,
+	fault.Canceled:          connect.CodeCanceled,
+	fault.Timeout:           connect.CodeDeadlineExceeded,
+	fault.Unavailable:       connect.CodeUnavailable,
+	fault.NotFound:          connect.CodeNotFound,
+	fault.Conflict:          connect.CodeAborted,
+	fault.PermissionDenied:  connect.CodePermissionDenied,
+	fault.Unauthenticated:   connect.CodeUnauthenticated,
+	fault.ResourceExhausted: connect.CodeResourceExhausted,
+	fault.InvalidArgument:   connect.CodeInvalidArgument,
+	fault.Unimplemented:     connect.CodeUnimplemented,
+}
+
+// userCode returns the code of the first user error code which has a gRPC code
+// in the fault code registry, since Connect shares its codes with gRPC.
+func userCode(userErr *fault.UserError) connect.Code {
+	for _, code := range userErr.Codes() {
+		if info, ok := fault.LookupCode(code); ok && info.GRPCCode != 0 {
+			return connect.Code(info.GRPCCode)
+		}
+	}
+	return connect.CodeInvalidArgument
+}
+
+// CodeOf returns the Connect code which corresponds to the kind of a SystemError.
+func CodeOf(kind fault.Kind) connect.Code {
+	if code, ok := kindCodes[kind]; ok {
+		return code
+	}
+	return connect.CodeInternal
+}
+
+// KindOf returns the kind of a SystemError which corresponds to the Connect code.
+func KindOf(code connect.Code) fault.Kind {
+	switch code {
+	case connect.CodeCanceled:
+		return fault.Canceled
+	case connect.CodeDeadlineExceeded:
+		return fault.Timeout
+	case connect.CodeUnavailable:
+		return fault.Unavailable
+	case connect.CodeNotFound:
+		return fault.NotFound
+	case connect.CodeAlreadyExists, connect.CodeAborted, connect.CodeFailedPrecondition:
+		return fault.Conflict
+	case connect.CodePermissionDenied:
+		return fault.PermissionDenied
+	case connect.CodeUnauthenticated:
+		return fault.Unauthenticated
+	case connect.CodeResourceExhausted:
+		return fault.ResourceExhausted
+	case connect.CodeInvalidArgument, connect.CodeOutOfRange:
+		return fault.InvalidArgument
+	case connect.CodeUnimplemented:
+		return fault.Unimplemented
+	default:
+		return fault.Internal
+	}
+}
+
+// isServerSide reports whether the code indicates a fault of the server
+// rather than of the client, similar to a 5xx status code in HTTP.
+func isServerSide(code connect.Code) bool {
+	switch code {
+	case connect.CodeUnknown, connect.CodeDeadlineExceeded, connect.CodeUnimplemented,
+		connect.CodeInternal, connect.CodeUnavailable, connect.CodeDataLoss:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,147 @@
+package faultconnect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func invoke(t *testing.T, handlerErr error, handlerPanic interface{}) (error, error) {
+	t.Helper()
+	var logged error
+	interceptor := NewInterceptor(WithLogger(func(ctx context.Context, err error) {
+		logged = err
+	}))
+	handler := interceptor.WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if handlerPanic != nil {
+			panic(handlerPanic)
+		}
+		return nil, handlerErr
+	})
+	_, err := handler(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	return err, logged
+}
+
+func Test_Interceptor_WithUserError(t *testing.T) {
+	userErr := fault.User("MISSING_FIRST_NAME", "Please provide your first name.")
+	userErr.Add("MISSING_LAST_NAME", "Please provide your last name.")
+
+	err, logged := invoke(t, userErr, nil)
+
+	if code := connect.CodeOf(err); code != connect.CodeInvalidArgument {
+		t.Errorf(expectedFormat, connect.CodeInvalidArgument, code)
+	}
+	if logged != nil {
+		t.Errorf(expectedFormat, nil, logged)
+	}
+}
+
+func Test_Interceptor_WithSystemError(t *testing.T) {
+	sysErr := fault.System("connection refused").WithKind(fault.Unavailable)
+
+	err, logged := invoke(t, sysErr, nil)
+
+	if code := connect.CodeOf(err); code != connect.CodeUnavailable {
+		t.Errorf(expectedFormat, connect.CodeUnavailable, code)
+	}
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Message() != "unavailable" {
+		t.Errorf(expectedFormat, "unavailable", err)
+	}
+	if logged != sysErr {
+		t.Errorf(expectedFormat, sysErr, logged)
+	}
+}
+
+func Test_Interceptor_WithPanic(t *testing.T) {
+	err, logged := invoke(t, nil, "boom")
+
+	if code := connect.CodeOf(err); code != connect.CodeInternal {
+		t.Errorf(expectedFormat, connect.CodeInternal, code)
+	}
+	if logged == nil || logged.Error() != "panic: boom" {
+		t.Errorf(expectedFormat, "panic: boom", logged)
+	}
+}
+
+func Test_FromError_WithUserError(t *testing.T) {
+	userErr := fault.User("MISSING_FIRST_NAME", "Please provide your first name.")
+	userErr.Add("MISSING_LAST_NAME", "Please provide your last name.")
+
+	err := FromError("/users.v1.UsersService/Get", ToError(userErr))
+
+	var actual *fault.UserError
+	if !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.UserError", err)
+	}
+	if actual.Error() != userErr.Error() {
+		t.Errorf(expectedFormat, userErr.Error(), actual.Error())
+	}
+}
+
+func Test_ToError_WithRegisteredGRPCCode(t *testing.T) {
+	fault.RegisterCode(fault.CodeInfo{Code: "TEST_CONNECT_USER_NOT_FOUND", GRPCCode: uint32(connect.CodeNotFound)})
+	userErr := fault.User("TEST_CONNECT_USER_NOT_FOUND", "The user doesn't exist.")
+
+	connectErr := ToError(userErr)
+	if actual := connectErr.Code(); actual != connect.CodeNotFound {
+		t.Errorf(expectedFormat, connect.CodeNotFound, actual)
+	}
+
+	var actual *fault.UserError
+	if err := FromError("/users.v1.UsersService/Get", connectErr); !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.UserError", err)
+	}
+}
+
+func Test_FromError_WithSystemError(t *testing.T) {
+	sysErr := fault.System("connection refused").
+		WithKind(fault.Unavailable).
+		WithRetryAfter(time.Second)
+
+	err := FromError("/users.v1.UsersService/Get", ToError(sysErr))
+
+	var actual *fault.SystemError
+	if !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.SystemError", err)
+	}
+	if kind := actual.Kind(); kind != fault.Unavailable {
+		t.Errorf(expectedFormat, fault.Unavailable, kind)
+	}
+	if retryAfter, _ := actual.RetryAfter(); retryAfter != time.Second {
+		t.Errorf(expectedFormat, time.Second, retryAfter)
+	}
+	if code := connect.CodeOf(err); code != connect.CodeUnavailable {
+		t.Errorf(expectedFormat, connect.CodeUnavailable, code)
+	}
+}
+
+func Test_FromError_WithNonConnectError(t *testing.T) {
+	err := errors.New("foo bar")
+
+	actual := FromError("/users.v1.UsersService/Get", err)
+
+	if actual != err {
+		t.Errorf(expectedFormat, err, actual)
+	}
+}
+
+func Test_ToError_WithConnectError(t *testing.T) {
+	connectErr := connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+
+	actual := ToError(connectErr)
+
+	if actual != connectErr {
+		t.Errorf(expectedFormat, connectErr, actual)
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/dusted-go/fault/faultconnect
+
+go 1.21
+
+require (
+	connectrpc.com/connect v1.16.1
+	github.com/dusted-go/fault v1.5.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237
+	google.golang.org/protobuf v1.34.1
+)
+
+replace github.com/dusted-go/fault => ../
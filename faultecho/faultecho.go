@@ -0,0 +1,49 @@
+// Package faultecho integrates the fault package with the Echo web framework.
+package faultecho
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/dusted-go/fault/httpfault"
+)
+
+// HTTPErrorHandler returns an echo.HTTPErrorHandler which writes errors
+// with the given httpfault.Responder.
+//
+// User errors get rendered with all their codes and messages, whereas any
+// other error gets rendered as a sanitized response which doesn't leak internal
+// details. The internal details are passed to the Logger of the Responder instead.
+// If the Responder is nil then the httpfault.DefaultResponder will be used.
+//
+// Errors which have been raised by Echo itself (*echo.HTTPError, e.g. for an
+// unknown route) are passed to Echo's default error handler.
+//
+//	e := echo.New()
+//	e.HTTPErrorHandler = faultecho.HTTPErrorHandler(responder)
+func HTTPErrorHandler(rs *httpfault.Responder) echo.HTTPErrorHandler {
+	if rs == nil {
+		rs = httpfault.DefaultResponder
+	}
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			c.Echo().DefaultHTTPErrorHandler(err, c)
+			return
+		}
+
+		withRoute := *rs
+		if withRoute.RoutePattern == nil {
+			withRoute.RoutePattern = func(*http.Request) string {
+				return c.Path()
+			}
+		}
+		withRoute.WriteError(c.Response(), c.Request(), err)
+	}
+}
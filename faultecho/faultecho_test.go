@@ -0,0 +1,81 @@
+package faultecho
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/dusted-go/fault/fault"
+	"github.com/dusted-go/fault/httpfault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func newEcho(logged *error) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(&httpfault.Responder{
+		Logger: func(r *http.Request, err error) {
+			*logged = err
+		},
+	})
+	e.GET("/users/:id", func(c echo.Context) error {
+		return fault.User("INVALID_ID", "Invalid ID.")
+	})
+	e.GET("/orders/:id", func(c echo.Context) error {
+		return fault.System("connection refused")
+	})
+	return e
+}
+
+func Test_HTTPErrorHandler_WithUserError(t *testing.T) {
+	var logged error
+	w := httptest.NewRecorder()
+
+	newEcho(&logged).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/x", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf(expectedFormat, http.StatusBadRequest, w.Code)
+	}
+	expected := `{"message":"Bad Request","errors":[{"code":"INVALID_ID","message":"Invalid ID."}]}`
+	if actual := strings.TrimSpace(w.Body.String()); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_HTTPErrorHandler_WithSystemError(t *testing.T) {
+	var logged error
+	w := httptest.NewRecorder()
+
+	newEcho(&logged).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, w.Code)
+	}
+	if actual := w.Body.String(); strings.Contains(actual, "connection refused") {
+		t.Errorf("The response was not expected to contain internal details:\n%s", actual)
+	}
+	var sysErr *fault.SystemError
+	if !errors.As(logged, &sysErr) {
+		t.Fatal("System errors were expected to be logged.")
+	}
+	if actual := sysErr.Fields()["route"]; actual != "/orders/:id" {
+		t.Errorf(expectedFormat, "/orders/:id", actual)
+	}
+}
+
+func Test_HTTPErrorHandler_WithEchoError(t *testing.T) {
+	var logged error
+	w := httptest.NewRecorder()
+
+	newEcho(&logged).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf(expectedFormat, http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,78 @@
+// Package faultfiber integrates the fault package with the Fiber web framework.
+//
+// Fiber is built on top of fasthttp and therefore bypasses the net/http
+// based middleware of the httpfault package.
+package faultfiber
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+
+	"github.com/dusted-go/fault/fault"
+	"github.com/dusted-go/fault/httpfault"
+)
+
+// Config configures the ErrorHandler.
+type Config struct {
+	// Responder writes the error responses, which includes logging, localization,
+	// content negotiation and debug pages (see httpfault.Responder.WriteError).
+	// If nil then the httpfault.DefaultResponder will be used.
+	Responder *httpfault.Responder
+}
+
+// ErrorHandler returns a fiber.ErrorHandler which writes errors with the Responder,
+// so that Fiber apps render, log and publish errors in the same way as the net/http,
+// Gin and Echo integrations. User errors are rendered with all their codes and messages
+// and any other error as a sanitized response which doesn't leak internal details.
+//
+// The route pattern of Fiber is used unless the Responder has its own RoutePattern,
+// and the breadcrumbs are taken from the user context of the request (see fiber.Ctx.UserContext).
+//
+// Errors which have been raised by Fiber itself (*fiber.Error, e.g. for an unknown route)
+// are passed to Fiber's default error handler.
+//
+//	app := fiber.New(fiber.Config{
+//	   ErrorHandler: faultfiber.ErrorHandler(faultfiber.Config{}),
+//	})
+//	app.Use(faultfiber.Recover())
+func ErrorHandler(cfg Config) fiber.ErrorHandler {
+	rs := cfg.Responder
+	if rs == nil {
+		rs = httpfault.DefaultResponder
+	}
+	return func(c *fiber.Ctx, err error) error {
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			return fiber.DefaultErrorHandler(c, err)
+		}
+
+		withRoute := *rs
+		if withRoute.RoutePattern == nil {
+			route := c.Route().Path
+			withRoute.RoutePattern = func(*http.Request) string {
+				return route
+			}
+		}
+		ctx := c.UserContext()
+		return adaptor.HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			withRoute.WriteError(w, r.WithContext(ctx), err)
+		})(c)
+	}
+}
+
+// Recover returns a middleware which recovers panics into a SystemError,
+// pointing to the location where the panic occurred, and passes it on to
+// the error handler of the Fiber app.
+func Recover() fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = fault.FromPanic(v)
+			}
+		}()
+		return c.Next()
+	}
+}
@@ -0,0 +1,128 @@
+package faultfiber
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/dusted-go/fault/fault"
+	"github.com/dusted-go/fault/httpfault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func newApp(logged *error) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: ErrorHandler(Config{
+			Responder: &httpfault.Responder{
+				Logger: func(r *http.Request, err error) {
+					*logged = err
+				},
+				Catalog: fault.Catalog{
+					"de": {"INVALID_ID": "Ungültige ID."},
+				},
+			},
+		}),
+	})
+	app.Use(Recover())
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return fault.User("INVALID_ID", "Invalid ID.")
+	})
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		return fault.System("connection refused")
+	})
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("something went wrong")
+	})
+	return app
+}
+
+func test(t *testing.T, app *fiber.App, path string, headers ...string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func Test_ErrorHandler_WithUserError(t *testing.T) {
+	var logged error
+
+	status, body := test(t, newApp(&logged), "/users/x")
+
+	if status != http.StatusBadRequest {
+		t.Errorf(expectedFormat, http.StatusBadRequest, status)
+	}
+	expected := `{"message":"Bad Request","errors":[{"code":"INVALID_ID","message":"Invalid ID."}]}`
+	if actual := strings.TrimSpace(body); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_ErrorHandler_NegotiatesAndLocalizes(t *testing.T) {
+	var logged error
+
+	status, body := test(t, newApp(&logged), "/users/x", "Accept", "text/plain", "Accept-Language", "de")
+
+	if status != http.StatusBadRequest {
+		t.Errorf(expectedFormat, http.StatusBadRequest, status)
+	}
+	if !strings.Contains(body, "Ungültige ID.") || strings.HasPrefix(body, "{") {
+		t.Errorf(expectedFormat, "Ungültige ID. (plain text)", body)
+	}
+}
+
+func Test_ErrorHandler_WithSystemError(t *testing.T) {
+	var logged error
+
+	status, body := test(t, newApp(&logged), "/orders/1")
+
+	if status != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, status)
+	}
+	if strings.Contains(body, "connection refused") {
+		t.Errorf("The response was not expected to contain internal details:\n%s", body)
+	}
+	var sysErr *fault.SystemError
+	if !errors.As(logged, &sysErr) {
+		t.Fatal("System errors were expected to be logged.")
+	}
+	if actual := sysErr.Fields()["route"]; actual != "/orders/:id" {
+		t.Errorf(expectedFormat, "/orders/:id", actual)
+	}
+}
+
+func Test_Recover_WithPanic(t *testing.T) {
+	var logged error
+
+	status, _ := test(t, newApp(&logged), "/panic")
+
+	if status != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, status)
+	}
+	expected := "panic: something went wrong"
+	if logged == nil || logged.Error() != expected {
+		t.Errorf(expectedFormat, expected, logged)
+	}
+	var sysErr *fault.SystemError
+	if errors.As(logged, &sysErr) && !strings.Contains(strings.SplitN(sysErr.StackTrace(), "\n", 3)[1], "faultfiber_test.go") {
+		t.Errorf("The stack trace was expected to start at the panic:\n%s", sysErr.StackTrace())
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// definitions is the content of a definition file.
+// JSON files can be parsed as well, since JSON is a subset of YAML.
+type definitions struct {
+	Package string       `yaml:"package"`
+	Codes   []definition `yaml:"codes"`
+}
+
+type definition struct {
+	Code        string `yaml:"code"`
+	Message     string `yaml:"message"`
+	Description string `yaml:"description"`
+	Status      int    `yaml:"status"`
+	GRPCCode    uint32 `yaml:"grpc_code"`
+	DocURL      string `yaml:"doc_url"`
+}
+
+func parse(data []byte) (*definitions, error) {
+	defs := &definitions{}
+	if err := yaml.Unmarshal(data, defs); err != nil {
+		return nil, err
+	}
+	if len(defs.Codes) == 0 {
+		return nil, fmt.Errorf("no codes have been defined")
+	}
+	seen := map[string]bool{}
+	for i, def := range defs.Codes {
+		if def.Code == "" {
+			return nil, fmt.Errorf("code %d: the code must not be empty", i+1)
+		}
+		if def.Message == "" {
+			return nil, fmt.Errorf("code %s: the message must not be empty", def.Code)
+		}
+		name := identifier(def.Code)
+		if !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("code %s: the code can't be converted into a Go identifier", def.Code)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("code %s: the code has been defined more than once", def.Code)
+		}
+		seen[name] = true
+	}
+	return defs, nil
+}
+
+var fileTemplate = template.Must(template.New("file").
+	Funcs(template.FuncMap{"quote": strconv.Quote}).
+	Parse(`// Code generated by faultgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"fmt"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// Code is an error code of the {{.Package}} package.
+type Code fault.Code
+
+const (
+{{- range $i, $c := .Codes}}
+{{- if $i}}
+{{end}}
+	// Code{{$c.Name}} is the {{$c.Code}} error code.
+	{{- if $c.Description}}
+	// {{$c.Description}}
+	{{- end}}
+	Code{{$c.Name}} Code = {{quote $c.Code}}
+{{- end}}
+)
+
+// String returns the error code as a string.
+func (c Code) String() string {
+	return string(c)
+}
+
+// ParseCode returns the Code which corresponds to the string,
+// or an error if it isn't an error code of the {{.Package}} package.
+func ParseCode(s string) (Code, error) {
+	switch c := Code(s); c {
+	case {{range $i, $c := .Codes}}{{if $i}}, {{end}}Code{{$c.Name}}{{end}}:
+		return c, nil
+	}
+	return "", fmt.Errorf("{{.Package}}: unknown error code %q", s)
+}
+{{range .Codes}}
+// New{{.Name}} creates a new UserError with the {{.Code}} error code.
+{{- if .Formatted}}
+func New{{.Name}}(a ...interface{}) *fault.UserError {
+	return fault.Userf(fault.Code(Code{{.Name}}), {{quote .Message}}, a...)
+}
+{{- else}}
+func New{{.Name}}() *fault.UserError {
+	return fault.User(fault.Code(Code{{.Name}}), {{quote .Message}})
+}
+{{- end}}
+{{end}}
+func init() {
+	fault.RegisterCode(
+	{{- range .Codes}}
+		fault.CodeInfo{
+			Code: fault.Code(Code{{.Name}}),
+			{{- if .Description}}
+			Description: {{quote .Description}},
+			{{- end}}
+			{{- if .Status}}
+			Status: {{.Status}},
+			{{- end}}
+			{{- if .GRPCCode}}
+			GRPCCode: {{.GRPCCode}},
+			{{- end}}
+			{{- if .DocURL}}
+			DocURL: {{quote .DocURL}},
+			{{- end}}
+		},
+	{{- end}}
+	)
+}
+`))
+
+type templateCode struct {
+	definition
+	Name      string
+	Formatted bool
+}
+
+// generate returns the formatted source code for the error code definitions.
+func generate(pkg string, defs []definition) ([]byte, error) {
+	codes := make([]templateCode, len(defs))
+	for i, def := range defs {
+		def.Description = strings.Join(strings.Fields(def.Description), " ")
+		codes[i] = templateCode{
+			definition: def,
+			Name:       identifier(def.Code),
+			Formatted:  hasVerb(def.Message),
+		}
+	}
+	buf := &bytes.Buffer{}
+	err := fileTemplate.Execute(buf, map[string]interface{}{
+		"Package": pkg,
+		"Codes":   codes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// identifier converts an error code (e.g. MISSING_FIRST_NAME)
+// into an exported Go identifier (e.g. MissingFirstName).
+func identifier(code string) string {
+	sb := strings.Builder{}
+	upper := true
+	for _, r := range code {
+		switch {
+		case r == '_' || r == '-' || r == '.' || r == ' ':
+			upper = true
+		case upper:
+			sb.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			sb.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return sb.String()
+}
+
+// hasVerb reports whether the message contains a formatting verb.
+func hasVerb(msg string) bool {
+	for i := 0; i < len(msg)-1; i++ {
+		if msg[i] == '%' {
+			if msg[i+1] != '%' {
+				return true
+			}
+			i++
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func Test_Generate(t *testing.T) {
+	defs, err := parse([]byte(`
+codes:
+  - code: MISSING_FIRST_NAME
+    message: Please provide your first name.
+    description: The first name has not been provided.
+  - code: user-not-found
+    message: The user %s doesn't exist.
+    status: 404
+    grpc_code: 5
+    doc_url: https://example.com/errors#user-not-found
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := generate("errs", defs.Codes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := string(src)
+	for _, expected := range []string{
+		"// Code generated by faultgen. DO NOT EDIT.\n\npackage errs\n",
+		"\t// CodeMissingFirstName is the MISSING_FIRST_NAME error code.\n" +
+			"\t// The first name has not been provided.\n" +
+			"\tCodeMissingFirstName Code = \"MISSING_FIRST_NAME\"\n",
+		"type Code fault.Code\n",
+		"\tcase CodeMissingFirstName, CodeUserNotFound:\n\t\treturn c, nil\n",
+		"func NewMissingFirstName() *fault.UserError {\n" +
+			"\treturn fault.User(fault.Code(CodeMissingFirstName), \"Please provide your first name.\")\n}",
+		"func NewUserNotFound(a ...interface{}) *fault.UserError {\n" +
+			"\treturn fault.Userf(fault.Code(CodeUserNotFound), \"The user %s doesn't exist.\", a...)\n}",
+		"\t\t\tStatus:   404,\n\t\t\tGRPCCode: 5,\n\t\t\tDocURL:   \"https://example.com/errors#user-not-found\",\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
+
+func Test_Parse_WithJSON(t *testing.T) {
+	defs, err := parse([]byte(`{"package": "errs", "codes": [{"code": "A", "message": "a"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if defs.Package != "errs" || len(defs.Codes) != 1 || defs.Codes[0].Code != "A" {
+		t.Errorf("parse() returned an unexpected result: %+v", defs)
+	}
+}
+
+func Test_Parse_WithInvalidDefinitions(t *testing.T) {
+	tests := map[string]string{
+		"codes: [{message: a}]": "code 1: the code must not be empty",
+		"codes: [{code: A}]":    "code A: the message must not be empty",
+		"codes: [{code: A_B, message: a}, {code: a-b, message: b}]": "code a-b: the code has been defined more than once",
+		"codes: [{code: 1_A, message: a}]":                          "code 1_A: the code can't be converted into a Go identifier",
+		"codes: []":                                                 "no codes have been defined",
+	}
+	for data, expected := range tests {
+		_, err := parse([]byte(data))
+		if err == nil || err.Error() != expected {
+			t.Errorf(expectedFormat, expected, err)
+		}
+	}
+}
+
+func Test_HasVerb(t *testing.T) {
+	tests := map[string]bool{
+		"a":      false,
+		"100%":   false,
+		"100%% ": false,
+		"a %s":   true,
+		"%d%%":   true,
+	}
+	for msg, expected := range tests {
+		if actual := hasVerb(msg); actual != expected {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/dusted-go/fault/faultgen
+
+go 1.19
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,78 @@
+// Command faultgen generates typed error code constants, UserError constructors
+// and code registry registrations from a YAML or JSON list of error codes.
+//
+// It is meant to be invoked via go:generate:
+//
+//	//go:generate go run github.com/dusted-go/fault/faultgen -in errors.yaml
+//
+// The definition file lists the error codes with their metadata:
+//
+//	codes:
+//	   - code: MISSING_FIRST_NAME
+//	     message: Please provide your first name.
+//	     description: The first name has not been provided.
+//	   - code: USER_NOT_FOUND
+//	     message: The user %s doesn't exist.
+//	     status: 404
+//	     grpc_code: 5
+//	     doc_url: https://example.com/errors#user-not-found
+//
+// For each code a constant (CodeMissingFirstName) and a constructor
+// (NewMissingFirstName) will be generated. Constructors of messages which
+// contain formatting verbs accept the formatting arguments.
+//
+// The constants are of the generated Code type, which has a String method
+// and is accompanied by a ParseCode function. Since the constants are the only
+// values of the type, linters such as exhaustive can check that a switch
+// statement over a Code handles every error code.
+//
+// The grpc_code is the numeric value of the gRPC status code (e.g. 5 for codes.NotFound).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	in := flag.String("in", "", "path to the YAML or JSON file which defines the error codes")
+	out := flag.String("out", "", "path to the generated Go file (default <in>_gen.go)")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated Go file")
+	flag.Parse()
+
+	if err := run(*in, *out, *pkg); err != nil {
+		fmt.Fprintf(os.Stderr, "faultgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out, pkg string) error {
+	if in == "" {
+		return fmt.Errorf("the -in flag is required")
+	}
+	if out == "" {
+		out = strings.TrimSuffix(in, filepath.Ext(in)) + "_gen.go"
+	}
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	defs, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", in, err)
+	}
+	if defs.Package != "" {
+		pkg = defs.Package
+	}
+	if pkg == "" {
+		return fmt.Errorf("the package name must be set via the -package flag or the definition file")
+	}
+	src, err := generate(pkg, defs.Codes)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0o600)
+}
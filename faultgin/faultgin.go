@@ -0,0 +1,65 @@
+// Package faultgin integrates the fault package with the Gin web framework.
+package faultgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dusted-go/fault/fault"
+	"github.com/dusted-go/fault/httpfault"
+)
+
+// ErrorHandler returns a Gin middleware which writes the last error of the
+// Gin context as a HTTP response and recovers panics into a SystemError.
+//
+// The response is written with the given httpfault.Responder, which means that
+// user errors and system errors get mapped to the same response bodies and status
+// codes as in any other handler built on the httpfault package.
+// If the Responder is nil then the httpfault.DefaultResponder will be used.
+func ErrorHandler(rs *httpfault.Responder) gin.HandlerFunc {
+	if rs == nil {
+		rs = httpfault.DefaultResponder
+	}
+	return func(c *gin.Context) {
+		defer func() {
+			if v := recover(); v != nil {
+				// nolint: errorlint // Sentinel panic value must be compared directly:
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				c.Abort()
+				writeError(c, rs, fault.FromPanic(v))
+			}
+		}()
+
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		writeError(c, rs, c.Errors.Last().Err)
+	}
+}
+
+// Error attaches the error to the Gin context and aborts the request.
+// It can be used in place of c.Error(err) inside handlers:
+//
+//	if err != nil {
+//	   faultgin.Error(c, err)
+//	   return
+//	}
+func Error(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+func writeError(c *gin.Context, rs *httpfault.Responder, err error) {
+	withRoute := *rs
+	if withRoute.RoutePattern == nil {
+		withRoute.RoutePattern = func(*http.Request) string {
+			return c.FullPath()
+		}
+	}
+	withRoute.WriteError(c.Writer, c.Request, err)
+}
@@ -0,0 +1,89 @@
+package faultgin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dusted-go/fault/fault"
+	"github.com/dusted-go/fault/httpfault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func newRouter(logged *error) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler(&httpfault.Responder{
+		Logger: func(r *http.Request, err error) {
+			*logged = err
+		},
+	}))
+	router.GET("/users/:id", func(c *gin.Context) {
+		Error(c, fault.User("INVALID_ID", "Invalid ID."))
+	})
+	router.GET("/orders/:id", func(c *gin.Context) {
+		Error(c, fault.System("connection refused"))
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("something went wrong")
+	})
+	return router
+}
+
+func Test_ErrorHandler_WithUserError(t *testing.T) {
+	var logged error
+	w := httptest.NewRecorder()
+
+	newRouter(&logged).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/x", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf(expectedFormat, http.StatusBadRequest, w.Code)
+	}
+	expected := `{"message":"Bad Request","errors":[{"code":"INVALID_ID","message":"Invalid ID."}]}`
+	if actual := strings.TrimSpace(w.Body.String()); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if logged != nil {
+		t.Error("User errors were not expected to be logged.")
+	}
+}
+
+func Test_ErrorHandler_WithSystemError(t *testing.T) {
+	var logged error
+	w := httptest.NewRecorder()
+
+	newRouter(&logged).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, w.Code)
+	}
+	var sysErr *fault.SystemError
+	if !errors.As(logged, &sysErr) {
+		t.Fatal("System errors were expected to be logged.")
+	}
+	if actual := sysErr.Fields()["route"]; actual != "/orders/:id" {
+		t.Errorf(expectedFormat, "/orders/:id", actual)
+	}
+}
+
+func Test_ErrorHandler_WithPanic(t *testing.T) {
+	var logged error
+	w := httptest.NewRecorder()
+
+	newRouter(&logged).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, w.Code)
+	}
+	expected := "panic: something went wrong"
+	if logged == nil || logged.Error() != expected {
+		t.Errorf(expectedFormat, expected, logged)
+	}
+}
@@ -0,0 +1,119 @@
+// Package faultgql integrates the fault package with GraphQL servers
+// which are built on top of github.com/vektah/gqlparser, such as gqlgen.
+package faultgql
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	// InternalErrorMessage is the message of a masked system error.
+	InternalErrorMessage = "internal server error"
+
+	// InternalErrorCode is the extension code of a masked system error.
+	InternalErrorCode = "INTERNAL_SERVER_ERROR"
+)
+
+// Presenter converts errors of GraphQL resolvers into GraphQL errors.
+//
+// User errors get converted into a GraphQL error which exposes all error
+// codes and messages as extensions. Any other error gets masked into a
+// generic internal server error and its internal details get logged instead.
+//
+// The Present method has the signature of gqlgen's graphql.ErrorPresenterFunc:
+//
+//	p := &faultgql.Presenter{Path: graphql.GetPath}
+//	srv.SetErrorPresenter(p.Present)
+type Presenter struct {
+	// Path returns the path of the field which is being resolved.
+	// With gqlgen this is graphql.GetPath.
+	Path func(ctx context.Context) ast.Path
+
+	// Logger receives all errors which have been masked, which are published to the
+	// fault.DefaultBus as well. If nil then the error including its stack trace will be
+	// written to the standard logger.
+	Logger func(ctx context.Context, err error)
+}
+
+// Present converts the error into a GraphQL error.
+func (p *Presenter) Present(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := Convert(err)
+	if gqlErr.Path == nil && p.Path != nil {
+		gqlErr.Path = p.Path(ctx)
+	}
+	if gqlErr.Message == InternalErrorMessage {
+		p.log(ctx, err)
+		fault.Publish(err)
+	}
+	return gqlErr
+}
+
+func (p *Presenter) log(ctx context.Context, err error) {
+	if p.Logger != nil {
+		p.Logger(ctx, err)
+		return
+	}
+	log.Printf("%+v", err)
+}
+
+// Convert converts an error into a GraphQL error.
+//
+// A UserError gets converted into a GraphQL error with the friendly error message
+// and the error codes as extensions:
+//
+//	{
+//	   "message": "Please provide your first name",
+//	   "extensions": {
+//	      "code": "MISSING_FIRST_NAME",
+//	      "errors": [
+//	         { "code": "MISSING_FIRST_NAME", "message": "Please provide your first name" }
+//	      ]
+//	   }
+//	}
+//
+// A GraphQL error which doesn't wrap a SystemError (e.g. a query validation error) is returned as is.
+// Any other error gets masked into a generic internal server error.
+func Convert(err error) *gqlerror.Error {
+	var gqlErr *gqlerror.Error
+	hasGQLErr := errors.As(err, &gqlErr)
+
+	result := &gqlerror.Error{Err: err}
+	if hasGQLErr {
+		result.Path = gqlErr.Path
+		result.Locations = gqlErr.Locations
+	}
+
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		userErr = userErr.Scrub()
+		msgs := userErr.ErrorMessages()
+		codes := userErr.Codes()
+		entries := make([]map[string]interface{}, len(codes))
+		for i, code := range codes {
+			entries[i] = map[string]interface{}{"code": code.String(), "message": msgs[i]}
+		}
+		result.Message = userErr.FriendlyError()
+		result.Extensions = map[string]interface{}{"errors": entries}
+		if len(codes) > 0 {
+			result.Extensions["code"] = codes[0]
+		}
+		return result
+	}
+
+	var sysErr *fault.SystemError
+	// nolint: errorlint // Only a GraphQL error at the top of the chain is passed through:
+	if topErr, ok := err.(*gqlerror.Error); ok && !errors.As(err, &sysErr) {
+		return topErr
+	}
+
+	result.Message = InternalErrorMessage
+	result.Extensions = map[string]interface{}{"code": InternalErrorCode}
+	return result
+}
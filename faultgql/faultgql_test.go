@@ -0,0 +1,78 @@
+package faultgql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func Test_Present_WithUserError(t *testing.T) {
+	var logged error
+	p := &Presenter{
+		Path: func(ctx context.Context) ast.Path {
+			return ast.Path{ast.PathName("user")}
+		},
+		Logger: func(ctx context.Context, err error) {
+			logged = err
+		},
+	}
+	userErr := fault.User("MISSING_FIRST_NAME", "Please provide your first name.")
+
+	gqlErr := p.Present(context.Background(), fault.SystemWrap(userErr, "validating user"))
+
+	data, err := json.Marshal(gqlErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"message":"Please provide your first name.","path":["user"],"extensions":{` +
+		`"code":"MISSING_FIRST_NAME",` +
+		`"errors":[{"code":"MISSING_FIRST_NAME","message":"Please provide your first name."}]}}`
+	if actual := string(data); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if logged != nil {
+		t.Error("User errors were not expected to be logged.")
+	}
+}
+
+func Test_Present_WithSystemError(t *testing.T) {
+	var logged error
+	p := &Presenter{Logger: func(ctx context.Context, err error) {
+		logged = err
+	}}
+	sysErr := fault.System("connection refused")
+
+	gqlErr := p.Present(context.Background(), sysErr)
+
+	data, err := json.Marshal(gqlErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"message":"internal server error","extensions":{"code":"INTERNAL_SERVER_ERROR"}}`
+	if actual := string(data); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if !errors.Is(logged, sysErr) {
+		t.Error("System errors were expected to be logged.")
+	}
+}
+
+func Test_Convert_WithGraphQLError(t *testing.T) {
+	err := gqlerror.Errorf("Cannot query field \"foo\" on type \"Query\".")
+
+	actual := Convert(err)
+
+	if actual != err {
+		t.Errorf(expectedFormat, err, actual)
+	}
+}
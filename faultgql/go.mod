@@ -0,0 +1,10 @@
+module github.com/dusted-go/fault/faultgql
+
+go 1.19
+
+require (
+	github.com/dusted-go/fault v1.5.0
+	github.com/vektah/gqlparser/v2 v2.5.16
+)
+
+replace github.com/dusted-go/fault => ../
@@ -0,0 +1,148 @@
+package faultgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// UnaryClientInterceptor returns a unary client interceptor which converts
+// returned gRPC status errors back into faults. See FromError for more details.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return FromError(method, invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor returns a stream client interceptor which converts
+// returned gRPC status errors back into faults. See FromError for more details.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromError(method, err)
+		}
+		return &clientStream{ClientStream: cs, method: method}, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	method string
+}
+
+func (cs *clientStream) SendMsg(m interface{}) error {
+	return cs.convert(cs.ClientStream.SendMsg(m))
+}
+
+func (cs *clientStream) RecvMsg(m interface{}) error {
+	return cs.convert(cs.ClientStream.RecvMsg(m))
+}
+
+func (cs *clientStream) convert(err error) error {
+	if errors.Is(err, io.EOF) {
+		return err
+	}
+	return FromError(cs.method, err)
+}
+
+// FromError converts an error which has been returned by a gRPC call back into a fault.
+//
+// A status with a client-side code and a errdetails.BadRequest detail (as created by
+// ToStatus) gets converted into a UserError with the same codes and messages.
+// Any other status gets converted into a SystemError which wraps the status error
+// and has the kind which corresponds to the status code. A errdetails.RetryInfo detail
+// gets converted into a retry hint.
+//
+// Errors which are not a gRPC status are returned as is.
+func FromError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var badRequest *errdetails.BadRequest
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			badRequest = d
+		case *errdetails.RetryInfo:
+			retryInfo = d
+		}
+	}
+
+	if !isServerSide(st.Code()) && badRequest != nil && len(badRequest.FieldViolations) > 0 {
+		var userErr *fault.UserError
+		for _, v := range badRequest.FieldViolations {
+			if userErr == nil {
+				userErr = fault.User(fault.Code(v.Field), v.Description)
+			} else {
+				userErr.Add(fault.Code(v.Field), v.Description)
+			}
+		}
+		if retryInfo != nil {
+			userErr.WithRetryAfter(retryInfo.RetryDelay.AsDuration())
+		}
+		return userErr
+	}
+
+	sysErr := fault.SystemWrap(err, fmt.Sprintf("calling %s", method)).WithKind(KindOf(st.Code()))
+	if retryInfo != nil {
+		sysErr.WithRetryAfter(retryInfo.RetryDelay.AsDuration())
+	}
+	return sysErr
+}
+
+// KindOf returns the kind of a SystemError which corresponds to the gRPC code.
+func KindOf(code codes.Code) fault.Kind {
+	switch code {
+	case codes.Canceled:
+		return fault.Canceled
+	case codes.DeadlineExceeded:
+		return fault.Timeout
+	case codes.Unavailable:
+		return fault.Unavailable
+	case codes.NotFound:
+		return fault.NotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return fault.Conflict
+	case codes.PermissionDenied:
+		return fault.PermissionDenied
+	case codes.Unauthenticated:
+		return fault.Unauthenticated
+	case codes.ResourceExhausted:
+		return fault.ResourceExhausted
+	case codes.InvalidArgument, codes.OutOfRange:
+		return fault.InvalidArgument
+	case codes.Unimplemented:
+		return fault.Unimplemented
+	default:
+		return fault.Internal
+	}
+}
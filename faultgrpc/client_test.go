@@ -0,0 +1,99 @@
+package faultgrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func call(serverErr error) error {
+	server := UnaryServerInterceptor(WithLogger(func(ctx context.Context, err error) {}))
+	client := UnaryClientInterceptor()
+	return client(
+		context.Background(),
+		"/users.Users/Get",
+		nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			_, err := server(ctx, req, &grpc.UnaryServerInfo{FullMethod: method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					return nil, serverErr
+				})
+			return err
+		})
+}
+
+func Test_UnaryClientInterceptor_WithUserError(t *testing.T) {
+	userErr := fault.User("MISSING_FIRST_NAME", "Please provide your first name.")
+	userErr.Add("MISSING_LAST_NAME", "Please provide your last name.")
+
+	err := call(userErr)
+
+	var actual *fault.UserError
+	if !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.UserError", err)
+	}
+	if actual.Error() != userErr.Error() {
+		t.Errorf(expectedFormat, userErr.Error(), actual.Error())
+	}
+	if !actual.HasCode("MISSING_LAST_NAME") {
+		t.Error("The converted user error was expected to have the code MISSING_LAST_NAME.")
+	}
+}
+
+func Test_UnaryClientInterceptor_WithRegisteredGRPCCode(t *testing.T) {
+	fault.RegisterCode(fault.CodeInfo{Code: "TEST_GRPC_ORDER_NOT_FOUND", GRPCCode: uint32(codes.NotFound)})
+	userErr := fault.User("TEST_GRPC_ORDER_NOT_FOUND", "The order doesn't exist.")
+
+	err := call(userErr)
+
+	var actual *fault.UserError
+	if !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.UserError", err)
+	}
+	if !actual.HasCode("TEST_GRPC_ORDER_NOT_FOUND") {
+		t.Error("The converted user error was expected to have the code TEST_GRPC_ORDER_NOT_FOUND.")
+	}
+}
+
+func Test_UnaryClientInterceptor_WithSystemError(t *testing.T) {
+	serverErr := fault.System("connection refused").
+		WithKind(fault.Unavailable).
+		WithRetryAfter(time.Second)
+
+	err := call(serverErr)
+
+	var actual *fault.SystemError
+	if !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.SystemError", err)
+	}
+	if kind := actual.Kind(); kind != fault.Unavailable {
+		t.Errorf(expectedFormat, fault.Unavailable, kind)
+	}
+	if retryAfter, _ := actual.RetryAfter(); retryAfter != time.Second {
+		t.Errorf(expectedFormat, time.Second, retryAfter)
+	}
+	if code := status.Code(err); code != codes.Unavailable {
+		t.Errorf(expectedFormat, codes.Unavailable, code)
+	}
+	expected := "calling /users.Users/Get"
+	if msg := actual.Error(); msg[:len(expected)] != expected {
+		t.Errorf(expectedFormat, expected, msg)
+	}
+}
+
+func Test_FromError_WithNonStatusError(t *testing.T) {
+	err := errors.New("foo bar")
+
+	actual := FromError("/users.Users/Get", err)
+
+	if actual != err {
+		t.Errorf(expectedFormat, err, actual)
+	}
+}
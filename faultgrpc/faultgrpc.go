@@ -0,0 +1,234 @@
+// Package faultgrpc integrates the fault package with gRPC.
+package faultgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/dusted-go/fault/fault"
+	"github.com/dusted-go/fault/stack"
+)
+
+// Option configures the server interceptors.
+type Option func(*options)
+
+type options struct {
+	logger func(ctx context.Context, err error)
+	debug  bool
+}
+
+// WithLogger sets the function which receives the internal details of all errors
+// which have been converted into a server side status (e.g. codes.Internal).
+// By default the error including its stack trace will be written to the standard logger.
+// The errors are published to the fault.DefaultBus as well.
+func WithLogger(logger func(ctx context.Context, err error)) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithDebug attaches a errdetails.DebugInfo detail to every server side status,
+// which contains the message chain and the stack frames of the original error.
+//
+// WithDebug exposes internal details to the client and must only be enabled
+// in development, test or staging environments. The DebugInfo detail is attached
+// as well if the DebugAttachments setting of the fault.Profile is enabled.
+func WithDebug() Option {
+	return func(o *options) {
+		o.debug = true
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		logger: func(ctx context.Context, err error) {
+			log.Printf("%+v", err)
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// UnaryServerInterceptor returns a unary server interceptor which recovers panics
+// into a SystemError and converts all returned errors into a gRPC status.
+// See ToStatus for more details on the conversion.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = o.convert(ctx, fault.FromPanic(v))
+			}
+		}()
+		resp, err = handler(ctx, req)
+		if err != nil {
+			return resp, o.convert(ctx, err)
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor which recovers panics
+// into a SystemError and converts all returned errors into a gRPC status.
+// See ToStatus for more details on the conversion.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = o.convert(ss.Context(), fault.FromPanic(v))
+			}
+		}()
+		if err := handler(srv, ss); err != nil {
+			return o.convert(ss.Context(), err)
+		}
+		return nil
+	}
+}
+
+func (o *options) convert(ctx context.Context, err error) error {
+	st := ToStatus(err)
+	if isServerSide(st.Code()) {
+		o.logger(ctx, err)
+		fault.Publish(err)
+		if o.debug || fault.CurrentProfile().DebugAttachments {
+			st = withDetails(st, debugInfo(err))
+		}
+	}
+	return st.Err()
+}
+
+// debugInfo returns the message chain and the stack frames of the
+// outermost SystemError of the error's chain.
+func debugInfo(err error) *errdetails.DebugInfo {
+	info := &errdetails.DebugInfo{Detail: fault.Scrub(err.Error())}
+	var sysErr *fault.SystemError
+	if errors.As(err, &sysErr) {
+		for _, f := range sysErr.Trace().Frames() {
+			info.StackEntries = append(info.StackEntries,
+				fmt.Sprintf("%s (%s)", stack.FuncName(f.Function), stack.Location(f)))
+		}
+	}
+	return info
+}
+
+// ToStatus converts an error into a gRPC status.
+//
+// A UserError gets converted into a status with the friendly error message and a
+// errdetails.BadRequest detail, which contains a field violation for each user error
+// (the field being the error code and the description being the message). The status
+// has the GRPCCode of the first user error code which has one in the fault code registry
+// (see fault.CodeInfo), or codes.InvalidArgument otherwise.
+//
+// A SystemError gets converted into a status with the code of its kind and a generic
+// message which doesn't leak any internal details. If the SystemError contains a retry hint
+// then the status contains a errdetails.RetryInfo detail.
+//
+// An error which is a gRPC status already (and doesn't wrap a fault) is returned as is.
+// Any other error gets converted into a codes.Internal status.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		userErr = userErr.Scrub()
+		msgs := userErr.ErrorMessages()
+		badRequest := &errdetails.BadRequest{}
+		for i, code := range userErr.Codes() {
+			badRequest.FieldViolations = append(badRequest.FieldViolations,
+				&errdetails.BadRequest_FieldViolation{Field: code.String(), Description: msgs[i]})
+		}
+		return withDetails(status.New(userCode(userErr), userErr.FriendlyError()), badRequest)
+	}
+
+	var sysErr *fault.SystemError
+	if !errors.As(err, &sysErr) {
+		// nolint: errorlint // Only a status at the top of the chain is passed through:
+		if st, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+			return st.GRPCStatus()
+		}
+	}
+
+	code := CodeOf(fault.KindOf(err))
+	st := status.New(code, code.String())
+	if retryAfter, ok := fault.RetryAfter(err); ok {
+		st = withDetails(st, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	}
+	return st
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// userCode returns the gRPC code of the first user error code
+// which has one in the fault code registry.
+func userCode(userErr *fault.UserError) codes.Code {
+	for _, code := range userErr.Codes() {
+		if info, ok := fault.LookupCode(code); ok && info.GRPCCode != 0 {
+			return codes.Code(info.GRPCCode)
+		}
+	}
+	return codes.InvalidArgument
+}
+
+var kindCodes = map[fault.Kind]codes.Code{
+	fault.Internal:          codes.Internal,
+	fault.Canceled:          codes.Canceled,
+	fault.Timeout:           codes.DeadlineExceeded,
+	fault.Unavailable:       codes.Unavailable,
+	fault.NotFound:          codes.NotFound,
+	fault.Conflict:          codes.Aborted,
+	fault.PermissionDenied:  codes.PermissionDenied,
+	fault.Unauthenticated:   codes.Unauthenticated,
+	fault.ResourceExhausted: codes.ResourceExhausted,
+	fault.InvalidArgument:   codes.InvalidArgument,
+	fault.Unimplemented:     codes.Unimplemented,
+}
+
+// CodeOf returns the gRPC code which corresponds to the kind of a SystemError.
+func CodeOf(kind fault.Kind) codes.Code {
+	if code, ok := kindCodes[kind]; ok {
+		return code
+	}
+	return codes.Internal
+}
+
+// isServerSide reports whether the code indicates a fault of the server
+// rather than of the client, similar to a 5xx status code in HTTP.
+func isServerSide(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented,
+		codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,178 @@
+package faultgrpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func invoke(t *testing.T, handlerErr error, handlerPanic interface{}) (error, error) {
+	t.Helper()
+	var logged error
+	interceptor := UnaryServerInterceptor(WithLogger(func(ctx context.Context, err error) {
+		logged = err
+	}))
+	_, err := interceptor(
+		context.Background(),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: "/users.Users/Get"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			if handlerPanic != nil {
+				panic(handlerPanic)
+			}
+			return nil, handlerErr
+		})
+	return err, logged
+}
+
+func Test_UnaryServerInterceptor_WithUserError(t *testing.T) {
+	userErr := fault.User("MISSING_FIRST_NAME", "Please provide your first name.")
+	userErr.Add("MISSING_LAST_NAME", "Please provide your last name.")
+
+	err, logged := invoke(t, userErr, nil)
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf(expectedFormat, codes.InvalidArgument, st.Code())
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf(expectedFormat, 1, len(st.Details()))
+	}
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok || len(badRequest.FieldViolations) != 2 {
+		t.Fatalf(expectedFormat, "BadRequest with 2 field violations", st.Details()[0])
+	}
+	if actual := badRequest.FieldViolations[1].Field; actual != "MISSING_LAST_NAME" {
+		t.Errorf(expectedFormat, "MISSING_LAST_NAME", actual)
+	}
+	if logged != nil {
+		t.Error("User errors were not expected to be logged.")
+	}
+}
+
+func Test_ToStatus_WithRegisteredGRPCCode(t *testing.T) {
+	fault.RegisterCode(fault.CodeInfo{Code: "TEST_GRPC_USER_NOT_FOUND", GRPCCode: uint32(codes.NotFound)})
+	userErr := fault.User("MISSING_FIRST_NAME", "Please provide your first name.")
+	userErr.Add("TEST_GRPC_USER_NOT_FOUND", "The user doesn't exist.")
+
+	if actual := ToStatus(userErr).Code(); actual != codes.NotFound {
+		t.Errorf(expectedFormat, codes.NotFound, actual)
+	}
+}
+
+func Test_UnaryServerInterceptor_WithSystemError(t *testing.T) {
+	sysErr := fault.System("connection refused").
+		WithKind(fault.Unavailable).
+		WithRetryAfter(2 * time.Second)
+
+	err, logged := invoke(t, fault.SystemWrap(sysErr, "connecting to db"), nil)
+
+	st := status.Convert(err)
+	if st.Code() != codes.Unavailable {
+		t.Errorf(expectedFormat, codes.Unavailable, st.Code())
+	}
+	if st.Message() != codes.Unavailable.String() {
+		t.Errorf(expectedFormat, codes.Unavailable.String(), st.Message())
+	}
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	if !ok || retryInfo.RetryDelay.AsDuration() != 2*time.Second {
+		t.Errorf(expectedFormat, "RetryInfo of 2s", st.Details())
+	}
+	if !errors.Is(logged, sysErr) {
+		t.Error("System errors were expected to be logged.")
+	}
+}
+
+func Test_UnaryServerInterceptor_WithPanic(t *testing.T) {
+	err, logged := invoke(t, nil, "something went wrong")
+
+	if code := status.Code(err); code != codes.Internal {
+		t.Errorf(expectedFormat, codes.Internal, code)
+	}
+	expected := "panic: something went wrong"
+	if logged == nil || logged.Error() != expected {
+		t.Errorf(expectedFormat, expected, logged)
+	}
+}
+
+func Test_UnaryServerInterceptor_WithStatusError(t *testing.T) {
+	statusErr := status.Error(codes.FailedPrecondition, "not ready")
+
+	err, logged := invoke(t, statusErr, nil)
+
+	if err.Error() != statusErr.Error() {
+		t.Errorf(expectedFormat, statusErr, err)
+	}
+	if logged != nil {
+		t.Error("Client side status errors were not expected to be logged.")
+	}
+}
+
+func Test_UnaryServerInterceptor_WithDebug(t *testing.T) {
+	interceptor := UnaryServerInterceptor(
+		WithLogger(func(ctx context.Context, err error) {}),
+		WithDebug())
+	_, err := interceptor(
+		context.Background(),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: "/users.Users/Get"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, fault.SystemWrap(errors.New("connection refused"), "failed to load user")
+		})
+
+	st := status.Convert(err)
+	if len(st.Details()) != 1 {
+		t.Fatalf(expectedFormat, 1, len(st.Details()))
+	}
+	info, ok := st.Details()[0].(*errdetails.DebugInfo)
+	if !ok {
+		t.Fatalf(expectedFormat, "*errdetails.DebugInfo", st.Details()[0])
+	}
+	expected := "failed to load user\n   connection refused"
+	if info.Detail != expected {
+		t.Errorf(expectedFormat, expected, info.Detail)
+	}
+	if len(info.StackEntries) == 0 ||
+		!strings.HasPrefix(info.StackEntries[0], "faultgrpc.Test_UnaryServerInterceptor_WithDebug (") {
+		t.Errorf(expectedFormat, "faultgrpc.Test_UnaryServerInterceptor_WithDebug (...)", info.StackEntries)
+	}
+}
+
+func Test_UnaryServerInterceptor_WithoutDebug(t *testing.T) {
+	err, _ := invoke(t, fault.System("connection refused"), nil)
+
+	for _, detail := range status.Convert(err).Details() {
+		if _, ok := detail.(*errdetails.DebugInfo); ok {
+			t.Error("The status was not expected to contain debug information.")
+		}
+	}
+}
+
+func Test_UnaryServerInterceptor_PublishesServerSideErrors(t *testing.T) {
+	var published []error
+	sub := fault.Subscribe(func(err error) {
+		published = append(published, err)
+	})
+	sysErr := fault.System("db down").WithKind(fault.Unavailable).WithSeverity(fault.SeverityCritical)
+
+	_, _ = invoke(t, sysErr, nil)
+	_, _ = invoke(t, fault.User("A", "a"), nil)
+	sub.Close()
+
+	if len(published) != 1 || published[0] != sysErr {
+		t.Errorf(expectedFormat, sysErr, published)
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/dusted-go/fault/faultgrpc
+
+go 1.21
+
+require (
+	github.com/dusted-go/fault v1.5.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237
+	google.golang.org/grpc v1.64.0
+	google.golang.org/protobuf v1.34.1
+)
+
+require (
+	golang.org/x/net v0.22.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/dusted-go/fault => ../
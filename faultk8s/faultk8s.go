@@ -0,0 +1,79 @@
+// Package faultk8s classifies Kubernetes API errors as faults.
+package faultk8s
+
+import (
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// Classify returns the kind and retryability of a Kubernetes API error.
+// It reports false if the error isn't a classified API error.
+//
+// Conflicts are classified as retryable, since controllers are expected to
+// re-read the object and retry the update. Forbidden, unauthorized and invalid
+// requests are classified as Internal, since they are caused by the service
+// account or the requests of the service itself rather than by its clients.
+func Classify(err error) (kind fault.Kind, retryable bool, ok bool) {
+	switch {
+	case err == nil:
+		return "", false, false
+	case apierrors.IsNotFound(err):
+		return fault.NotFound, false, true
+	case apierrors.IsConflict(err):
+		return fault.Conflict, true, true
+	case apierrors.IsAlreadyExists(err):
+		return fault.Conflict, false, true
+	case apierrors.IsTooManyRequests(err):
+		return fault.ResourceExhausted, true, true
+	case apierrors.IsServerTimeout(err), apierrors.IsTimeout(err):
+		return fault.Timeout, true, true
+	case apierrors.IsServiceUnavailable(err), apierrors.IsInternalError(err):
+		return fault.Unavailable, true, true
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err),
+		apierrors.IsBadRequest(err), apierrors.IsInvalid(err):
+		return fault.Internal, false, true
+	default:
+		return "", false, false
+	}
+}
+
+// adapter classifies Kubernetes API errors and attaches their reason and retry hint.
+var adapter = fault.Adapter{Classify: Classify, Annotate: annotate}
+
+// Wrap wraps an error of a Kubernetes client (e.g. of client-go or controller-runtime)
+// into a SystemError whose kind and retryability tell a reconciler whether to requeue
+// the object. The reason of the API status (e.g. AlreadyExists) is attached as the
+// reason field, and the delay which the API server asks for (e.g. when throttling)
+// becomes the retry hint. Errors without an API status are wrapped without a kind,
+// and a nil error results in an Invariant fault (see fault.SystemWrap).
+//
+//	Example:
+//	   if err := r.Client.Update(ctx, obj); err != nil {
+//	      return faultk8s.Wrap(err, "failed to update object")
+//	   }
+func Wrap(err error, msg string) *fault.SystemError {
+	return adapter.WrapSkip(err, msg, 1)
+}
+
+// Rule is a fault.Rule which translates the API errors returned by Kubernetes clients
+// into SystemErrors with the kind, reason and retry hint of Wrap, whose message is
+// the one of the API status. Errors with an unknown reason are left to the other rules.
+//
+//	Example:
+//	   fault.Register(faultk8s.Rule)
+func Rule(err error) error {
+	return adapter.Rule(err)
+}
+
+// annotate attaches the reason and the retry hint of the API status.
+func annotate(sysErr *fault.SystemError, err error) {
+	if reason := apierrors.ReasonForError(err); reason != "" {
+		sysErr.WithField("reason", string(reason))
+	}
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		sysErr.WithRetryAfter(time.Duration(seconds) * time.Second)
+	}
+}
@@ -0,0 +1,88 @@
+package faultk8s
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+var pods = schema.GroupResource{Resource: "pods"}
+
+func Test_Classify(t *testing.T) {
+	tests := []struct {
+		err       error
+		kind      fault.Kind
+		retryable bool
+		ok        bool
+	}{
+		{nil, "", false, false},
+		{errors.New("a"), "", false, false},
+		{apierrors.NewNotFound(pods, "a"), fault.NotFound, false, true},
+		{fmt.Errorf("a: %w", apierrors.NewConflict(pods, "a", errors.New("b"))), fault.Conflict, true, true},
+		{apierrors.NewAlreadyExists(pods, "a"), fault.Conflict, false, true},
+		{apierrors.NewTooManyRequests("a", 1), fault.ResourceExhausted, true, true},
+		{apierrors.NewTimeoutError("a", 1), fault.Timeout, true, true},
+		{apierrors.NewServiceUnavailable("a"), fault.Unavailable, true, true},
+		{apierrors.NewForbidden(pods, "a", errors.New("b")), fault.Internal, false, true},
+		{apierrors.NewUnauthorized("a"), fault.Internal, false, true},
+		{apierrors.NewBadRequest("a"), fault.Internal, false, true},
+	}
+	for _, test := range tests {
+		kind, retryable, ok := Classify(test.err)
+		actual := fmt.Sprint(kind, retryable, ok)
+		expected := fmt.Sprint(test.kind, test.retryable, test.ok)
+		if actual != expected {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
+
+func Test_Wrap_WithTooManyRequests(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	sysErr := Wrap(apierrors.NewTooManyRequests("slow down", 5), "failed to list pods")
+
+	if sysErr.Kind() != fault.ResourceExhausted {
+		t.Errorf(expectedFormat, fault.ResourceExhausted, sysErr.Kind())
+	}
+	if retryAfter, ok := sysErr.RetryAfter(); !ok || retryAfter != 5*time.Second {
+		t.Errorf(expectedFormat, 5*time.Second, retryAfter)
+	}
+	if actual := sysErr.Fields()["reason"]; actual != "TooManyRequests" {
+		t.Errorf(expectedFormat, "TooManyRequests", actual)
+	}
+	if expected := fmt.Sprintf("%s:%d: failed to list pods", file, line+1); !strings.HasSuffix(sysErr.StackTrace(), expected) {
+		t.Errorf(expectedFormat, expected, sysErr.StackTrace())
+	}
+	if actual := Wrap(nil, "a").Kind(); actual != fault.Bug {
+		t.Errorf(expectedFormat, fault.Bug, actual)
+	}
+}
+
+func Test_Rule(t *testing.T) {
+	tr := &fault.Translator{}
+	tr.Register(Rule)
+
+	translated := tr.Translate(apierrors.NewNotFound(pods, "a"))
+	if actual := fault.KindOf(translated); actual != fault.NotFound {
+		t.Errorf(expectedFormat, fault.NotFound, actual)
+	}
+	if expected := apierrors.NewNotFound(pods, "a").Error(); translated.Error() != expected {
+		t.Errorf(expectedFormat, expected, translated.Error())
+	}
+	err := errors.New("a")
+	if actual := tr.Translate(err); actual != err {
+		t.Errorf(expectedFormat, err, actual)
+	}
+}
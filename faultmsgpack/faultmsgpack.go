@@ -0,0 +1,48 @@
+// Package faultmsgpack encodes and decodes fault chains as MessagePack, with the same
+// schema as fault.Encode. Compared to JSON the encoding is more compact and preserves
+// integer field values, which makes it suitable for constrained devices and message buses.
+//
+//	Example:
+//	   payload := faultmsgpack.Encode(err)
+//	   ...
+//	   err := faultmsgpack.Decode(payload)
+package faultmsgpack
+
+import (
+	"bytes"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// Encode serializes the entire chain of the error as MessagePack.
+// See fault.Encode for more details. It returns nil if the error is nil.
+func Encode(err error) []byte {
+	return fault.EncodeWith(err, marshal)
+}
+
+// Decode restores an error chain which has been serialized by Encode.
+// See fault.Decode for more details. It returns nil if the data is empty.
+func Decode(data []byte) error {
+	return fault.DecodeWith(data, unmarshal)
+}
+
+// marshal encodes the value with the names of its json struct tags.
+func marshal(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := msgpack.NewEncoder(buf)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshal decodes the value with the names of its json struct tags.
+func unmarshal(data []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
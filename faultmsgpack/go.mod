@@ -0,0 +1,12 @@
+module github.com/dusted-go/fault/faultmsgpack
+
+go 1.19
+
+require (
+	github.com/dusted-go/fault v1.5.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+
+replace github.com/dusted-go/fault => ../
@@ -0,0 +1,306 @@
+// Package faultnotify posts critical faults to a webhook or a Slack channel.
+//
+// It is meant for small teams which don't use an error tracking service:
+// repeated occurrences of the same fault are deduplicated by their fingerprint
+// (see fault.Fingerprint) and the number of notifications is rate limited,
+// so that an outage doesn't flood the channel.
+package faultnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dusted-go/fault/fault"
+	"github.com/dusted-go/fault/stack"
+)
+
+// Default settings of a Notifier.
+const (
+	DefaultDedupWindow = time.Hour
+	DefaultLimit       = 10
+	DefaultInterval    = time.Minute
+	DefaultTopFrames   = 5
+)
+
+// Notification is the content of a notification about a fault.
+type Notification struct {
+	Message     string                 `json:"message"`
+	Messages    []string               `json:"messages"`
+	Fingerprint string                 `json:"fingerprint"`
+	Kind        fault.Kind             `json:"kind"`
+	Severity    fault.Severity         `json:"severity"`
+	Frames      []string               `json:"frames,omitempty"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+
+	// Suppressed is the number of occurrences of the same fault
+	// which have been suppressed since the previous notification.
+	Suppressed int `json:"suppressed,omitempty"`
+}
+
+// EncodeJSON encodes the notification as a JSON object.
+func EncodeJSON(n *Notification) ([]byte, error) {
+	return json.Marshal(n)
+}
+
+// EncodeSlack encodes the notification as the payload of a Slack incoming webhook.
+func EncodeSlack(n *Notification) ([]byte, error) {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("*[%s] %s*\n", n.Severity, n.Message))
+	sb.WriteString(fmt.Sprintf("Kind: `%s`  Fingerprint: `%s`", n.Kind, n.Fingerprint))
+	if n.Suppressed > 0 {
+		sb.WriteString(fmt.Sprintf("  (%d more since the last notification)", n.Suppressed))
+	}
+	if len(n.Messages) > 1 {
+		sb.WriteString("\n>" + strings.Join(n.Messages, "\n>"))
+	}
+	if len(n.Frames) > 0 {
+		sb.WriteString("\n```\n" + strings.Join(n.Frames, "\n") + "\n```")
+	}
+	keys := make([]string, 0, len(n.Fields))
+	for k := range n.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("\n• %s: `%v`", k, n.Fields[k]))
+	}
+	return json.Marshal(struct {
+		Text string `json:"text"`
+	}{sb.String()})
+}
+
+// Notifier posts faults of a minimum severity to a webhook.
+//
+// Subscribed to the fault.DefaultBus, it receives the errors which the transport
+// integrations (e.g. the httpfault.Responder) log, including the severity which
+// has been attached by WithSeverity.
+//
+//	Example:
+//	   notifier := &faultnotify.Notifier{
+//	      URL:    os.Getenv("SLACK_WEBHOOK_URL"),
+//	      Encode: faultnotify.EncodeSlack,
+//	   }
+//	   sub := fault.Subscribe(notifier.Listener())
+//	   defer sub.Close()
+type Notifier struct {
+	// URL is the URL of the webhook.
+	URL string
+
+	// Client sends the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+
+	// Encode encodes the body of the request. Defaults to EncodeJSON.
+	Encode func(n *Notification) ([]byte, error)
+
+	// MinSeverity is the minimum severity of a fault which gets posted.
+	// Defaults to fault.SeverityCritical.
+	MinSeverity fault.Severity
+
+	// DedupWindow is the time during which repeated occurrences of a fault
+	// with the same fingerprint are suppressed. Defaults to DefaultDedupWindow.
+	DedupWindow time.Duration
+
+	// Limit is the maximum number of notifications which are posted per Interval.
+	// Defaults to DefaultLimit per DefaultInterval.
+	Limit    int
+	Interval time.Duration
+
+	// TopFrames is the number of stack frames which are included. Defaults to DefaultTopFrames.
+	TopFrames int
+
+	// Logger logs errors which occur when posting a notification from a Listener.
+	// Defaults to log.Printf.
+	Logger func(err error)
+
+	mu   sync.Mutex
+	seen map[string]*occurrence
+	sent []time.Time
+	now  func() time.Time
+}
+
+type occurrence struct {
+	notified   time.Time
+	suppressed int
+}
+
+// Notify posts a notification about the error, unless its severity is below MinSeverity,
+// the same fault has already been posted within the DedupWindow or the rate limit
+// has been exceeded. Nothing will be posted if the error is nil.
+func (n *Notifier) Notify(ctx context.Context, err error) error {
+	if err == nil || fault.SeverityOf(err) < n.minSeverity() {
+		return nil
+	}
+	fingerprint := fault.Fingerprint(err)
+	suppressed, ok := n.admit(fingerprint)
+	if !ok {
+		return nil
+	}
+
+	notification := NewNotification(err, n.topFrames())
+	notification.Suppressed = suppressed
+	encode := n.Encode
+	if encode == nil {
+		encode = EncodeJSON
+	}
+	body, encErr := encode(notification)
+	if encErr != nil {
+		return fault.SystemWrap(encErr, "failed to encode notification")
+	}
+	req, reqErr := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if reqErr != nil {
+		return fault.SystemWrap(reqErr, "failed to create notification request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, postErr := client.Do(req)
+	if postErr != nil {
+		return fault.SystemWrap(postErr, "failed to post notification")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fault.Systemf("failed to post notification: %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return nil
+}
+
+// Listener returns a fault.Listener which posts the published errors.
+func (n *Notifier) Listener() fault.Listener {
+	return func(err error) {
+		if notifyErr := n.Notify(context.Background(), err); notifyErr != nil {
+			if n.Logger != nil {
+				n.Logger(notifyErr)
+				return
+			}
+			log.Printf("faultnotify: %v", notifyErr)
+		}
+	}
+}
+
+// admit reports whether a notification about the fingerprint may be posted
+// and how many occurrences have been suppressed since the previous one.
+func (n *Notifier) admit(fingerprint string) (int, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := n.clock()
+	window := n.DedupWindow
+	if window <= 0 {
+		window = DefaultDedupWindow
+	}
+	if n.seen == nil {
+		n.seen = map[string]*occurrence{}
+	}
+	for fp, o := range n.seen {
+		if now.Sub(o.notified) >= window && o.suppressed == 0 {
+			delete(n.seen, fp)
+		}
+	}
+
+	o := n.seen[fingerprint]
+	if o != nil && now.Sub(o.notified) < window {
+		o.suppressed++
+		return 0, false
+	}
+
+	limit, interval := n.Limit, n.Interval
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	recent := n.sent[:0]
+	for _, t := range n.sent {
+		if now.Sub(t) < interval {
+			recent = append(recent, t)
+		}
+	}
+	n.sent = recent
+	if len(n.sent) >= limit {
+		if o != nil {
+			o.suppressed++
+		}
+		return 0, false
+	}
+	n.sent = append(n.sent, now)
+
+	suppressed := 0
+	if o != nil {
+		suppressed = o.suppressed
+	}
+	n.seen[fingerprint] = &occurrence{notified: now}
+	return suppressed, true
+}
+
+func (n *Notifier) clock() time.Time {
+	if n.now != nil {
+		return n.now()
+	}
+	return time.Now()
+}
+
+func (n *Notifier) minSeverity() fault.Severity {
+	if n.MinSeverity == 0 {
+		return fault.SeverityCritical
+	}
+	return n.MinSeverity
+}
+
+func (n *Notifier) topFrames() int {
+	if n.TopFrames <= 0 {
+		return DefaultTopFrames
+	}
+	return n.TopFrames
+}
+
+// NewNotification returns the notification about the error, including the
+// top frames of the stack trace of the innermost SystemError of the chain.
+// Messages and fields are scrubbed by the fault.DefaultScrubber.
+func NewNotification(err error, topFrames int) *Notification {
+	notification := &Notification{
+		Message:     fault.Scrub(err.Error()),
+		Messages:    []string{fault.Scrub(err.Error())},
+		Fingerprint: fault.Fingerprint(err),
+		Kind:        fault.KindOf(err),
+		Severity:    fault.SeverityOf(err),
+	}
+	var sysErr *fault.SystemError
+	if !errors.As(err, &sysErr) {
+		return notification
+	}
+	if msgs := sysErr.Messages(); len(msgs) > 0 {
+		for i, msg := range msgs {
+			msgs[i] = fault.Scrub(msg)
+		}
+		notification.Message = msgs[0]
+		notification.Messages = msgs
+	}
+	notification.Fields = fault.ScrubFields(sysErr.Fields())
+
+	origin := sysErr
+	for e := error(sysErr); e != nil; e = errors.Unwrap(e) {
+		// nolint: errorlint // Walking the chain manually:
+		if inner, ok := e.(*fault.SystemError); ok {
+			origin = inner
+		}
+	}
+	for i, f := range origin.Trace().Frames() {
+		if i == topFrames {
+			break
+		}
+		notification.Frames = append(notification.Frames,
+			fmt.Sprintf("%s (%s)", stack.FuncName(f.Function), stack.Location(f)))
+	}
+	return notification
+}
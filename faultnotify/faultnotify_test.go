@@ -0,0 +1,186 @@
+package faultnotify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dusted-go/fault/fault"
+	"github.com/dusted-go/fault/httpfault"
+)
+
+const expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+
+type webhook struct {
+	mu     sync.Mutex
+	bodies []string
+	server *httptest.Server
+}
+
+func newWebhook(t *testing.T) *webhook {
+	wh := &webhook{}
+	wh.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		wh.mu.Lock()
+		defer wh.mu.Unlock()
+		wh.bodies = append(wh.bodies, string(body))
+	}))
+	t.Cleanup(wh.server.Close)
+	return wh
+}
+
+func newCritical(id int) error {
+	err := fault.SystemWrapf(errors.New("invariant violated"), "failed to charge order %d", id)
+	return err.WithSeverity(fault.SeverityCritical).WithField("order_id", id)
+}
+
+func Test_Notifier_Notify_PostsJSON(t *testing.T) {
+	wh := newWebhook(t)
+	n := &Notifier{URL: wh.server.URL}
+
+	if err := n.Notify(context.Background(), newCritical(42)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(wh.bodies) != 1 {
+		t.Fatalf(expectedFormat, 1, len(wh.bodies))
+	}
+	var notification Notification
+	if err := json.Unmarshal([]byte(wh.bodies[0]), &notification); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "failed to charge order 42"; notification.Message != expected {
+		t.Errorf(expectedFormat, expected, notification.Message)
+	}
+	if len(notification.Fingerprint) != 16 {
+		t.Errorf(expectedFormat, "16 hex digits", notification.Fingerprint)
+	}
+	if notification.Severity != fault.SeverityCritical {
+		t.Errorf(expectedFormat, fault.SeverityCritical, notification.Severity)
+	}
+	if len(notification.Frames) == 0 || !strings.Contains(notification.Frames[0], "faultnotify.newCritical") {
+		t.Errorf(expectedFormat, "faultnotify.newCritical", notification.Frames)
+	}
+	if actual := notification.Fields["order_id"]; actual != float64(42) {
+		t.Errorf(expectedFormat, 42, actual)
+	}
+}
+
+func Test_Notifier_Notify_IgnoresBelowMinSeverity(t *testing.T) {
+	wh := newWebhook(t)
+	n := &Notifier{URL: wh.server.URL}
+
+	if err := n.Notify(context.Background(), fault.System("boom")); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(wh.bodies) != 0 {
+		t.Errorf(expectedFormat, 0, len(wh.bodies))
+	}
+}
+
+func Test_Notifier_Notify_Deduplicates(t *testing.T) {
+	wh := newWebhook(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	n := &Notifier{URL: wh.server.URL, DedupWindow: time.Minute}
+	n.now = func() time.Time { return now }
+
+	for _, offset := range []time.Duration{0, time.Second, 2 * time.Second, time.Minute} {
+		now = now.Add(offset)
+		if err := n.Notify(context.Background(), newCritical(1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(wh.bodies) != 2 {
+		t.Fatalf(expectedFormat, 2, len(wh.bodies))
+	}
+	if !strings.Contains(wh.bodies[1], `"suppressed":2`) {
+		t.Errorf(expectedFormat, `"suppressed":2`, wh.bodies[1])
+	}
+}
+
+func Test_Notifier_Notify_RateLimits(t *testing.T) {
+	wh := newWebhook(t)
+	n := &Notifier{URL: wh.server.URL, Limit: 2}
+
+	for _, kind := range []fault.Kind{fault.Internal, fault.Timeout, fault.Unavailable} {
+		err := fault.System(fmt.Sprintf("boom %s", kind)).WithKind(kind).WithSeverity(fault.SeverityCritical)
+		if notifyErr := n.Notify(context.Background(), err); notifyErr != nil {
+			t.Fatal(notifyErr)
+		}
+	}
+
+	if len(wh.bodies) != 2 {
+		t.Errorf(expectedFormat, 2, len(wh.bodies))
+	}
+}
+
+func Test_Notifier_Notify_WithFailingWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	n := &Notifier{URL: server.URL}
+
+	err := n.Notify(context.Background(), newCritical(1))
+
+	expected := "failed to post notification: 500 Internal Server Error"
+	if err == nil || err.Error() != expected {
+		t.Errorf(expectedFormat, expected, err)
+	}
+}
+
+func Test_EncodeSlack(t *testing.T) {
+	data, err := EncodeSlack(&Notification{
+		Message:     "failed to charge order 42",
+		Messages:    []string{"failed to charge order 42", "invariant violated"},
+		Fingerprint: "abc",
+		Kind:        fault.Internal,
+		Severity:    fault.SeverityCritical,
+		Frames:      []string{"main.charge (/app/main.go:12)"},
+		Fields:      map[string]interface{}{"order_id": 42},
+		Suppressed:  3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatal(err)
+	}
+	expected := "*[critical] failed to charge order 42*\n" +
+		"Kind: `internal`  Fingerprint: `abc`  (3 more since the last notification)\n" +
+		">failed to charge order 42\n>invariant violated\n" +
+		"```\nmain.charge (/app/main.go:12)\n```\n" +
+		"• order_id: `42`"
+	if payload.Text != expected {
+		t.Errorf(expectedFormat, expected, payload.Text)
+	}
+}
+
+func Test_Notifier_Listener_ReceivesLoggedErrors(t *testing.T) {
+	wh := newWebhook(t)
+	n := &Notifier{URL: wh.server.URL}
+	sub := fault.Subscribe(n.Listener())
+	rs := &httpfault.Responder{Logger: func(r *http.Request, err error) {}}
+
+	rs.WriteError(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/orders", nil), newCritical(42))
+	rs.WriteError(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/orders", nil), fault.System("not critical"))
+	sub.Close()
+
+	if len(wh.bodies) != 1 || !strings.Contains(wh.bodies[0], "failed to charge order 42") {
+		t.Errorf(expectedFormat, "failed to charge order 42", wh.bodies)
+	}
+}
@@ -0,0 +1,490 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: fault.proto
+
+package faultproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Fault is either a UserError or a SystemError.
+type Fault struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Fault:
+	//	*Fault_User
+	//	*Fault_System
+	Fault isFault_Fault `protobuf_oneof:"fault"`
+}
+
+func (x *Fault) Reset() {
+	*x = Fault{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fault_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Fault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fault) ProtoMessage() {}
+
+func (x *Fault) ProtoReflect() protoreflect.Message {
+	mi := &file_fault_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fault.ProtoReflect.Descriptor instead.
+func (*Fault) Descriptor() ([]byte, []int) {
+	return file_fault_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *Fault) GetFault() isFault_Fault {
+	if m != nil {
+		return m.Fault
+	}
+	return nil
+}
+
+func (x *Fault) GetUser() *UserError {
+	if x, ok := x.GetFault().(*Fault_User); ok {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Fault) GetSystem() *SystemError {
+	if x, ok := x.GetFault().(*Fault_System); ok {
+		return x.System
+	}
+	return nil
+}
+
+type isFault_Fault interface {
+	isFault_Fault()
+}
+
+type Fault_User struct {
+	User *UserError `protobuf:"bytes,1,opt,name=user,proto3,oneof"`
+}
+
+type Fault_System struct {
+	System *SystemError `protobuf:"bytes,2,opt,name=system,proto3,oneof"`
+}
+
+func (*Fault_User) isFault_Fault() {}
+
+func (*Fault_System) isFault_Fault() {}
+
+// UserError is an error which was caused by the end user.
+type UserError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Errors contains the user errors in the order in which they were added.
+	Errors []*UserErrorEntry `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	// RetryAfter is the duration after which the request may be retried.
+	RetryAfter *durationpb.Duration `protobuf:"bytes,2,opt,name=retry_after,json=retryAfter,proto3" json:"retry_after,omitempty"`
+}
+
+func (x *UserError) Reset() {
+	*x = UserError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fault_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserError) ProtoMessage() {}
+
+func (x *UserError) ProtoReflect() protoreflect.Message {
+	mi := &file_fault_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserError.ProtoReflect.Descriptor instead.
+func (*UserError) Descriptor() ([]byte, []int) {
+	return file_fault_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserError) GetErrors() []*UserErrorEntry {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *UserError) GetRetryAfter() *durationpb.Duration {
+	if x != nil {
+		return x.RetryAfter
+	}
+	return nil
+}
+
+// UserErrorEntry is a single user error.
+type UserErrorEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *UserErrorEntry) Reset() {
+	*x = UserErrorEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fault_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserErrorEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserErrorEntry) ProtoMessage() {}
+
+func (x *UserErrorEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_fault_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserErrorEntry.ProtoReflect.Descriptor instead.
+func (*UserErrorEntry) Descriptor() ([]byte, []int) {
+	return file_fault_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserErrorEntry) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UserErrorEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// SystemError is an error which was caused by an internal fault.
+type SystemError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Messages is the message chain, starting with the outermost message.
+	Messages []string `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	// Kind classifies the error (e.g. "timeout").
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Fields contains the structured fields of the entire chain.
+	Fields *structpb.Struct `protobuf:"bytes,3,opt,name=fields,proto3" json:"fields,omitempty"`
+	// Stack is the formatted stack trace. It is empty if it has been omitted.
+	Stack string `protobuf:"bytes,4,opt,name=stack,proto3" json:"stack,omitempty"`
+	// RetryAfter is the duration after which the operation may be retried.
+	RetryAfter *durationpb.Duration `protobuf:"bytes,5,opt,name=retry_after,json=retryAfter,proto3" json:"retry_after,omitempty"`
+	// Retryable is the explicit retry classification of the error.
+	Retryable *bool `protobuf:"varint,6,opt,name=retryable,proto3,oneof" json:"retryable,omitempty"`
+	// Cause is the UserError which is wrapped by the SystemError.
+	Cause *UserError `protobuf:"bytes,7,opt,name=cause,proto3" json:"cause,omitempty"`
+}
+
+func (x *SystemError) Reset() {
+	*x = SystemError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fault_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemError) ProtoMessage() {}
+
+func (x *SystemError) ProtoReflect() protoreflect.Message {
+	mi := &file_fault_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemError.ProtoReflect.Descriptor instead.
+func (*SystemError) Descriptor() ([]byte, []int) {
+	return file_fault_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SystemError) GetMessages() []string {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *SystemError) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SystemError) GetFields() *structpb.Struct {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *SystemError) GetStack() string {
+	if x != nil {
+		return x.Stack
+	}
+	return ""
+}
+
+func (x *SystemError) GetRetryAfter() *durationpb.Duration {
+	if x != nil {
+		return x.RetryAfter
+	}
+	return nil
+}
+
+func (x *SystemError) GetRetryable() bool {
+	if x != nil && x.Retryable != nil {
+		return *x.Retryable
+	}
+	return false
+}
+
+func (x *SystemError) GetCause() *UserError {
+	if x != nil {
+		return x.Cause
+	}
+	return nil
+}
+
+var File_fault_proto protoreflect.FileDescriptor
+
+var file_fault_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x64,
+	0x75, 0x73, 0x74, 0x65, 0x64, 0x2e, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x76, 0x31, 0x1a, 0x1e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7a, 0x0a, 0x05,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x30, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x75, 0x73, 0x74, 0x65, 0x64, 0x2e, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48,
+	0x00, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x75, 0x73, 0x74, 0x65, 0x64,
+	0x2e, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x42,
+	0x07, 0x0a, 0x05, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x09, 0x55, 0x73, 0x65,
+	0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x75, 0x73, 0x74, 0x65, 0x64, 0x2e,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12,
+	0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x72, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0x3e, 0x0a, 0x0e, 0x55,
+	0x73, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa3, 0x02, 0x0a, 0x0b,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2f, 0x0a, 0x06, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x63, 0x6b, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x21,
+	0x0a, 0x09, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x30, 0x0a, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x64, 0x75, 0x73, 0x74, 0x65, 0x64, 0x2e, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x61,
+	0x75, 0x73, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c,
+	0x65, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x64, 0x75, 0x73, 0x74, 0x65, 0x64, 0x2d, 0x67, 0x6f, 0x2f, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2f,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_fault_proto_rawDescOnce sync.Once
+	file_fault_proto_rawDescData = file_fault_proto_rawDesc
+)
+
+func file_fault_proto_rawDescGZIP() []byte {
+	file_fault_proto_rawDescOnce.Do(func() {
+		file_fault_proto_rawDescData = protoimpl.X.CompressGZIP(file_fault_proto_rawDescData)
+	})
+	return file_fault_proto_rawDescData
+}
+
+var file_fault_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_fault_proto_goTypes = []any{
+	(*Fault)(nil),               // 0: dusted.fault.v1.Fault
+	(*UserError)(nil),           // 1: dusted.fault.v1.UserError
+	(*UserErrorEntry)(nil),      // 2: dusted.fault.v1.UserErrorEntry
+	(*SystemError)(nil),         // 3: dusted.fault.v1.SystemError
+	(*durationpb.Duration)(nil), // 4: google.protobuf.Duration
+	(*structpb.Struct)(nil),     // 5: google.protobuf.Struct
+}
+var file_fault_proto_depIdxs = []int32{
+	1, // 0: dusted.fault.v1.Fault.user:type_name -> dusted.fault.v1.UserError
+	3, // 1: dusted.fault.v1.Fault.system:type_name -> dusted.fault.v1.SystemError
+	2, // 2: dusted.fault.v1.UserError.errors:type_name -> dusted.fault.v1.UserErrorEntry
+	4, // 3: dusted.fault.v1.UserError.retry_after:type_name -> google.protobuf.Duration
+	5, // 4: dusted.fault.v1.SystemError.fields:type_name -> google.protobuf.Struct
+	4, // 5: dusted.fault.v1.SystemError.retry_after:type_name -> google.protobuf.Duration
+	1, // 6: dusted.fault.v1.SystemError.cause:type_name -> dusted.fault.v1.UserError
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_fault_proto_init() }
+func file_fault_proto_init() {
+	if File_fault_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_fault_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Fault); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fault_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*UserError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fault_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*UserErrorEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fault_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_fault_proto_msgTypes[0].OneofWrappers = []any{
+		(*Fault_User)(nil),
+		(*Fault_System)(nil),
+	}
+	file_fault_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_fault_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_fault_proto_goTypes,
+		DependencyIndexes: file_fault_proto_depIdxs,
+		MessageInfos:      file_fault_proto_msgTypes,
+	}.Build()
+	File_fault_proto = out.File
+	file_fault_proto_rawDesc = nil
+	file_fault_proto_goTypes = nil
+	file_fault_proto_depIdxs = nil
+}
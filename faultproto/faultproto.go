@@ -0,0 +1,169 @@
+// Package faultproto defines protobuf messages for faults, so that they
+// can be embedded in RPC payloads and event envelopes.
+package faultproto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative fault.proto
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// Option configures the conversion into a protobuf message.
+type Option func(*options)
+
+type options struct {
+	omitStack bool
+}
+
+// WithoutStack omits the stack trace of a SystemError,
+// e.g. when the message gets sent to an external party.
+func WithoutStack() Option {
+	return func(o *options) {
+		o.omitStack = true
+	}
+}
+
+// ToProto converts an error into a Fault message.
+//
+// A SystemError (anywhere in the error's chain) gets converted into a SystemError
+// message with the message chain, kind, fields, retry classification and stack trace of
+// the outermost SystemError. A UserError which is wrapped by the SystemError is kept as cause.
+//
+// A UserError gets converted into a UserError message and any other error
+// gets converted into a SystemError message without a stack trace.
+func ToProto(err error, opts ...Option) *Fault {
+	if err == nil {
+		return nil
+	}
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var userErr *fault.UserError
+	hasUserErr := errors.As(err, &userErr)
+
+	var sysErr *fault.SystemError
+	if !errors.As(err, &sysErr) {
+		if hasUserErr {
+			return &Fault{Fault: &Fault_User{User: userErrorToProto(userErr)}}
+		}
+		return &Fault{Fault: &Fault_System{System: &SystemError{
+			Messages:  []string{fault.Scrub(err.Error())},
+			Kind:      fault.KindOf(err).String(),
+			Retryable: proto.Bool(fault.IsRetryable(err)),
+		}}}
+	}
+
+	msgs := sysErr.Messages()
+	for i, m := range msgs {
+		msgs[i] = fault.Scrub(m)
+	}
+	msg := &SystemError{
+		Messages:  msgs,
+		Kind:      sysErr.Kind().String(),
+		Fields:    fieldsToProto(fault.ScrubFields(sysErr.Fields())),
+		Retryable: proto.Bool(sysErr.Retryable()),
+	}
+	if !o.omitStack {
+		msg.Stack = sysErr.StackTrace()
+	}
+	if retryAfter, ok := sysErr.RetryAfter(); ok {
+		msg.RetryAfter = durationpb.New(retryAfter)
+	}
+	if hasUserErr {
+		msg.Cause = userErrorToProto(userErr)
+	}
+	return &Fault{Fault: &Fault_System{System: msg}}
+}
+
+func userErrorToProto(userErr *fault.UserError) *UserError {
+	msg := &UserError{}
+	msgs := userErr.Scrub().ErrorMessages()
+	for i, code := range userErr.Codes() {
+		msg.Errors = append(msg.Errors, &UserErrorEntry{Code: code.String(), Message: msgs[i]})
+	}
+	if retryAfter, ok := userErr.RetryAfter(); ok {
+		msg.RetryAfter = durationpb.New(retryAfter)
+	}
+	return msg
+}
+
+// fieldsToProto converts the fields into a Struct. Values which
+// have no JSON representation get converted into strings.
+func fieldsToProto(fields map[string]interface{}) *structpb.Struct {
+	if len(fields) == 0 {
+		return nil
+	}
+	s := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(fields))}
+	for k, v := range fields {
+		value, err := structpb.NewValue(v)
+		if err != nil {
+			value = structpb.NewStringValue(fmt.Sprint(v))
+		}
+		s.Fields[k] = value
+	}
+	return s
+}
+
+// FromProto converts a Fault message back into a UserError or SystemError.
+// It returns nil if the message is nil or empty.
+//
+// The stack trace of a restored SystemError is only available as text
+// (see fault.RestoreSystem).
+func FromProto(msg *Fault) error {
+	switch f := msg.GetFault().(type) {
+	case *Fault_User:
+		if userErr := userErrorFromProto(f.User); userErr != nil {
+			return userErr
+		}
+		return nil
+	case *Fault_System:
+		return systemErrorFromProto(f.System)
+	default:
+		return nil
+	}
+}
+
+func userErrorFromProto(msg *UserError) *fault.UserError {
+	var userErr *fault.UserError
+	for _, e := range msg.GetErrors() {
+		if userErr == nil {
+			userErr = fault.User(fault.Code(e.GetCode()), e.GetMessage())
+		} else {
+			userErr.Add(fault.Code(e.GetCode()), e.GetMessage())
+		}
+	}
+	if userErr != nil && msg.GetRetryAfter() != nil {
+		userErr.WithRetryAfter(msg.GetRetryAfter().AsDuration())
+	}
+	return userErr
+}
+
+func systemErrorFromProto(msg *SystemError) *fault.SystemError {
+	var cause error
+	if userErr := userErrorFromProto(msg.GetCause()); userErr != nil {
+		cause = userErr
+	}
+	sysErr := fault.RestoreSystem(cause, msg.GetMessages(), msg.GetStack())
+	if msg.GetKind() != "" {
+		sysErr.WithKind(fault.Kind(msg.GetKind()))
+	}
+	if msg.GetFields() != nil {
+		sysErr.WithFields(msg.GetFields().AsMap())
+	}
+	if msg.GetRetryAfter() != nil {
+		sysErr.WithRetryAfter(msg.GetRetryAfter().AsDuration())
+	}
+	if msg.Retryable != nil {
+		sysErr.WithRetryable(msg.GetRetryable())
+	}
+	return sysErr
+}
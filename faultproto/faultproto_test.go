@@ -0,0 +1,114 @@
+package faultproto
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func roundTrip(t *testing.T, err error, opts ...Option) error {
+	t.Helper()
+	data, marshalErr := proto.Marshal(ToProto(err, opts...))
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	msg := &Fault{}
+	if unmarshalErr := proto.Unmarshal(data, msg); unmarshalErr != nil {
+		t.Fatal(unmarshalErr)
+	}
+	return FromProto(msg)
+}
+
+func Test_RoundTrip_WithUserError(t *testing.T) {
+	userErr := fault.User("MISSING_FIRST_NAME", "Please provide your first name.")
+	userErr.Add("MISSING_LAST_NAME", "Please provide your last name.")
+	userErr.WithRetryAfter(time.Minute)
+
+	err := roundTrip(t, userErr)
+
+	var actual *fault.UserError
+	if !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.UserError", err)
+	}
+	if actual.Error() != userErr.Error() {
+		t.Errorf(expectedFormat, userErr.Error(), actual.Error())
+	}
+	if retryAfter, _ := actual.RetryAfter(); retryAfter != time.Minute {
+		t.Errorf(expectedFormat, time.Minute, retryAfter)
+	}
+}
+
+func Test_RoundTrip_WithSystemError(t *testing.T) {
+	sysErr := fault.SystemWrap(
+		fault.User("MISSING_NAME", "Please provide a name."),
+		"failed to create user").
+		WithKind(fault.Conflict).
+		WithField("user_id", "123").
+		WithField("attempt", 2).
+		WithRetryable(false)
+
+	err := roundTrip(t, sysErr)
+
+	var actual *fault.SystemError
+	if !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.SystemError", err)
+	}
+	if actual.String() != sysErr.String() {
+		t.Errorf(expectedFormat, sysErr.String(), actual.String())
+	}
+	if actual.Kind() != fault.Conflict {
+		t.Errorf(expectedFormat, fault.Conflict, actual.Kind())
+	}
+	if fields := actual.Fields(); fields["user_id"] != "123" || fields["attempt"] != 2.0 {
+		t.Errorf(expectedFormat, sysErr.Fields(), fields)
+	}
+	if actual.Retryable() {
+		t.Error("The restored SystemError was expected to not be retryable.")
+	}
+	var userErr *fault.UserError
+	if !errors.As(err, &userErr) || !userErr.HasCode("MISSING_NAME") {
+		t.Errorf(expectedFormat, "MISSING_NAME", userErr)
+	}
+}
+
+func Test_RoundTrip_WithoutStack(t *testing.T) {
+	sysErr := fault.System("connection refused")
+
+	err := roundTrip(t, sysErr, WithoutStack())
+
+	var actual *fault.SystemError
+	if !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.SystemError", err)
+	}
+	if actual.Error() != sysErr.Error() {
+		t.Errorf(expectedFormat, sysErr.Error(), actual.Error())
+	}
+	if actual.StackTrace() != "" {
+		t.Errorf(expectedFormat, "", actual.StackTrace())
+	}
+}
+
+func Test_ToProto_WithPlainError(t *testing.T) {
+	msg := ToProto(errors.New("foo bar"))
+
+	if msg.GetSystem().GetMessages()[0] != "foo bar" {
+		t.Errorf(expectedFormat, "foo bar", msg.GetSystem().GetMessages())
+	}
+	if msg.GetSystem().GetKind() != fault.Internal.String() {
+		t.Errorf(expectedFormat, fault.Internal, msg.GetSystem().GetKind())
+	}
+}
+
+func Test_FromProto_WithNil(t *testing.T) {
+	if err := FromProto(nil); err != nil {
+		t.Errorf(expectedFormat, nil, err)
+	}
+}
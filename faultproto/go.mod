@@ -0,0 +1,10 @@
+module github.com/dusted-go/fault/faultproto
+
+go 1.21
+
+require (
+	github.com/dusted-go/fault v1.5.0
+	google.golang.org/protobuf v1.34.2
+)
+
+replace github.com/dusted-go/fault => ../
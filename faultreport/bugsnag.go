@@ -0,0 +1,68 @@
+package faultreport
+
+import "github.com/dusted-go/fault/fault"
+
+// BugsnagEvent is an event of the Bugsnag Error Reporting API.
+type BugsnagEvent struct {
+	Exceptions   []BugsnagException                `json:"exceptions"`
+	Severity     string                            `json:"severity"`
+	Unhandled    bool                              `json:"unhandled"`
+	GroupingHash string                            `json:"groupingHash,omitempty"`
+	MetaData     map[string]map[string]interface{} `json:"metaData,omitempty"`
+}
+
+// BugsnagException is an exception of a BugsnagEvent.
+type BugsnagException struct {
+	ErrorClass string              `json:"errorClass"`
+	Message    string              `json:"message"`
+	Type       string              `json:"type"`
+	Stacktrace []BugsnagStackframe `json:"stacktrace"`
+}
+
+// BugsnagStackframe is a frame of the stack trace of a BugsnagException,
+// starting with the most recent call.
+type BugsnagStackframe struct {
+	File       string `json:"file"`
+	LineNumber int    `json:"lineNumber"`
+	Method     string `json:"method"`
+	InProject  bool   `json:"inProject"`
+}
+
+// bugsnagSeverities maps the severities of faults to the severities of Bugsnag.
+var bugsnagSeverities = map[fault.Severity]string{
+	fault.SeverityInfo:     "info",
+	fault.SeverityWarning:  "warning",
+	fault.SeverityError:    "error",
+	fault.SeverityCritical: "error",
+}
+
+// NewBugsnagEvent converts the error into a Bugsnag event. It returns nil if the error is nil.
+//
+// The error class is the type of the error which caused the fault (see fault.CauseType)
+// and the events are grouped by the fingerprint of the fault (see fault.Fingerprint).
+// The kind, fingerprint, op and fields of the fault are attached as the fault tab of the metadata.
+func NewBugsnagEvent(err error) *BugsnagEvent {
+	if err == nil {
+		return nil
+	}
+	var stacktrace []BugsnagStackframe
+	for _, f := range frames(err) {
+		stacktrace = append(stacktrace, BugsnagStackframe{
+			File:       f.File,
+			LineNumber: f.Line,
+			Method:     f.Function,
+			InProject:  inProject(f),
+		})
+	}
+	return &BugsnagEvent{
+		Exceptions: []BugsnagException{{
+			ErrorClass: fault.CauseType(err),
+			Message:    message(err),
+			Type:       "go",
+			Stacktrace: stacktrace,
+		}},
+		Severity:     bugsnagSeverities[fault.SeverityOf(err)],
+		GroupingHash: fault.Fingerprint(err),
+		MetaData:     map[string]map[string]interface{}{"fault": metadata(err)},
+	}
+}
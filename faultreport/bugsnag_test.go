@@ -0,0 +1,60 @@
+package faultreport
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func newChargeError() error {
+	err := fault.SystemWrap(errors.New("card declined"), "failed to charge order")
+	return err.WithSeverity(fault.SeverityCritical).WithField("order_id", 42)
+}
+
+func Test_NewBugsnagEvent_Nil(t *testing.T) {
+	if actual := NewBugsnagEvent(nil); actual != nil {
+		t.Errorf(expectedFormat, nil, actual)
+	}
+}
+
+func Test_NewBugsnagEvent(t *testing.T) {
+	err := fault.SystemWrap(newChargeError(), "failed to checkout")
+
+	event := NewBugsnagEvent(err)
+
+	exception := event.Exceptions[0]
+	if expected := "*errors.errorString"; exception.ErrorClass != expected {
+		t.Errorf(expectedFormat, expected, exception.ErrorClass)
+	}
+	if expected := "failed to checkout"; exception.Message != expected {
+		t.Errorf(expectedFormat, expected, exception.Message)
+	}
+	if len(exception.Stacktrace) == 0 || !strings.HasSuffix(exception.Stacktrace[0].Method, "faultreport.newChargeError") {
+		t.Errorf(expectedFormat, "faultreport.newChargeError", exception.Stacktrace)
+	}
+	if !exception.Stacktrace[0].InProject {
+		t.Errorf(expectedFormat, true, exception.Stacktrace[0].InProject)
+	}
+	if expected := "error"; event.Severity != expected {
+		t.Errorf(expectedFormat, expected, event.Severity)
+	}
+	if expected := fault.Fingerprint(err); event.GroupingHash != expected {
+		t.Errorf(expectedFormat, expected, event.GroupingHash)
+	}
+	if actual := event.MetaData["fault"]["order_id"]; actual != 42 {
+		t.Errorf(expectedFormat, 42, actual)
+	}
+}
+
+func Test_NewBugsnagEvent_UserError(t *testing.T) {
+	event := NewBugsnagEvent(fault.User("MISSING_NAME", "Please provide a name."))
+
+	if expected := "info"; event.Severity != expected {
+		t.Errorf(expectedFormat, expected, event.Severity)
+	}
+	if actual := event.Exceptions[0].Stacktrace; actual != nil {
+		t.Errorf(expectedFormat, nil, actual)
+	}
+}
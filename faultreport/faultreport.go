@@ -0,0 +1,69 @@
+// Package faultreport converts faults into the event payloads of error tracking services.
+//
+// The payloads carry the stack trace which has been captured when the fault was created,
+// rather than the stack trace of the location where the error gets reported, and can be
+// sent to the service with its HTTP API or be merged into the events of its SDK.
+// Messages and fields are scrubbed by the fault.DefaultScrubber.
+// No SDK needs to be imported by this package.
+package faultreport
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// metadata returns the classification and the fields of the fault.
+func metadata(err error) map[string]interface{} {
+	meta := map[string]interface{}{
+		"kind":        fault.KindOf(err).String(),
+		"fingerprint": fault.Fingerprint(err),
+	}
+	var sysErr *fault.SystemError
+	if errors.As(err, &sysErr) {
+		if op := sysErr.Op(); op != "" {
+			meta["op"] = string(op)
+		}
+		for k, v := range fault.ScrubFields(sysErr.Fields()) {
+			meta[k] = v
+		}
+	}
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		meta["codes"] = userErr.Codes()
+	}
+	return meta
+}
+
+// frames returns the stack frames of the fault, starting with the most recent call.
+func frames(err error) []runtime.Frame {
+	var sysErr *fault.SystemError
+	if errors.As(err, &sysErr) {
+		return sysErr.Stack()
+	}
+	return nil
+}
+
+// message returns the outermost message of the fault.
+func message(err error) string {
+	var sysErr *fault.SystemError
+	if errors.As(err, &sysErr) {
+		if msgs := sysErr.Messages(); len(msgs) > 0 {
+			return fault.Scrub(msgs[0])
+		}
+	}
+	return fault.Scrub(err.Error())
+}
+
+// inProject reports whether the frame belongs to the main package or to a module
+// outside of the standard library, whose import paths start with a domain name.
+func inProject(f runtime.Frame) bool {
+	pkg := f.Function
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	return strings.Contains(f.Function[:len(f.Function)-len(pkg)], ".") ||
+		strings.HasPrefix(f.Function, "main.")
+}
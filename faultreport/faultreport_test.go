@@ -0,0 +1,22 @@
+package faultreport
+
+import (
+	"runtime"
+	"testing"
+)
+
+const expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+
+func Test_inProject(t *testing.T) {
+	testCases := map[string]bool{
+		"main.main":                             true,
+		"github.com/acme/app/store.(*Repo).Get": true,
+		"net/http.(*Server).Serve":              false,
+		"runtime.goexit":                        false,
+	}
+	for function, expected := range testCases {
+		if actual := inProject(runtime.Frame{Function: function}); actual != expected {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
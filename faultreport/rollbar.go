@@ -0,0 +1,77 @@
+package faultreport
+
+import "github.com/dusted-go/fault/fault"
+
+// RollbarItem is the data of an item of the Rollbar API.
+type RollbarItem struct {
+	Body        RollbarBody            `json:"body"`
+	Level       string                 `json:"level"`
+	Language    string                 `json:"language"`
+	Title       string                 `json:"title,omitempty"`
+	Fingerprint string                 `json:"fingerprint,omitempty"`
+	Custom      map[string]interface{} `json:"custom,omitempty"`
+}
+
+// RollbarBody is the body of a RollbarItem.
+type RollbarBody struct {
+	Trace RollbarTrace `json:"trace"`
+}
+
+// RollbarTrace is the stack trace and exception of a RollbarItem.
+type RollbarTrace struct {
+	Frames    []RollbarFrame   `json:"frames"`
+	Exception RollbarException `json:"exception"`
+}
+
+// RollbarFrame is a frame of a RollbarTrace, starting with the oldest call.
+type RollbarFrame struct {
+	Filename string `json:"filename"`
+	Lineno   int    `json:"lineno"`
+	Method   string `json:"method"`
+}
+
+// RollbarException is the exception of a RollbarTrace.
+type RollbarException struct {
+	Class   string `json:"class"`
+	Message string `json:"message"`
+}
+
+// rollbarLevels maps the severities of faults to the levels of Rollbar.
+var rollbarLevels = map[fault.Severity]string{
+	fault.SeverityInfo:     "info",
+	fault.SeverityWarning:  "warning",
+	fault.SeverityError:    "error",
+	fault.SeverityCritical: "critical",
+}
+
+// NewRollbarItem converts the error into the data of a Rollbar item. It returns nil if the error is nil.
+//
+// The exception class is the type of the error which caused the fault (see fault.CauseType)
+// and the items are grouped by the fingerprint of the fault (see fault.Fingerprint).
+// The kind, fingerprint, op and fields of the fault are attached as custom data.
+func NewRollbarItem(err error) *RollbarItem {
+	if err == nil {
+		return nil
+	}
+	stack := frames(err)
+	rollbarFrames := make([]RollbarFrame, len(stack))
+	for i, f := range stack {
+		rollbarFrames[len(stack)-1-i] = RollbarFrame{
+			Filename: f.File,
+			Lineno:   f.Line,
+			Method:   f.Function,
+		}
+	}
+	msg := message(err)
+	return &RollbarItem{
+		Body: RollbarBody{Trace: RollbarTrace{
+			Frames:    rollbarFrames,
+			Exception: RollbarException{Class: fault.CauseType(err), Message: msg},
+		}},
+		Level:       rollbarLevels[fault.SeverityOf(err)],
+		Language:    "go",
+		Title:       msg,
+		Fingerprint: fault.Fingerprint(err),
+		Custom:      metadata(err),
+	}
+}
@@ -0,0 +1,41 @@
+package faultreport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_NewRollbarItem_Nil(t *testing.T) {
+	if actual := NewRollbarItem(nil); actual != nil {
+		t.Errorf(expectedFormat, nil, actual)
+	}
+}
+
+func Test_NewRollbarItem(t *testing.T) {
+	err := newChargeError()
+
+	item := NewRollbarItem(err)
+
+	frames := item.Body.Trace.Frames
+	if len(frames) == 0 || !strings.HasSuffix(frames[len(frames)-1].Method, "faultreport.newChargeError") {
+		t.Errorf(expectedFormat, "faultreport.newChargeError as the last frame", frames)
+	}
+	exception := item.Body.Trace.Exception
+	if expected := "*errors.errorString"; exception.Class != expected {
+		t.Errorf(expectedFormat, expected, exception.Class)
+	}
+	if expected := "failed to charge order"; exception.Message != expected || item.Title != expected {
+		t.Errorf(expectedFormat, expected, exception.Message)
+	}
+	if expected := "critical"; item.Level != expected {
+		t.Errorf(expectedFormat, expected, item.Level)
+	}
+	if expected := fault.Fingerprint(err); item.Fingerprint != expected {
+		t.Errorf(expectedFormat, expected, item.Fingerprint)
+	}
+	if expected := "internal"; item.Custom["kind"] != expected {
+		t.Errorf(expectedFormat, expected, item.Custom["kind"])
+	}
+}
@@ -0,0 +1,138 @@
+// Package faultsql classifies database errors as faults.
+//
+// Driver errors are recognized by their SQLSTATE code, which is exposed by the
+// SQLState method of the PostgreSQL drivers pgx (*pgconn.PgError) and
+// lib/pq (*pq.Error). No driver needs to be imported by this package.
+package faultsql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// SQLSTATE codes which are commonly checked by applications.
+const (
+	UniqueViolation      = "23505"
+	ForeignKeyViolation  = "23503"
+	SerializationFailure = "40001"
+	DeadlockDetected     = "40P01"
+)
+
+type classification struct {
+	kind      fault.Kind
+	retryable bool
+}
+
+// states classifies individual SQLSTATE codes.
+//
+// Failures which are caused by the server itself (e.g. wrong database credentials,
+// missing privileges or invalid data which the server should have validated)
+// are classified as Internal, so that they aren't surfaced as 4xx responses
+// to clients, which wouldn't be logged.
+var states = map[string]classification{
+	UniqueViolation:      {fault.Conflict, false},
+	"23P01":              {fault.Conflict, false}, // exclusion_violation
+	SerializationFailure: {fault.Conflict, true},
+	DeadlockDetected:     {fault.Conflict, true},
+	"42501":              {fault.Internal, false}, // insufficient_privilege
+	"55P03":              {fault.Conflict, true},  // lock_not_available
+	"57014":              {fault.Timeout, true},   // query_canceled
+}
+
+// classes classifies SQLSTATE codes by their class (first two characters).
+var classes = map[string]classification{
+	"08": {fault.Unavailable, true},       // connection_exception
+	"22": {fault.Internal, false},         // data_exception
+	"23": {fault.Internal, false},         // integrity_constraint_violation
+	"28": {fault.Internal, false},         // invalid_authorization_specification
+	"40": {fault.Conflict, true},          // transaction_rollback
+	"53": {fault.ResourceExhausted, true}, // insufficient_resources
+	"57": {fault.Unavailable, true},       // operator_intervention
+	"58": {fault.Unavailable, true},       // system_error
+	"XX": {fault.Internal, false},         // internal_error
+}
+
+// SQLState returns the SQLSTATE code of the first driver error in the error's chain.
+func SQLState(err error) (string, bool) {
+	return fault.As(err, func(err error) (string, bool) {
+		// nolint: errorlint // Walking the chain with fault.As:
+		if e, ok := err.(interface{ SQLState() string }); ok {
+			return e.SQLState(), true
+		}
+		return "", false
+	})
+}
+
+// IsUniqueViolation reports whether the error has been caused by a unique constraint.
+func IsUniqueViolation(err error) bool {
+	state, ok := SQLState(err)
+	return ok && state == UniqueViolation
+}
+
+// Classify returns the kind and retryability of a database error.
+// It reports false if the error can't be classified.
+//
+// sql.ErrNoRows is classified as NotFound, whereas closed and broken connections
+// are classified as Unavailable. Driver errors are classified by their SQLSTATE code,
+// e.g. unique violations as Conflict and serialization failures as retryable Conflict.
+func Classify(err error) (kind fault.Kind, retryable bool, ok bool) {
+	switch {
+	case err == nil:
+		return "", false, false
+	case errors.Is(err, sql.ErrNoRows):
+		return fault.NotFound, false, true
+	case errors.Is(err, sql.ErrConnDone), errors.Is(err, driver.ErrBadConn):
+		return fault.Unavailable, true, true
+	}
+	state, found := SQLState(err)
+	if !found {
+		return "", false, false
+	}
+	c, found := states[state]
+	if !found && len(state) == 5 {
+		c, found = classes[state[:2]]
+	}
+	if !found {
+		return "", false, false
+	}
+	return c.kind, c.retryable, true
+}
+
+// adapter classifies database errors and attaches their SQLSTATE code.
+var adapter = fault.Adapter{Classify: Classify, Annotate: annotate}
+
+// Wrap wraps a database error into a SystemError, so that the caller can tell from its
+// kind how to respond (e.g. a missing row as NotFound) and whether to retry the
+// transaction (e.g. after a deadlock). The SQLSTATE code of driver errors is attached
+// as the sqlstate field. Errors which aren't database errors are wrapped without a kind,
+// and a nil error results in an Invariant fault (see fault.SystemWrap).
+//
+//	Example:
+//	   err := db.QueryRowContext(ctx, query, id).Scan(&user.Name)
+//	   if err != nil {
+//	      return faultsql.Wrap(err, "failed to load user")
+//	   }
+func Wrap(err error, msg string) *fault.SystemError {
+	return adapter.WrapSkip(err, msg, 1)
+}
+
+// Rule is a fault.Rule for repositories which return database errors unwrapped. It
+// translates them into SystemErrors with the kind, retryability and sqlstate field
+// of Wrap, whose message is the one of the driver. Unclassified errors (e.g. a
+// scan into a wrong type) are left to the other rules.
+//
+//	Example:
+//	   fault.Register(faultsql.Rule)
+func Rule(err error) error {
+	return adapter.Rule(err)
+}
+
+// annotate attaches the SQLSTATE code of the driver error.
+func annotate(sysErr *fault.SystemError, err error) {
+	if state, ok := SQLState(err); ok {
+		sysErr.WithField("sqlstate", state)
+	}
+}
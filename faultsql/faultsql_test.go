@@ -0,0 +1,94 @@
+package faultsql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+type pgError struct {
+	code string
+}
+
+func (e *pgError) Error() string    { return "pg error " + e.code }
+func (e *pgError) SQLState() string { return e.code }
+
+func Test_Classify(t *testing.T) {
+	tests := []struct {
+		err       error
+		kind      fault.Kind
+		retryable bool
+		ok        bool
+	}{
+		{nil, "", false, false},
+		{errors.New("a"), "", false, false},
+		{sql.ErrNoRows, fault.NotFound, false, true},
+		{fmt.Errorf("a: %w", driver.ErrBadConn), fault.Unavailable, true, true},
+		{&pgError{UniqueViolation}, fault.Conflict, false, true},
+		{&pgError{SerializationFailure}, fault.Conflict, true, true},
+		{&pgError{"23502"}, fault.Internal, false, true},
+		{&pgError{"22P02"}, fault.Internal, false, true},
+		{&pgError{"28P01"}, fault.Internal, false, true},
+		{&pgError{"42501"}, fault.Internal, false, true},
+		{&pgError{"08006"}, fault.Unavailable, true, true},
+		{&pgError{"53300"}, fault.ResourceExhausted, true, true},
+		{&pgError{"57014"}, fault.Timeout, true, true},
+		{&pgError{"P0001"}, "", false, false},
+	}
+	for _, test := range tests {
+		kind, retryable, ok := Classify(test.err)
+		actual := fmt.Sprint(kind, retryable, ok)
+		expected := fmt.Sprint(test.kind, test.retryable, test.ok)
+		if actual != expected {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
+
+func Test_Wrap(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	sysErr := Wrap(fmt.Errorf("insert: %w", &pgError{UniqueViolation}), "failed to create user")
+
+	if sysErr.Kind() != fault.Conflict || sysErr.Retryable() {
+		t.Errorf(expectedFormat, "conflict false", fmt.Sprint(sysErr.Kind(), sysErr.Retryable()))
+	}
+	if actual := sysErr.Fields()["sqlstate"]; actual != UniqueViolation {
+		t.Errorf(expectedFormat, UniqueViolation, actual)
+	}
+	if !IsUniqueViolation(sysErr) {
+		t.Error("err was expected to be a unique violation")
+	}
+	if expected := fmt.Sprintf("%s:%d: failed to create user", file, line+1); !strings.HasSuffix(sysErr.StackTrace(), expected) {
+		t.Errorf(expectedFormat, expected, sysErr.StackTrace())
+	}
+	if actual := Wrap(nil, "a").Kind(); actual != fault.Bug {
+		t.Errorf(expectedFormat, fault.Bug, actual)
+	}
+}
+
+func Test_Rule(t *testing.T) {
+	tr := &fault.Translator{}
+	tr.Register(Rule)
+
+	translated := tr.Translate(sql.ErrNoRows)
+	if actual := fault.KindOf(translated); actual != fault.NotFound {
+		t.Errorf(expectedFormat, fault.NotFound, actual)
+	}
+	if translated.Error() != sql.ErrNoRows.Error() {
+		t.Errorf(expectedFormat, sql.ErrNoRows, translated)
+	}
+	err := errors.New("a")
+	if actual := tr.Translate(err); actual != err {
+		t.Errorf(expectedFormat, err, actual)
+	}
+}
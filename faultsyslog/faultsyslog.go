@@ -0,0 +1,243 @@
+// Package faultsyslog emits faults as RFC 5424 syslog messages.
+//
+// The classification of a fault is written as structured data, so that syslog
+// collectors can index the kind, codes, fingerprint and fields of a fault without
+// parsing the message:
+//
+//	<11>1 2024-01-02T15:04:05.000000Z host app 42 - [fault@32473 kind="timeout" ref="8f2c..."][fields@32473 user_id="42"] failed to load user: context deadline exceeded
+package faultsyslog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// Facility is the syslog facility of a message.
+type Facility int
+
+// Facilities which are commonly used by applications.
+const (
+	FacilityUser   Facility = 1
+	FacilityDaemon Facility = 3
+	FacilityLocal0 Facility = 16
+	FacilityLocal1 Facility = 17
+	FacilityLocal2 Facility = 18
+	FacilityLocal3 Facility = 19
+	FacilityLocal4 Facility = 20
+	FacilityLocal5 Facility = 21
+	FacilityLocal6 Facility = 22
+	FacilityLocal7 Facility = 23
+)
+
+// Syslog severities as defined by RFC 5424.
+const (
+	SeverityCritical = 2
+	SeverityError    = 3
+	SeverityWarning  = 4
+	SeverityInfo     = 6
+)
+
+// DefaultEnterpriseID is the private enterprise number of the structured data IDs
+// if none has been set. It is the number which RFC 5424 reserves for documentation.
+const DefaultEnterpriseID = "32473"
+
+// SeverityCode maps the severity of a fault to a syslog severity.
+// Faults without a severity are mapped to SeverityError.
+func SeverityCode(s fault.Severity) int {
+	switch s {
+	case fault.SeverityCritical:
+		return SeverityCritical
+	case fault.SeverityWarning:
+		return SeverityWarning
+	case fault.SeverityInfo:
+		return SeverityInfo
+	default:
+		return SeverityError
+	}
+}
+
+// Writer writes faults as RFC 5424 messages, each terminated by a newline,
+// which is the framing that syslog collectors expect on stream connections.
+//
+//	Example:
+//	   conn, err := net.Dial("udp", "syslog.internal:514")
+//	   ...
+//	   w := &faultsyslog.Writer{W: conn, Facility: faultsyslog.FacilityLocal0}
+//	   sub := fault.Subscribe(w.Listener())
+//	   defer sub.Close()
+type Writer struct {
+	// W receives the messages, typically a connection to a syslog collector.
+	W io.Writer
+
+	// Facility is the facility of the messages. Defaults to FacilityUser.
+	Facility Facility
+
+	// Hostname and AppName identify the sender. They default
+	// to the hostname and the name of the executable.
+	Hostname string
+	AppName  string
+
+	// EnterpriseID is the private enterprise number of the structured data IDs.
+	// Defaults to DefaultEnterpriseID.
+	EnterpriseID string
+
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// WriteFault writes the error as a syslog message.
+// Nothing will be written if the error is nil.
+func (w *Writer) WriteFault(err error) error {
+	if err == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, writeErr := io.WriteString(w.W, w.format(err)+"\n"); writeErr != nil {
+		return fault.SystemWrap(writeErr, "failed to write syslog message")
+	}
+	return nil
+}
+
+// Listener returns a fault.Listener which writes the published errors.
+func (w *Writer) Listener() fault.Listener {
+	return func(err error) {
+		if writeErr := w.WriteFault(err); writeErr != nil {
+			log.Printf("faultsyslog: %v", writeErr)
+		}
+	}
+}
+
+func (w *Writer) format(err error) string {
+	facility := w.Facility
+	if facility == 0 {
+		facility = FacilityUser
+	}
+	now := time.Now
+	if w.now != nil {
+		now = w.now
+	}
+	hostname := w.Hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+	appName := w.AppName
+	if appName == "" {
+		appName = filepath.Base(os.Args[0])
+	}
+	pri := int(facility)*8 + SeverityCode(fault.SeverityOf(err))
+	return fmt.Sprintf("<%d>1 %s %s %s %d - %s %s",
+		pri,
+		now().UTC().Format("2006-01-02T15:04:05.000000Z"),
+		headerField(hostname),
+		headerField(appName),
+		os.Getpid(),
+		StructuredData(err, w.EnterpriseID),
+		Message(err))
+}
+
+// StructuredData returns the RFC 5424 structured data elements of the error:
+// the fault element with the kind, the codes of a UserError, the fingerprint as ref
+// and the op of a SystemError, and the fields element with the attached fields,
+// which are scrubbed by the fault.DefaultScrubber.
+func StructuredData(err error, enterpriseID string) string {
+	if enterpriseID == "" {
+		enterpriseID = DefaultEnterpriseID
+	}
+	sb := strings.Builder{}
+	sb.WriteString("[fault@" + enterpriseID)
+	writeParam(&sb, "kind", fault.KindOf(err).String())
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		codes := make([]string, 0, len(userErr.Codes()))
+		for _, code := range userErr.Codes() {
+			codes = append(codes, code.String())
+		}
+		writeParam(&sb, "code", strings.Join(codes, ","))
+	}
+	writeParam(&sb, "ref", fault.Fingerprint(err))
+	var sysErr *fault.SystemError
+	if !errors.As(err, &sysErr) {
+		sb.WriteString("]")
+		return sb.String()
+	}
+	if op := sysErr.Op(); op != "" {
+		writeParam(&sb, "op", string(op))
+	}
+	sb.WriteString("]")
+
+	fields := fault.ScrubFields(sysErr.Fields())
+	if len(fields) == 0 {
+		return sb.String()
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sb.WriteString("[fields@" + enterpriseID)
+	for _, k := range keys {
+		writeParam(&sb, paramName(k), fmt.Sprint(fields[k]))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// Message returns the message of the error on a single line: the message
+// chain of a SystemError is joined by colons and the messages of a UserError
+// are joined by semicolons. The message is scrubbed by the fault.DefaultScrubber.
+func Message(err error) string {
+	var sysErr *fault.SystemError
+	if errors.As(err, &sysErr) {
+		return fault.Scrub(strings.Join(sysErr.Messages(), ": "))
+	}
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		return strings.Join(strings.Split(userErr.Scrub().Error(), "\n"), "; ")
+	}
+	return fault.Scrub(strings.ReplaceAll(err.Error(), "\n", " "))
+}
+
+// sdEscaper escapes the characters which must be escaped in a parameter value.
+var sdEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`, `]`, `\]`)
+
+func writeParam(sb *strings.Builder, name string, value string) {
+	sb.WriteString(fmt.Sprintf(` %s="%s"`, name, sdEscaper.Replace(value)))
+}
+
+// paramName turns a field key into a valid parameter name, which consists of
+// at most 32 printable ASCII characters other than '=', ' ', ']' and '"'.
+func paramName(key string) string {
+	name := []byte(key)
+	for i, c := range name {
+		if c <= ' ' || c > '~' || c == '=' || c == ']' || c == '"' {
+			name[i] = '_'
+		}
+	}
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	if len(name) == 0 {
+		return "_"
+	}
+	return string(name)
+}
+
+// headerField returns the value of a header field, or the nil value "-" if it is empty.
+func headerField(value string) string {
+	value = strings.ReplaceAll(value, " ", "_")
+	if value == "" {
+		return "-"
+	}
+	return value
+}
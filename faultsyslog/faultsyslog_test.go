@@ -0,0 +1,102 @@
+package faultsyslog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+
+func Test_SeverityCode(t *testing.T) {
+	testCases := map[fault.Severity]int{
+		0:                      SeverityError,
+		fault.SeverityInfo:     SeverityInfo,
+		fault.SeverityWarning:  SeverityWarning,
+		fault.SeverityError:    SeverityError,
+		fault.SeverityCritical: SeverityCritical,
+	}
+	for severity, expected := range testCases {
+		if actual := SeverityCode(severity); actual != expected {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
+
+func Test_Writer_WriteFault(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := &Writer{W: buf, Facility: FacilityLocal0, Hostname: "host", AppName: "app"}
+	w.now = func() time.Time { return time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC) }
+	err := fault.SystemWrap(errors.New("connection refused"), "failed to load user").
+		WithKind(fault.Unavailable).
+		WithSeverity(fault.SeverityCritical).
+		WithField("user id", 42).
+		WithField("query", `SELECT "name"`)
+
+	if writeErr := w.WriteFault(err); writeErr != nil {
+		t.Fatal(writeErr)
+	}
+
+	expected := fmt.Sprintf(
+		`<130>1 2024-01-02T15:04:05.000000Z host app %d - `+
+			`[fault@32473 kind="unavailable" ref="%s"][fields@32473 query="SELECT \"name\"" user_id="42"] `+
+			"failed to load user: connection refused\n",
+		os.Getpid(), fault.Fingerprint(err))
+	if actual := buf.String(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Writer_WriteFault_Nil(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := &Writer{W: buf}
+
+	if err := w.WriteFault(nil); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf(expectedFormat, "", buf.String())
+	}
+}
+
+func Test_StructuredData_UserError(t *testing.T) {
+	err := fault.User("MISSING_NAME", "Please provide a name.")
+	err.Add("MISSING_EMAIL", "Please provide an email address.")
+
+	actual := StructuredData(err, "1234")
+
+	expected := `[fault@1234 kind="invalid_argument" code="MISSING_NAME,MISSING_EMAIL" ref="`
+	if !strings.HasPrefix(actual, expected) || strings.Contains(actual, "fields@") {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Message_UserError(t *testing.T) {
+	err := fault.User("MISSING_NAME", "Please provide a name.")
+	err.Add("MISSING_EMAIL", "Please provide an email address.")
+
+	expected := "- Please provide a name. (MISSING_NAME); - Please provide an email address. (MISSING_EMAIL)"
+	if actual := Message(err); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_paramName(t *testing.T) {
+	testCases := map[string]string{
+		"user_id":   "user_id",
+		`a b=c]d"e`: "a_b_c_d_e",
+		"":          "_",
+		"a_very_long_field_name_with_more_than_32_chars": "a_very_long_field_name_with_more",
+	}
+	for key, expected := range testCases {
+		if actual := paramName(key); actual != expected {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
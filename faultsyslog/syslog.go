@@ -0,0 +1,45 @@
+//go:build !windows && !plan9
+
+package faultsyslog
+
+import (
+	"log/syslog"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// Priority maps the severity of a fault to the syslog priority of the log/syslog package.
+func Priority(s fault.Severity, facility syslog.Priority) syslog.Priority {
+	return facility | syslog.Priority(SeverityCode(s))
+}
+
+// Log writes the error to a writer of the log/syslog package with the priority
+// of the fault's severity. Since log/syslog writes RFC 3164 messages, the structured
+// data elements are prepended to the message (see StructuredData).
+// Nothing will be written if the error is nil.
+//
+//	Example:
+//	   w, err := syslog.New(syslog.LOG_LOCAL0, "app")
+//	   ...
+//	   faultsyslog.Log(w, err)
+func Log(w *syslog.Writer, err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := StructuredData(err, "") + " " + Message(err)
+	var writeErr error
+	switch SeverityCode(fault.SeverityOf(err)) {
+	case SeverityCritical:
+		writeErr = w.Crit(msg)
+	case SeverityWarning:
+		writeErr = w.Warning(msg)
+	case SeverityInfo:
+		writeErr = w.Info(msg)
+	default:
+		writeErr = w.Err(msg)
+	}
+	if writeErr != nil {
+		return fault.SystemWrap(writeErr, "failed to write syslog message")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+//go:build !windows && !plan9
+
+package faultsyslog
+
+import (
+	"log/syslog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_Priority(t *testing.T) {
+	expected := syslog.LOG_LOCAL0 | syslog.LOG_CRIT
+	if actual := Priority(fault.SeverityCritical, syslog.LOG_LOCAL0); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Log(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer conn.Close()
+	w, err := syslog.Dial("udp", conn.LocalAddr().String(), syslog.LOG_LOCAL0, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	if logErr := Log(w, fault.System("boom").WithSeverity(fault.SeverityWarning)); logErr != nil {
+		t.Fatal(logErr)
+	}
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := string(buf[:n])
+	expected := `<132>`
+	if !strings.HasPrefix(actual, expected) || !strings.Contains(actual, `[fault@32473 kind="internal" ref="`) ||
+		!strings.HasSuffix(strings.TrimSpace(actual), "] boom") {
+		t.Errorf(expectedFormat, expected+`... [fault@32473 kind="internal" ref="..."] boom`, actual)
+	}
+}
@@ -0,0 +1,65 @@
+package faulttest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// errorView is the representation of an error which gets compared by CmpOptions.
+type errorView struct {
+	Message    string
+	UserErrors map[string]string
+	UserCodes  []fault.Code
+	Kind       fault.Kind
+	Fields     map[string]interface{}
+	RetryAfter time.Duration
+}
+
+// CmpOptions returns options for github.com/google/go-cmp/cmp which compare errors
+// (including errors which are embedded in larger structs) by their messages, the codes
+// and messages of a UserError and the kind, fields and retry hint of a SystemError.
+// Stack traces and fields with time.Time values are ignored.
+//
+// The options must not be combined with other options which apply to errors
+// (e.g. cmpopts.EquateErrors), since cmp rejects ambiguous options.
+//
+//	Example:
+//	   if diff := cmp.Diff(expected, actual, faulttest.CmpOptions()); diff != "" {
+//	      t.Errorf("mismatch (-expected +actual):\n%s", diff)
+//	   }
+func CmpOptions() cmp.Options {
+	return cmp.Options{
+		cmp.Comparer(func(a, b error) bool {
+			if a == nil || b == nil {
+				return a == nil && b == nil
+			}
+			return cmp.Equal(viewOf(a), viewOf(b))
+		}),
+	}
+}
+
+func viewOf(err error) errorView {
+	view := errorView{Message: err.Error()}
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		view.UserErrors = userErr.Errors()
+		view.UserCodes = userErr.Codes()
+	}
+	var sysErr *fault.SystemError
+	if errors.As(err, &sysErr) {
+		view.Kind = sysErr.Kind()
+		view.Fields = map[string]interface{}{}
+		for k, v := range sysErr.Fields() {
+			if _, ok := v.(time.Time); ok {
+				continue
+			}
+			view.Fields[k] = v
+		}
+	}
+	view.RetryAfter, _ = fault.RetryAfter(err)
+	return view
+}
@@ -0,0 +1,67 @@
+package faulttest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+type result struct {
+	ID  string
+	Err error
+}
+
+func newSystemError(userID string) error {
+	return fault.SystemWrap(errors.New("connection refused"), "failed to load user").
+		WithKind(fault.Unavailable).
+		WithField("user_id", userID).
+		WithField("time", time.Now())
+}
+
+func Test_CmpOptions_WithEqualSystemErrors(t *testing.T) {
+	expected := result{ID: "a", Err: newSystemError("123")}
+	actual := result{ID: "a", Err: newSystemError("123")}
+
+	if diff := cmp.Diff(expected, actual, CmpOptions()); diff != "" {
+		t.Errorf(expectedFormat, "", diff)
+	}
+}
+
+func Test_CmpOptions_WithDifferentFields(t *testing.T) {
+	expected := result{ID: "a", Err: newSystemError("123")}
+	actual := result{ID: "a", Err: newSystemError("456")}
+
+	if cmp.Equal(expected, actual, CmpOptions()) {
+		t.Error("Errors with different fields were not expected to be equal.")
+	}
+}
+
+func Test_CmpOptions_WithUserErrors(t *testing.T) {
+	newUserError := func(codes ...fault.Code) *fault.UserError {
+		userErr := fault.User(codes[0], "message "+codes[0].String())
+		for _, code := range codes[1:] {
+			userErr.Add(code, "message "+code.String())
+		}
+		return userErr
+	}
+
+	if !cmp.Equal(newUserError("a", "b"), newUserError("a", "b"), CmpOptions()) {
+		t.Error("User errors with the same codes were expected to be equal.")
+	}
+	if cmp.Equal(newUserError("a", "b"), newUserError("b", "a"), CmpOptions()) {
+		t.Error("User errors with a different order of codes were not expected to be equal.")
+	}
+}
+
+func Test_CmpOptions_WithNil(t *testing.T) {
+	if !cmp.Equal(result{}, result{}, CmpOptions()) {
+		t.Error("Nil errors were expected to be equal.")
+	}
+	if cmp.Equal(result{}, result{Err: errors.New("a")}, CmpOptions()) {
+		t.Error("A nil error was not expected to equal a non nil error.")
+	}
+}
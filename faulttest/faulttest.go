@@ -0,0 +1,133 @@
+// Package faulttest provides assertions for faults in tests.
+package faulttest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// TestingT is the subset of testing.TB which is used by the assertions.
+// It is compatible with the TestingT interfaces of testify's assert and require packages.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+type tHelper interface {
+	Helper()
+}
+
+const (
+	failureFormat = "%s\n\nexpected:\n%s\n\nactual:\n%s\n\nchain:\n%s"
+)
+
+// AssertUserCode asserts that the error's chain contains a UserError with the given code.
+//
+//	Example:
+//	   faulttest.AssertUserCode(t, err, "MISSING_EMAIL")
+func AssertUserCode(t TestingT, err error, code fault.Code) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var userErr *fault.UserError
+	if !errors.As(err, &userErr) {
+		t.Errorf(failureFormat, "Error is not a user error.", code, "no user error", Chain(err))
+		return false
+	}
+	if !userErr.HasCode(code) {
+		codes := make([]string, 0, len(userErr.Codes()))
+		for _, c := range userErr.Codes() {
+			codes = append(codes, c.String())
+		}
+		t.Errorf(failureFormat, "User error doesn't have the expected code.",
+			code, strings.Join(codes, ", "), Chain(err))
+		return false
+	}
+	return true
+}
+
+// AssertKind asserts that the kind of the error is the given kind (see fault.KindOf).
+//
+//	Example:
+//	   faulttest.AssertKind(t, err, fault.Timeout)
+func AssertKind(t TestingT, err error, kind fault.Kind) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if err == nil {
+		t.Errorf(failureFormat, "Error is nil.", kind, "nil", Chain(err))
+		return false
+	}
+	if actual := fault.KindOf(err); actual != kind {
+		t.Errorf(failureFormat, "Error doesn't have the expected kind.", kind, actual, Chain(err))
+		return false
+	}
+	return true
+}
+
+// AssertChainContains asserts that the message of an error in the
+// error's chain (or of a layer of a SystemError) contains the given text.
+//
+//	Example:
+//	   faulttest.AssertChainContains(t, err, "connecting to db")
+func AssertChainContains(t TestingT, err error, text string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	for _, msg := range messages(err) {
+		if strings.Contains(msg, text) {
+			return true
+		}
+	}
+	t.Errorf(failureFormat, "Error chain doesn't contain the expected message.",
+		text, firstLine(err), Chain(err))
+	return false
+}
+
+// Chain returns a readable description of the error's chain (see fault.Flatten),
+// listing each error with its type and message on a separate line.
+func Chain(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	sb := strings.Builder{}
+	for i, e := range fault.Flatten(err) {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		msg, _, _ := strings.Cut(e.Message, "\n")
+		switch e.Type {
+		case "*fault.UserError":
+			sb.WriteString(fmt.Sprintf("%d. %s %s", i, e.Type, strings.ReplaceAll(e.Message, "\n", "; ")))
+		case "*fault.SystemError":
+			sb.WriteString(fmt.Sprintf("%d. %s %q kind=%s", i, e.Type, msg, e.Kind))
+		default:
+			sb.WriteString(fmt.Sprintf("%d. %s %q", i, e.Type, msg))
+		}
+	}
+	return sb.String()
+}
+
+func messages(err error) []string {
+	var msgs []string
+	for err != nil {
+		// nolint: errorlint // Walking the chain manually:
+		if sysErr, ok := err.(*fault.SystemError); ok {
+			msgs = append(msgs, sysErr.Messages()...)
+		} else {
+			msgs = append(msgs, err.Error())
+		}
+		err = errors.Unwrap(err)
+	}
+	return msgs
+}
+
+func firstLine(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	msg, _, _ := strings.Cut(err.Error(), "\n")
+	return msg
+}
@@ -0,0 +1,88 @@
+package faulttest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+type recorder struct {
+	failures []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func Test_AssertUserCode(t *testing.T) {
+	userErr := fault.User("MISSING_EMAIL", "Please provide an email address.")
+	err := fault.SystemWrap(userErr, "failed to register user")
+
+	r := &recorder{}
+	if !AssertUserCode(r, err, "MISSING_EMAIL") {
+		t.Errorf(expectedFormat, nil, r.failures)
+	}
+	if AssertUserCode(r, err, "MISSING_NAME") {
+		t.Error("AssertUserCode was expected to fail for a missing code.")
+	}
+	if AssertUserCode(r, errors.New("foo"), "MISSING_EMAIL") {
+		t.Error("AssertUserCode was expected to fail for a non user error.")
+	}
+	if len(r.failures) != 2 {
+		t.Fatalf(expectedFormat, 2, len(r.failures))
+	}
+}
+
+func Test_AssertKind(t *testing.T) {
+	err := fault.System("connection timed out").WithKind(fault.Timeout)
+
+	r := &recorder{}
+	if !AssertKind(r, err, fault.Timeout) {
+		t.Errorf(expectedFormat, nil, r.failures)
+	}
+	if AssertKind(r, err, fault.NotFound) {
+		t.Error("AssertKind was expected to fail for a different kind.")
+	}
+	if AssertKind(r, nil, fault.Internal) {
+		t.Error("AssertKind was expected to fail for a nil error.")
+	}
+}
+
+func Test_AssertChainContains(t *testing.T) {
+	err := fault.SystemWrap(
+		fmt.Errorf("connecting to db: %w", errors.New("connection refused")),
+		"failed to load user")
+
+	r := &recorder{}
+	if !AssertChainContains(r, err, "connecting to db") {
+		t.Errorf(expectedFormat, nil, r.failures)
+	}
+	if !AssertChainContains(r, err, "failed to load user") {
+		t.Errorf(expectedFormat, nil, r.failures)
+	}
+	if AssertChainContains(r, err, "timeout") {
+		t.Error("AssertChainContains was expected to fail for a missing message.")
+	}
+	expected := `Error chain doesn't contain the expected message.
+
+expected:
+timeout
+
+actual:
+failed to load user
+
+chain:
+0. *fault.SystemError "failed to load user" kind=internal
+1. *fmt.wrapError "connecting to db: connection refused"
+2. *errors.errorString "connection refused"`
+	if len(r.failures) != 1 || r.failures[0] != expected {
+		t.Errorf(expectedFormat, expected, strings.Join(r.failures, "\n---\n"))
+	}
+}
@@ -0,0 +1,78 @@
+package faulttest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// Matcher matches errors.
+//
+// It implements the GomegaMatcher interface, so it can be used with gomega:
+//
+//	Expect(err).To(faulttest.HaveUserCode("MISSING_EMAIL"))
+//
+// Matches can be used with testify:
+//
+//	assert.True(t, faulttest.BeKind(fault.NotFound).Matches(err))
+//	mock.MatchedBy(faulttest.HaveUserCode("MISSING_EMAIL").Matches)
+type Matcher struct {
+	description string
+	match       func(err error) bool
+}
+
+// HaveUserCode returns a Matcher which succeeds if the error's
+// chain contains a UserError with the given code.
+func HaveUserCode(code fault.Code) Matcher {
+	return Matcher{
+		description: fmt.Sprintf("to have user code %q", code),
+		match: func(err error) bool {
+			var userErr *fault.UserError
+			return errors.As(err, &userErr) && userErr.HasCode(code)
+		},
+	}
+}
+
+// BeKind returns a Matcher which succeeds if the kind of the error
+// is the given kind (see fault.KindOf).
+func BeKind(kind fault.Kind) Matcher {
+	return Matcher{
+		description: fmt.Sprintf("to be of kind %q", kind),
+		match: func(err error) bool {
+			return err != nil && fault.KindOf(err) == kind
+		},
+	}
+}
+
+// Matches reports whether the error matches.
+func (m Matcher) Matches(err error) bool {
+	return m.match(err)
+}
+
+// Match implements the GomegaMatcher interface.
+func (m Matcher) Match(actual interface{}) (bool, error) {
+	if actual == nil {
+		return false, nil
+	}
+	err, ok := actual.(error)
+	if !ok {
+		return false, fmt.Errorf("expected an error, got:\n\t%#v", actual)
+	}
+	return m.match(err), nil
+}
+
+// FailureMessage implements the GomegaMatcher interface.
+func (m Matcher) FailureMessage(actual interface{}) string {
+	return m.message(actual, m.description)
+}
+
+// NegatedFailureMessage implements the GomegaMatcher interface.
+func (m Matcher) NegatedFailureMessage(actual interface{}) string {
+	return m.message(actual, "not "+m.description)
+}
+
+func (m Matcher) message(actual interface{}, description string) string {
+	err, _ := actual.(error)
+	return fmt.Sprintf("Expected\n%s\n%s", Chain(err), description)
+}
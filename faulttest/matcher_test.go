@@ -0,0 +1,53 @@
+package faulttest
+
+import (
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// gomegaMatcher mirrors the GomegaMatcher interface of github.com/onsi/gomega/types.
+type gomegaMatcher interface {
+	Match(actual interface{}) (bool, error)
+	FailureMessage(actual interface{}) string
+	NegatedFailureMessage(actual interface{}) string
+}
+
+var _ gomegaMatcher = Matcher{}
+
+func Test_HaveUserCode(t *testing.T) {
+	err := fault.SystemWrap(fault.User("MISSING_EMAIL", "Please provide an email address."), "a")
+
+	if !HaveUserCode("MISSING_EMAIL").Matches(err) {
+		t.Error("HaveUserCode was expected to match.")
+	}
+	if HaveUserCode("MISSING_NAME").Matches(err) {
+		t.Error("HaveUserCode was not expected to match a different code.")
+	}
+	if ok, _ := HaveUserCode("MISSING_EMAIL").Match(nil); ok {
+		t.Error("HaveUserCode was not expected to match nil.")
+	}
+}
+
+func Test_BeKind(t *testing.T) {
+	err := fault.System("a").WithKind(fault.NotFound)
+
+	if ok, matchErr := BeKind(fault.NotFound).Match(err); !ok || matchErr != nil {
+		t.Errorf(expectedFormat, true, matchErr)
+	}
+	if BeKind(fault.Timeout).Matches(err) {
+		t.Error("BeKind was not expected to match a different kind.")
+	}
+	if _, matchErr := BeKind(fault.NotFound).Match("a"); matchErr == nil {
+		t.Error("BeKind was expected to return an error for a non error value.")
+	}
+}
+
+func Test_Matcher_FailureMessage(t *testing.T) {
+	err := fault.System("a").WithKind(fault.NotFound)
+
+	expected := "Expected\n0. *fault.SystemError \"a\" kind=not_found\nnot to be of kind \"not_found\""
+	if actual := BeKind(fault.NotFound).NegatedFailureMessage(err); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
@@ -0,0 +1,29 @@
+package faulttest
+
+import "regexp"
+
+var (
+	goFilePath  = regexp.MustCompile(`(?:[A-Za-z]:)?(?:(?:/|\\{1,2})[^/\\\s"'():]+)*(?:/|\\{1,2})([^/\\\s"'():]+\.go)\b`)
+	lineNumber  = regexp.MustCompile(`\.go:\d+`)
+	goroutineID = regexp.MustCompile(`goroutine \d+`)
+	pcOffset    = regexp.MustCompile(` \+0x[0-9a-f]+`)
+)
+
+// Normalize replaces the unstable parts of a rendered fault (e.g. the output of
+// String(), ErrorReport() or a JSON log entry) with stable placeholders, so that it
+// can be compared against a golden file. The directories of Go files are replaced
+// by <path>, line numbers by <line> and goroutine IDs by <id>. Program counter
+// offsets (e.g. +0x1d) are removed.
+//
+//	Example:
+//	   at /home/dev/app/store/repo.go:42
+//
+// becomes:
+//
+//	at <path>/repo.go:<line>
+func Normalize(s string) string {
+	s = goFilePath.ReplaceAllString(s, "<path>/$1")
+	s = lineNumber.ReplaceAllString(s, ".go:<line>")
+	s = goroutineID.ReplaceAllString(s, "goroutine <id>")
+	return pcOffset.ReplaceAllString(s, "")
+}
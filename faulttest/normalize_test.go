@@ -0,0 +1,57 @@
+package faulttest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_Normalize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "String",
+			input:    "boom\n\nat /home/dev/app/store/repo.go:42\n   --> store.(*Repo).Get",
+			expected: "boom\n\nat <path>/repo.go:<line>\n   --> store.(*Repo).Get",
+		},
+		{
+			name:     "RuntimeString",
+			input:    "goroutine 17 [running]:\nmain.main()\n\t/app/main.go:12 +0x1d",
+			expected: "goroutine <id> [running]:\nmain.main()\n\t<path>/main.go:<line>",
+		},
+		{
+			name:     "JSON",
+			input:    `{"stack":"\nat C:\\src\\app\\main.go:7\n   --> main.main"}`,
+			expected: `{"stack":"\nat <path>/main.go:<line>\n   --> main.main"}`,
+		},
+		{
+			name:     "Relative path",
+			input:    "at main.go:7",
+			expected: "at main.go:<line>",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			actual := Normalize(tc.input)
+			if actual != tc.expected {
+				t.Errorf(expectedFormat, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_Normalize_WithSystemError(t *testing.T) {
+	err := fault.System("boom")
+
+	expected := "boom\n\nat <path>/normalize_test.go:<line>\n   --> faulttest.Test_Normalize_WithSystemError"
+	actual := Normalize(fmt.Sprintf("%+v", err))
+	if actual[:len(expected)] != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
@@ -0,0 +1,116 @@
+package faulttest
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// AssertRoundTrip asserts that the error survives all serialization formats of the
+// fault package: fault.Encode/fault.Decode and, if the error is a UserError or SystemError,
+// JSON and gob. The restored errors must have the same messages, stack trace text,
+// user error codes, kind, retry hint and fields (compared by their string representation).
+//
+//	Example:
+//	   func FuzzMyErrors(f *testing.F) {
+//	      f.Fuzz(func(t *testing.T, msg string) {
+//	         faulttest.AssertRoundTrip(t, newMyError(msg))
+//	      })
+//	   }
+func AssertRoundTrip(t TestingT, err error) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	ok := assertSame(t, "Encode/Decode", err, fault.Decode(fault.Encode(err)))
+
+	// nolint: errorlint // Only the outermost error can be serialized directly:
+	switch e := err.(type) {
+	case *fault.UserError:
+		ok = assertJSON(t, e, &fault.UserError{}) && ok
+		ok = assertGob(t, e, &fault.UserError{}) && ok
+	case *fault.SystemError:
+		ok = assertJSON(t, e, &fault.SystemError{}) && ok
+		ok = assertGob(t, e, &fault.SystemError{}) && ok
+	}
+	return ok
+}
+
+func assertJSON(t TestingT, err error, target error) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	data, marshalErr := json.Marshal(err)
+	if marshalErr == nil {
+		marshalErr = json.Unmarshal(data, target)
+	}
+	if marshalErr != nil {
+		t.Errorf("JSON round trip failed: %v", marshalErr)
+		return false
+	}
+	return assertSame(t, "JSON", err, target)
+}
+
+func assertGob(t TestingT, err error, target error) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var buf bytes.Buffer
+	gobErr := gob.NewEncoder(&buf).Encode(err)
+	if gobErr == nil {
+		gobErr = gob.NewDecoder(&buf).Decode(target)
+	}
+	if gobErr != nil {
+		t.Errorf("gob round trip failed: %v", gobErr)
+		return false
+	}
+	return assertSame(t, "gob", err, target)
+}
+
+func assertSame(t TestingT, format string, expected, actual error) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	e, a := describe(expected), describe(actual)
+	if e != a {
+		t.Errorf("%s round trip doesn't preserve the error.\n\nexpected:\n%s\n\nactual:\n%s", format, e, a)
+		return false
+	}
+	return true
+}
+
+// describe returns a description of all properties which must survive a round trip.
+func describe(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("message: %q\n", err.Error()))
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		sb.WriteString(fmt.Sprintf("codes: %q\n", userErr.Codes()))
+		sb.WriteString(fmt.Sprintf("messages: %q\n", userErr.ErrorMessages()))
+	}
+	var sysErr *fault.SystemError
+	if errors.As(err, &sysErr) {
+		sb.WriteString(fmt.Sprintf("stack: %q\n", sysErr.StackTrace()))
+		sb.WriteString(fmt.Sprintf("kind: %s\n", sysErr.Kind()))
+		fields := sysErr.Fields()
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sb.WriteString(fmt.Sprintf("field %q: %q\n", k, fmt.Sprint(fields[k])))
+		}
+	}
+	retryAfter, _ := fault.RetryAfter(err)
+	sb.WriteString(fmt.Sprintf("retry after: %s", retryAfter))
+	return sb.String()
+}
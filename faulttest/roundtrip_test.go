@@ -0,0 +1,64 @@
+package faulttest
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_AssertRoundTrip(t *testing.T) {
+	userErr := fault.User("MISSING_NAME", "Bitte gib deinen Namen an. 🙂")
+	userErr.Add("INVALID_EMAIL", "メールアドレスが無効です")
+	userErr.WithRetryAfter(time.Minute)
+
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{name: "nil", err: nil},
+		{name: "plain error", err: errors.New("a")},
+		{name: "user error", err: userErr},
+		{
+			name: "system error",
+			err: fault.SystemWrap(userErr, "failed to create user").
+				WithKind(fault.Conflict).
+				WithField("attempt", 2).
+				WithField("user_id", "123"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			AssertRoundTrip(t, tc.err)
+		})
+	}
+}
+
+func Test_AssertRoundTrip_WithLossyError(t *testing.T) {
+	r := &recorder{}
+
+	if AssertRoundTrip(r, fault.System("a").WithField("time", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))) {
+		t.Error("AssertRoundTrip was expected to fail for a field which changes its representation.")
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add("MISSING_NAME", "Please provide a name.", "failed to create user", "user_id", "123")
+	f.Add("", "", "", "", "")
+	f.Add("ÜNICODE", "ゆにこーど\n\t\"quoted\"", "<html>&amp;", "ключ", "🙂")
+
+	f.Fuzz(func(t *testing.T, code, msg, sysMsg, key, value string) {
+		for _, s := range []string{code, msg, sysMsg, key, value} {
+			if !utf8.ValidString(s) {
+				t.Skip("JSON replaces invalid UTF-8")
+			}
+		}
+		userErr := fault.User(fault.Code(code), msg)
+		AssertRoundTrip(t, userErr)
+		AssertRoundTrip(t, fault.SystemWrap(userErr, sysMsg).WithField(key, value))
+	})
+}
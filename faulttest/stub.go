@@ -0,0 +1,44 @@
+package faulttest
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+	"github.com/dusted-go/fault/stack"
+)
+
+// StubFrame is the frame of the stub stack trace when no frames have been given.
+var StubFrame = runtime.Frame{Function: "main.main", File: "main.go", Line: 1}
+
+type stubCapturer struct {
+	frames []runtime.Frame
+}
+
+func (c stubCapturer) Capture() *stack.Trace {
+	return stack.Synthetic(c.frames...)
+}
+
+func (c stubCapturer) CapturePanic() *stack.Trace {
+	return stack.Synthetic(c.frames...)
+}
+
+// StubStack makes all SystemErrors which are created during the test have
+// a stack trace which consists of the given frames (or the StubFrame).
+// The default stack capturing gets restored when the test finishes.
+//
+// StubStack replaces the stack capturer globally and must not be used
+// in parallel tests.
+//
+//	Example:
+//	   faulttest.StubStack(t)
+//	   err := fault.System("boom")
+//	   // err.StackTrace() == "\nat main.go:1\n   --> main.main"
+func StubStack(t testing.TB, frames ...runtime.Frame) {
+	t.Helper()
+	if len(frames) == 0 {
+		frames = []runtime.Frame{StubFrame}
+	}
+	restore := fault.SetCapturer(stubCapturer{frames: frames})
+	t.Cleanup(restore)
+}
@@ -0,0 +1,44 @@
+package faulttest
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_StubStack_WithDefaultFrame(t *testing.T) {
+	StubStack(t)
+
+	err := fault.SystemWrap(fault.System("a"), "b")
+
+	expected := "b\n   a\n\nat main.go:1\n   --> main.main"
+	if actual := err.String(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_StubStack_WithFrames(t *testing.T) {
+	StubStack(t,
+		runtime.Frame{Function: "github.com/acme/app/store.(*Repo).Get", File: "store/repo.go", Line: 42},
+		runtime.Frame{Function: "main.main", File: "main.go", Line: 12})
+
+	err := fault.System("a")
+
+	expected := "\nat store/repo.go:42\n   --> store.(*Repo).Get\nat main.go:12\n   --> main.main"
+	if actual := err.StackTrace(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_StubStack_RestoresCapturer(t *testing.T) {
+	t.Run("stub", func(t *testing.T) {
+		StubStack(t)
+	})
+
+	err := fault.System("a")
+
+	if frames := err.Trace().Frames(); len(frames) == 0 || frames[0].File == StubFrame.File {
+		t.Errorf(expectedFormat, "captured frames", frames)
+	}
+}
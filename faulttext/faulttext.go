@@ -0,0 +1,40 @@
+// Package faulttext provides the plural rules of all CLDR languages, as implemented
+// by golang.org/x/text, for the formatting of user error messages (see fault.FormatMessage).
+//
+//	Example:
+//	   func main() {
+//	      fault.SetPluralRules(faulttext.PluralRules)
+//	      ...
+//	   }
+package faulttext
+
+import (
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+var _ fault.PluralRules = PluralRules
+
+var forms = map[plural.Form]string{
+	plural.Other: "other",
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+}
+
+// PluralRules returns the CLDR plural category of the count in the language.
+// Languages which can't be parsed fall back to English.
+func PluralRules(lang string, n int) string {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		tag = language.English
+	}
+	if n < 0 {
+		n = -n
+	}
+	return forms[plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0)]
+}
@@ -0,0 +1,43 @@
+package faulttext
+
+import (
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func Test_PluralRules(t *testing.T) {
+	testCases := []struct {
+		lang     string
+		n        int
+		expected string
+	}{
+		{"en", 1, "one"},
+		{"en", 2, "other"},
+		{"pl", 3, "few"},
+		{"pl", 5, "many"},
+		{"ar", 0, "zero"},
+		{"ar", 2, "two"},
+		{"ja", 1, "other"},
+		{"invalid language", 1, "one"},
+	}
+	for _, tc := range testCases {
+		if actual := PluralRules(tc.lang, tc.n); actual != tc.expected {
+			t.Errorf(expectedFormat, tc.expected, actual)
+		}
+	}
+}
+
+func Test_PluralRules_WithFormatMessage(t *testing.T) {
+	restore := fault.SetPluralRules(PluralRules)
+	defer restore()
+	const msg = "{count, plural, one {# plik} few {# pliki} many {# plików} other {# pliku}}"
+
+	if actual := fault.FormatMessage(msg, "pl", fault.Params{"count": 22}); actual != "22 pliki" {
+		t.Errorf(expectedFormat, "22 pliki", actual)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/dusted-go/fault/faulttext
+
+go 1.19
+
+require (
+	github.com/dusted-go/fault v1.5.0
+	golang.org/x/text v0.14.0
+)
+
+replace github.com/dusted-go/fault => ../
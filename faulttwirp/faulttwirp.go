@@ -0,0 +1,228 @@
+// Package faulttwirp integrates the fault package with Twirp.
+package faulttwirp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// RetryAfterMeta is the metadata key which contains the retry hint of an error.
+const RetryAfterMeta = "retry_after"
+
+// Option configures the server hooks.
+type Option func(*options)
+
+type options struct {
+	logger func(ctx context.Context, err error)
+}
+
+// WithLogger sets the function which receives the internal details of all errors
+// which have been converted into a server side error (e.g. twirp.Internal).
+// By default the error including its stack trace will be written to the standard logger.
+func WithLogger(logger func(ctx context.Context, err error)) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// Interceptor returns a server interceptor which recovers panics into a SystemError
+// and converts all returned errors into a twirp.Error. See ToError for more details.
+//
+// Example:
+//
+//	server := usersv1.NewUsersServer(svc,
+//	   twirp.WithServerInterceptors(faulttwirp.Interceptor()),
+//	   twirp.WithServerHooks(faulttwirp.ServerHooks()))
+func Interceptor() twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (resp interface{}, err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					err = ToError(fault.FromPanic(v))
+				}
+			}()
+			resp, err = next(ctx, req)
+			if err != nil {
+				return resp, ToError(err)
+			}
+			return resp, nil
+		}
+	}
+}
+
+// ServerHooks returns server hooks which log the internal error chain of all errors
+// which resulted in a server side error (5xx) and publish them to the fault.DefaultBus.
+func ServerHooks(opts ...Option) *twirp.ServerHooks {
+	o := &options{
+		logger: func(ctx context.Context, err error) {
+			log.Printf("%+v", err)
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &twirp.ServerHooks{
+		Error: func(ctx context.Context, twerr twirp.Error) context.Context {
+			if twirp.ServerHTTPStatusFromErrorCode(twerr.Code()) < http.StatusInternalServerError {
+				return ctx
+			}
+			// nolint: errorlint // Only the direct cause of the twirp.Error is of interest:
+			if cause := errors.Unwrap(twerr); cause != nil {
+				o.logger(ctx, cause)
+				fault.Publish(cause)
+				return ctx
+			}
+			o.logger(ctx, twerr)
+			fault.Publish(twerr)
+			return ctx
+		},
+	}
+}
+
+// ToError converts an error into a twirp.Error.
+//
+// A UserError gets converted into a twirp.InvalidArgument error with the friendly
+// error message and a metadata entry for each user error (the key being the error code
+// and the value being the message).
+//
+// A SystemError gets converted into an error with the code of its kind and a generic
+// message which doesn't leak any internal details. The returned error wraps the original
+// error so that the internal chain can be logged by the server hooks. If the SystemError
+// contains a retry hint then the error contains a RetryAfterMeta metadata entry.
+//
+// A twirp.Error (which isn't wrapped by a fault) is returned as is.
+// Any other error gets converted into a twirp.Internal error.
+func ToError(err error) twirp.Error {
+	if err == nil {
+		return nil
+	}
+
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		userErr = userErr.Scrub()
+		twerr := twirp.NewError(twirp.InvalidArgument, userErr.FriendlyError())
+		for code, msg := range userErr.Errors() {
+			twerr = twerr.WithMeta(code, msg)
+		}
+		return twerr
+	}
+
+	var sysErr *fault.SystemError
+	if !errors.As(err, &sysErr) {
+		var twerr twirp.Error
+		if errors.As(err, &twerr) {
+			return twerr
+		}
+	}
+
+	code := CodeOf(fault.KindOf(err))
+	twerr := twirp.NewError(code, string(code))
+	if retryAfter, ok := fault.RetryAfter(err); ok {
+		twerr = twerr.WithMeta(RetryAfterMeta, retryAfter.String())
+	}
+	return twirp.WrapError(twerr, err)
+}
+
+// FromError converts an error which has been returned by a Twirp client back into a fault.
+//
+// A twirp.InvalidArgument error with metadata (as created by ToError) gets converted
+// into a UserError with a user error for each metadata entry, sorted by the error code.
+// Any other twirp.Error gets converted into a SystemError which wraps the original
+// error and has the kind which corresponds to the error code.
+// A RetryAfterMeta metadata entry gets converted into a retry hint.
+//
+// Errors which are not a twirp.Error are returned as is.
+func FromError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		return err
+	}
+
+	meta := twerr.MetaMap()
+	retryAfter, retryErr := time.ParseDuration(meta[RetryAfterMeta])
+	delete(meta, RetryAfterMeta)
+
+	if twerr.Code() == twirp.InvalidArgument && len(meta) > 0 {
+		codes := make([]string, 0, len(meta))
+		for code := range meta {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		userErr := fault.User(fault.Code(codes[0]), meta[codes[0]])
+		for _, code := range codes[1:] {
+			userErr.Add(fault.Code(code), meta[code])
+		}
+		if retryErr == nil {
+			userErr.WithRetryAfter(retryAfter)
+		}
+		return userErr
+	}
+
+	sysErr := fault.SystemWrap(err, fmt.Sprintf("calling %s", method)).WithKind(KindOf(twerr.Code()))
+	if retryErr == nil {
+		sysErr.WithRetryAfter(retryAfter)
+	}
+	return sysErr
+}
+
+var kindCodes = map[fault.Kind]twirp.ErrorCode{
+	fault.Internal:          twirp.Internal,
+	fault.Canceled:          twirp.Canceled,
+	fault.Timeout:           twirp.DeadlineExceeded,
+	fault.Unavailable:       twirp.Unavailable,
+	fault.NotFound:          twirp.NotFound,
+	fault.Conflict:          twirp.Aborted,
+	fault.PermissionDenied:  twirp.PermissionDenied,
+	fault.Unauthenticated:   twirp.Unauthenticated,
+	fault.ResourceExhausted: twirp.ResourceExhausted,
+	fault.InvalidArgument:   twirp.InvalidArgument,
+	fault.Unimplemented:     twirp.Unimplemented,
+}
+
+// CodeOf returns the Twirp error code which corresponds to the kind of a SystemError.
+func CodeOf(kind fault.Kind) twirp.ErrorCode {
+	if code, ok := kindCodes[kind]; ok {
+		return code
+	}
+	return twirp.Internal
+}
+
+// KindOf returns the kind of a SystemError which corresponds to the Twirp error code.
+func KindOf(code twirp.ErrorCode) fault.Kind {
+	switch code {
+	case twirp.Canceled:
+		return fault.Canceled
+	case twirp.DeadlineExceeded:
+		return fault.Timeout
+	case twirp.Unavailable:
+		return fault.Unavailable
+	case twirp.NotFound, twirp.BadRoute:
+		return fault.NotFound
+	case twirp.AlreadyExists, twirp.Aborted, twirp.FailedPrecondition:
+		return fault.Conflict
+	case twirp.PermissionDenied:
+		return fault.PermissionDenied
+	case twirp.Unauthenticated:
+		return fault.Unauthenticated
+	case twirp.ResourceExhausted:
+		return fault.ResourceExhausted
+	case twirp.InvalidArgument, twirp.Malformed, twirp.OutOfRange:
+		return fault.InvalidArgument
+	case twirp.Unimplemented:
+		return fault.Unimplemented
+	default:
+		return fault.Internal
+	}
+}
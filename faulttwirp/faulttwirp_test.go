@@ -0,0 +1,146 @@
+package faulttwirp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func invoke(handlerErr error, handlerPanic interface{}) error {
+	method := Interceptor()(func(ctx context.Context, req interface{}) (interface{}, error) {
+		if handlerPanic != nil {
+			panic(handlerPanic)
+		}
+		return nil, handlerErr
+	})
+	_, err := method(context.Background(), nil)
+	return err
+}
+
+func Test_Interceptor_WithUserError(t *testing.T) {
+	userErr := fault.User("MISSING_FIRST_NAME", "Please provide your first name.")
+	userErr.Add("MISSING_LAST_NAME", "Please provide your last name.")
+
+	err := invoke(userErr, nil)
+
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		t.Fatalf(expectedFormat, "twirp.Error", err)
+	}
+	if twerr.Code() != twirp.InvalidArgument {
+		t.Errorf(expectedFormat, twirp.InvalidArgument, twerr.Code())
+	}
+	if msg := twerr.Meta("MISSING_LAST_NAME"); msg != "Please provide your last name." {
+		t.Errorf(expectedFormat, "Please provide your last name.", msg)
+	}
+}
+
+func Test_Interceptor_WithSystemError(t *testing.T) {
+	sysErr := fault.System("connection refused").WithKind(fault.Unavailable)
+
+	err := invoke(sysErr, nil)
+
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		t.Fatalf(expectedFormat, "twirp.Error", err)
+	}
+	if twerr.Code() != twirp.Unavailable {
+		t.Errorf(expectedFormat, twirp.Unavailable, twerr.Code())
+	}
+	if twerr.Msg() != "unavailable" {
+		t.Errorf(expectedFormat, "unavailable", twerr.Msg())
+	}
+	if !errors.Is(err, sysErr) {
+		t.Error("The twirp error was expected to wrap the system error.")
+	}
+}
+
+func Test_Interceptor_WithPanic(t *testing.T) {
+	err := invoke(nil, "boom")
+
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) || twerr.Code() != twirp.Internal {
+		t.Errorf(expectedFormat, twirp.Internal, err)
+	}
+}
+
+func Test_ServerHooks_LogsInternalChain(t *testing.T) {
+	var logged error
+	hooks := ServerHooks(WithLogger(func(ctx context.Context, err error) {
+		logged = err
+	}))
+	sysErr := fault.System("connection refused")
+
+	hooks.Error(context.Background(), ToError(sysErr))
+
+	if logged != sysErr {
+		t.Errorf(expectedFormat, sysErr, logged)
+	}
+}
+
+func Test_ServerHooks_IgnoresUserErrors(t *testing.T) {
+	var logged error
+	hooks := ServerHooks(WithLogger(func(ctx context.Context, err error) {
+		logged = err
+	}))
+
+	hooks.Error(context.Background(), ToError(fault.User("MISSING_NAME", "Please provide a name.")))
+
+	if logged != nil {
+		t.Errorf(expectedFormat, nil, logged)
+	}
+}
+
+func Test_FromError_WithUserError(t *testing.T) {
+	userErr := fault.User("MISSING_FIRST_NAME", "Please provide your first name.")
+	userErr.Add("MISSING_LAST_NAME", "Please provide your last name.")
+
+	err := FromError("Get", ToError(userErr))
+
+	var actual *fault.UserError
+	if !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.UserError", err)
+	}
+	if actual.Error() != userErr.Error() {
+		t.Errorf(expectedFormat, userErr.Error(), actual.Error())
+	}
+}
+
+func Test_FromError_WithSystemError(t *testing.T) {
+	sysErr := fault.System("connection refused").
+		WithKind(fault.Unavailable).
+		WithRetryAfter(time.Second)
+
+	err := FromError("Get", twirp.NewError(twirp.Unavailable, "unavailable").
+		WithMeta(RetryAfterMeta, "1s"))
+
+	var actual *fault.SystemError
+	if !errors.As(err, &actual) {
+		t.Fatalf(expectedFormat, "*fault.SystemError", err)
+	}
+	if kind := actual.Kind(); kind != sysErr.Kind() {
+		t.Errorf(expectedFormat, sysErr.Kind(), kind)
+	}
+	if retryAfter, _ := actual.RetryAfter(); retryAfter != time.Second {
+		t.Errorf(expectedFormat, time.Second, retryAfter)
+	}
+}
+
+func Test_FromError_WithNonTwirpError(t *testing.T) {
+	err := errors.New("foo bar")
+
+	actual := FromError("Get", err)
+
+	if actual != err {
+		t.Errorf(expectedFormat, err, actual)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/dusted-go/fault/faulttwirp
+
+go 1.19
+
+require (
+	github.com/dusted-go/fault v1.5.0
+	github.com/twitchtv/twirp v8.1.3+incompatible
+)
+
+replace github.com/dusted-go/fault => ../
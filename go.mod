@@ -1,3 +1,5 @@
 module github.com/dusted-go/fault
 
 go 1.19
+
+require github.com/google/go-cmp v0.6.0
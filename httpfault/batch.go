@@ -0,0 +1,223 @@
+package httpfault
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// BatchResponse is the response of a batch operation which reports the outcome of
+// every item, so that successes and failures can be returned in the same response
+// (partial success). Each outcome is in the shape of the AIP-193 error model.
+//
+//	Example:
+//	   {
+//	      "results": [
+//	         { "key": "0", "status": { "code": 200, "status": "OK" } },
+//	         {
+//	            "key": "1",
+//	            "status": {
+//	               "code": 400,
+//	               "status": "INVALID_ARGUMENT",
+//	               "message": "Bad Request",
+//	               "details": [
+//	                  {
+//	                     "@type": "type.googleapis.com/google.rpc.BadRequest",
+//	                     "fieldViolations": [
+//	                        { "field": "MISSING_NAME", "description": "Please provide a name." }
+//	                     ]
+//	                  }
+//	               ]
+//	            }
+//	         }
+//	      ]
+//	   }
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// BatchResult is the outcome of a single item of a batch operation.
+type BatchResult struct {
+	// Key is the index or ID of the item.
+	Key string `json:"key"`
+
+	// Status is the outcome of the item.
+	Status BatchStatus `json:"status"`
+}
+
+// BatchStatus is the status of a single item of a batch operation
+// in the shape of the JSON representation of a google.rpc.Status.
+type BatchStatus struct {
+	// Code is the HTTP status code of the item (200 if it has succeeded).
+	Code int `json:"code"`
+
+	// Status is the canonical name of the status code, e.g. INVALID_ARGUMENT.
+	Status string `json:"status"`
+
+	// Message is a short description of the error.
+	Message string `json:"message,omitempty"`
+
+	// Details contains a google.rpc.BadRequest detail with the user errors, if any.
+	Details []BatchDetail `json:"details,omitempty"`
+}
+
+// BatchDetail is a google.rpc.BadRequest detail which contains a field violation
+// for each user error (the field being the error code and the description being the message).
+type BatchDetail struct {
+	Type            string           `json:"@type"`
+	FieldViolations []FieldViolation `json:"fieldViolations"`
+}
+
+// FieldViolation is a single user error of a BatchDetail.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+const badRequestType = "type.googleapis.com/google.rpc.BadRequest"
+
+// canonicalNames maps HTTP status codes to the names of the canonical
+// error codes of google.rpc.Code, as defined by AIP-193.
+var canonicalNames = map[int]string{
+	http.StatusOK:                  "OK",
+	http.StatusBadRequest:          "INVALID_ARGUMENT",
+	http.StatusUnauthorized:        "UNAUTHENTICATED",
+	http.StatusForbidden:           "PERMISSION_DENIED",
+	http.StatusNotFound:            "NOT_FOUND",
+	http.StatusConflict:            "ABORTED",
+	http.StatusTooManyRequests:     "RESOURCE_EXHAUSTED",
+	499:                            "CANCELLED",
+	http.StatusInternalServerError: "INTERNAL",
+	http.StatusNotImplemented:      "UNIMPLEMENTED",
+	http.StatusServiceUnavailable:  "UNAVAILABLE",
+	http.StatusGatewayTimeout:      "DEADLINE_EXCEEDED",
+}
+
+func canonicalName(status int) string {
+	if name, ok := canonicalNames[status]; ok {
+		return name
+	}
+	if status >= http.StatusInternalServerError {
+		return "INTERNAL"
+	}
+	return "UNKNOWN"
+}
+
+// IndexKeys returns the keys of a batch of n items which
+// have been added to a fault.Aggregate by their index.
+func IndexKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	return keys
+}
+
+// WriteBatch writes the outcome of a batch operation using the DefaultResponder.
+func WriteBatch(w http.ResponseWriter, r *http.Request, keys []string, err error) {
+	DefaultResponder.WriteBatch(w, r, keys, err)
+}
+
+// WriteBatch writes the outcome of a batch operation as a BatchResponse with a 200 status code.
+//
+// The keys identify all items of the batch, which are the indices (see IndexKeys) or IDs by
+// which their failures have been added to a fault.Aggregate. Items which are not contained
+// in the Aggregate have succeeded. If the error is neither nil nor an Aggregate then the
+// entire batch has failed and the error gets written by WriteError instead.
+//
+// The failures of the items are sanitized, enriched, logged and published
+// in the same way as WriteError does with a single error.
+//
+//	Example:
+//	   agg := &fault.Aggregate{}
+//	   for i, user := range users {
+//	      agg.Add(i, save(user))
+//	   }
+//	   httpfault.WriteBatch(w, r, httpfault.IndexKeys(len(users)), agg.ErrorOrNil())
+func (rs *Responder) WriteBatch(w http.ResponseWriter, r *http.Request, keys []string, err error) {
+	var agg *fault.Aggregate
+	if err != nil && !errors.As(err, &agg) {
+		rs.WriteError(w, r, err)
+		return
+	}
+
+	failed := failedItems(agg)
+	resp := &BatchResponse{Results: make([]BatchResult, 0, len(keys))}
+	var langs []string
+	if rs.Catalog != nil {
+		w.Header().Add("Vary", "Accept-Language")
+		langs = rs.languages(r)
+	}
+	result := func(key string, itemErr error) BatchResult {
+		if itemErr == nil {
+			return BatchResult{Key: key, Status: BatchStatus{Code: http.StatusOK, Status: canonicalName(http.StatusOK)}}
+		}
+		itemResp := rs.NewErrorResponse(itemErr)
+		if rs.Catalog != nil {
+			rs.translate(langs, itemResp)
+		}
+		itemErr = rs.enrich(r, itemResp.Status, itemErr)
+		if rs.Stats != nil {
+			rs.Stats.Record(itemErr)
+		}
+		if itemResp.Status >= http.StatusInternalServerError {
+			rs.log(r, itemErr)
+			fault.Publish(itemErr)
+		}
+		return BatchResult{Key: key, Status: newBatchStatus(itemResp)}
+	}
+	for _, key := range keys {
+		resp.Results = append(resp.Results, result(key, failed[key]))
+		delete(failed, key)
+	}
+	// Failures of items which are missing from the keys must not get lost:
+	if agg != nil {
+		for _, key := range agg.Keys() {
+			if itemErr, ok := failed[key]; ok {
+				resp.Results = append(resp.Results, result(key, itemErr))
+				delete(failed, key)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if encErr := json.NewEncoder(w).Encode(resp); encErr != nil {
+		rs.log(r, fault.SystemWrap(encErr, "failed to encode batch response"))
+	}
+}
+
+func newBatchStatus(resp *ErrorResponse) BatchStatus {
+	st := BatchStatus{
+		Code:    resp.Status,
+		Status:  canonicalName(resp.Status),
+		Message: resp.Message,
+	}
+	if len(resp.Errors) > 0 {
+		detail := BatchDetail{Type: badRequestType}
+		for _, e := range resp.Errors {
+			detail.FieldViolations = append(detail.FieldViolations, FieldViolation{Field: e.Code, Description: e.Message})
+		}
+		st.Details = []BatchDetail{detail}
+	}
+	return st
+}
+
+// failedItems returns the failures of the Aggregate keyed by the index or ID of the item.
+func failedItems(agg *fault.Aggregate) map[string]error {
+	failed := map[string]error{}
+	if agg == nil {
+		return failed
+	}
+	for index, err := range agg.Failed() {
+		failed[strconv.Itoa(index)] = err
+	}
+	for id, err := range agg.FailedIDs() {
+		failed[id] = err
+	}
+	return failed
+}
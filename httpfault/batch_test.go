@@ -0,0 +1,65 @@
+package httpfault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func writeBatch(keys []string, err error) (*httptest.ResponseRecorder, []error) {
+	var logged []error
+	rs := &Responder{Logger: func(r *http.Request, err error) { logged = append(logged, err) }}
+	w := httptest.NewRecorder()
+	rs.WriteBatch(w, httptest.NewRequest(http.MethodPost, "/users:batchCreate", nil), keys, err)
+	return w, logged
+}
+
+func Test_WriteBatch_WithPartialSuccess(t *testing.T) {
+	agg := &fault.Aggregate{}
+	agg.Add(1, fault.User("MISSING_NAME", "Please provide a name."))
+	agg.Add(2, fault.System("connection refused"))
+
+	w, logged := writeBatch(IndexKeys(3), agg.ErrorOrNil())
+
+	if w.Code != http.StatusOK {
+		t.Errorf(expectedFormat, http.StatusOK, w.Code)
+	}
+	expected := `{"results":[` +
+		`{"key":"0","status":{"code":200,"status":"OK"}},` +
+		`{"key":"1","status":{"code":400,"status":"INVALID_ARGUMENT","message":"Bad Request","details":[` +
+		`{"@type":"type.googleapis.com/google.rpc.BadRequest","fieldViolations":[` +
+		`{"field":"MISSING_NAME","description":"Please provide a name."}]}]}},` +
+		`{"key":"2","status":{"code":500,"status":"INTERNAL","message":"Internal Server Error"}}]}` + "\n"
+	if actual := w.Body.String(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if len(logged) != 1 {
+		t.Errorf(expectedFormat, 1, len(logged))
+	}
+}
+
+func Test_WriteBatch_WithUnknownKey(t *testing.T) {
+	agg := &fault.Aggregate{}
+	agg.AddID("b", fault.User("NOT_FOUND", "The user doesn't exist."))
+
+	w, _ := writeBatch([]string{"a"}, agg.ErrorOrNil())
+
+	resp := &BatchResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Results) != 2 || resp.Results[1].Key != "b" || resp.Results[1].Status.Code != http.StatusBadRequest {
+		t.Errorf(expectedFormat, "a failed result for the key b", w.Body.String())
+	}
+}
+
+func Test_WriteBatch_WithFailedBatch(t *testing.T) {
+	w, _ := writeBatch(IndexKeys(2), fault.System("connection refused"))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, w.Code)
+	}
+}
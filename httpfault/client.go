@@ -0,0 +1,64 @@
+package httpfault
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	maxBodySize = 1 << 20
+)
+
+// FromResponse converts a HTTP error response back into a fault.
+//
+// It returns nil if the response doesn't have an error status code.
+// A 4xx response which contains user errors in the format of the JSONEncoder
+// or ProblemEncoder will be converted into a UserError with the same codes and messages.
+// Any other error response will be converted into a SystemError, whose message
+// contains the method, the URL without its user info and query, and the status.
+//
+// FromResponse reads the response body, but doesn't close it.
+func FromResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	body := struct {
+		ErrorResponse
+		Title string `json:"title"`
+	}{}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err == nil {
+		_ = json.Unmarshal(data, &body)
+	}
+
+	if resp.StatusCode < http.StatusInternalServerError && len(body.Errors) > 0 {
+		userErr := fault.User(fault.Code(body.Errors[0].Code), body.Errors[0].Message)
+		for _, e := range body.Errors[1:] {
+			userErr.Add(fault.Code(e.Code), e.Message)
+		}
+		return userErr
+	}
+
+	msg := body.Message
+	if msg == "" {
+		msg = body.Title
+	}
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		return fault.Systemf("%s %s: %d %s", resp.Request.Method, redactURL(resp.Request.URL), resp.StatusCode, msg)
+	}
+	return fault.Systemf("%d %s", resp.StatusCode, msg)
+}
+
+// redactURL returns the scheme, host and path of the URL, since its user info
+// and query (e.g. API keys or tokens) must not end up in the error message.
+func redactURL(u *url.URL) *url.URL {
+	return &url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path, RawPath: u.RawPath}
+}
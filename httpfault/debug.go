@@ -0,0 +1,149 @@
+package httpfault
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dusted-go/fault/fault"
+	"github.com/dusted-go/fault/stack"
+)
+
+const (
+	snippetRadius = 3
+)
+
+var debugTemplate = template.Must(template.New("debug").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Status}} {{.StatusText}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+pre { background: #f6f8fa; padding: 1em; overflow-x: auto; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ddd; padding: .3em .6em; text-align: left; }
+.frame { margin-bottom: 1.5em; }
+.current { background: #ffe5e5; font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Status}} {{.StatusText}}</h1>
+<pre>{{.Message}}</pre>
+{{- if .Kind}}
+<p>Kind: <code>{{.Kind}}</code></p>
+{{- end}}
+{{- if .Fields}}
+<h2>Fields</h2>
+<table>
+{{- range .Fields}}
+<tr><th>{{.Key}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Frames}}
+<h2>Stack Trace</h2>
+{{- range .Frames}}
+<div class="frame">
+<code>{{.Function}}</code><br>
+<small>{{.File}}:{{.Line}}</small>
+{{- if .Snippet}}
+<pre>{{range .Snippet}}<span{{if .Current}} class="current"{{end}}>{{printf "%5d" .Line}} {{.Code}}</span>
+{{end}}</pre>
+{{- end}}
+</div>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+type debugPage struct {
+	Status     int
+	StatusText string
+	Message    string
+	Kind       fault.Kind
+	Fields     []debugField
+	Frames     []debugFrame
+}
+
+type debugField struct {
+	Key   string
+	Value string
+}
+
+type debugFrame struct {
+	Function string
+	File     string
+	Line     int
+	Snippet  []debugLine
+}
+
+type debugLine struct {
+	Line    int
+	Code    string
+	Current bool
+}
+
+// writeDebugPage writes a HTML page which exposes the internal details of the error.
+// It must only ever be used in development environments.
+// Source code snippets are only included if snippets is true.
+func writeDebugPage(w http.ResponseWriter, status int, err error, snippets bool) error {
+	page := debugPage{
+		Status:     status,
+		StatusText: http.StatusText(status),
+		Message:    fault.Scrub(err.Error()),
+	}
+
+	var sysErr *fault.SystemError
+	if errors.As(err, &sysErr) {
+		page.Kind = sysErr.Kind()
+		for k, v := range fault.ScrubFields(sysErr.Fields()) {
+			page.Fields = append(page.Fields, debugField{Key: k, Value: fmt.Sprint(v)})
+		}
+		sort.Slice(page.Fields, func(i, j int) bool {
+			return page.Fields[i].Key < page.Fields[j].Key
+		})
+		for _, f := range sysErr.Trace().Frames() {
+			page.Frames = append(page.Frames, debugFrame{
+				Function: stack.FuncName(f.Function),
+				File:     f.File,
+				Line:     f.Line,
+			})
+			if snippets {
+				page.Frames[len(page.Frames)-1].Snippet = readSnippet(f.File, f.Line)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	return debugTemplate.Execute(w, page)
+}
+
+func readSnippet(file string, line int) []debugLine {
+	// nolint: gosec // Only reads source files of the stack trace in debug mode:
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(string(data), "\n")
+	var snippet []debugLine
+	for i := line - snippetRadius; i <= line+snippetRadius; i++ {
+		if i < 1 || i > len(lines) {
+			continue
+		}
+		snippet = append(snippet, debugLine{
+			Line:    i,
+			Code:    lines[i-1],
+			Current: i == line,
+		})
+	}
+	return snippet
+}
@@ -0,0 +1,47 @@
+package httpfault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_WriteError_WithDebug(t *testing.T) {
+	rs := &Responder{Debug: true, Logger: func(r *http.Request, err error) {}}
+	err := fault.SystemWrap(fault.System("<connection refused>"), "connecting to db").
+		WithField("db", "users")
+	w := httptest.NewRecorder()
+
+	rs.WriteError(w, httptest.NewRequest(http.MethodGet, "/", nil), err)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, w.Code)
+	}
+	body := w.Body.String()
+	for _, expected := range []string{
+		"<pre>connecting to db\n   &lt;connection refused&gt;</pre>",
+		"<tr><th>db</th><td>users</td></tr>",
+		"<code>httpfault.Test_WriteError_WithDebug</code>",
+		`<span class="current">`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf(expectedFormat, expected, body)
+		}
+	}
+}
+
+func Test_WriteError_WithoutDebug(t *testing.T) {
+	rs := &Responder{Logger: func(r *http.Request, err error) {}}
+	r := httptest.NewRequest(http.MethodGet, "/?debug=true", nil)
+	r.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+
+	rs.WriteError(w, r, fault.System("connection refused"))
+
+	if body := w.Body.String(); strings.Contains(body, "connection refused") {
+		t.Errorf("The response was not expected to contain internal details:\n%s", body)
+	}
+}
@@ -0,0 +1,211 @@
+package httpfault
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// ErrorEntry represents a single user error in a HTTP response.
+type ErrorEntry struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	// params are the params of the user error, which translations get formatted with.
+	params fault.Params
+}
+
+// ErrorResponse represents a HTTP error response.
+// It only contains information which is safe to be shown to an end user.
+type ErrorResponse struct {
+	// Status is the HTTP status code of the response.
+	Status int `json:"-"`
+
+	// Message is a short description of the error.
+	Message string `json:"message"`
+
+	// Errors is the list of user errors, if any.
+	Errors []ErrorEntry `json:"errors,omitempty"`
+
+	// RetryAfter is the duration after which the request may be retried, if known.
+	RetryAfter time.Duration `json:"-"`
+
+	// htmlEscaped reports whether the messages of the errors are HTML-escaped.
+	htmlEscaped bool
+}
+
+// Encoder writes the status code and body of an error response.
+//
+// Implement a custom Encoder in order to render error responses in a different shape,
+// for example to match an existing API error contract:
+//
+//	httpfault.EncoderFunc(
+//	   func(w http.ResponseWriter, r *http.Request, resp *httpfault.ErrorResponse) error {
+//	      w.Header().Set("Content-Type", "application/json")
+//	      w.WriteHeader(resp.Status)
+//	      return json.NewEncoder(w).Encode(map[string]interface{}{
+//	         "error":    resp.Message,
+//	         "errors":   resp.Errors,
+//	         "trace_id": traceID(r.Context()),
+//	      })
+//	   })
+type Encoder interface {
+	Encode(w http.ResponseWriter, r *http.Request, resp *ErrorResponse) error
+}
+
+// EncoderFunc is an adapter to allow the use of ordinary functions as an Encoder.
+type EncoderFunc func(w http.ResponseWriter, r *http.Request, resp *ErrorResponse) error
+
+// Encode calls f(w, r, resp).
+func (f EncoderFunc) Encode(w http.ResponseWriter, r *http.Request, resp *ErrorResponse) error {
+	return f(w, r, resp)
+}
+
+// JSONEncoder writes an error response as JSON.
+//
+//	Example:
+//	   {
+//	      "message": "Bad Request",
+//	      "errors": [
+//	         { "code": "MISSING_FIRST_NAME", "message": "Please provide your first name" }
+//	      ]
+//	   }
+type JSONEncoder struct{}
+
+// Encode writes the error response as JSON.
+func (JSONEncoder) Encode(w http.ResponseWriter, _ *http.Request, resp *ErrorResponse) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(resp.Status)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// ProblemEncoder writes an error response as a RFC 7807 problem details object.
+// User errors are included as an "errors" extension member.
+//
+//	Example:
+//	   {
+//	      "type": "about:blank",
+//	      "title": "Bad Request",
+//	      "status": 400,
+//	      "errors": [
+//	         { "code": "MISSING_FIRST_NAME", "message": "Please provide your first name" }
+//	      ]
+//	   }
+type ProblemEncoder struct{}
+
+type problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Errors []ErrorEntry `json:"errors,omitempty"`
+}
+
+// Encode writes the error response as problem details.
+func (ProblemEncoder) Encode(w http.ResponseWriter, _ *http.Request, resp *ErrorResponse) error {
+	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(resp.Status)
+	return json.NewEncoder(w).Encode(problem{
+		Type:   "about:blank",
+		Title:  resp.Message,
+		Status: resp.Status,
+		Errors: resp.Errors,
+	})
+}
+
+// TextEncoder writes an error response as plain text.
+//
+//	Example:
+//	   Bad Request
+//
+//	   - Please provide your first name (MISSING_FIRST_NAME)
+type TextEncoder struct{}
+
+// Encode writes the error response as plain text.
+func (TextEncoder) Encode(w http.ResponseWriter, _ *http.Request, resp *ErrorResponse) error {
+	sb := strings.Builder{}
+	sb.WriteString(resp.Message)
+	if len(resp.Errors) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, e := range resp.Errors {
+		sb.WriteString(fmt.Sprintf("\n- %s (%s)", e.Message, e.Code))
+	}
+	sb.WriteString("\n")
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(resp.Status)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+var htmlTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Status}} {{.Message}}</title>
+</head>
+<body>
+<h1>{{.Status}} {{.Message}}</h1>
+{{- if .Errors}}
+<ul>
+{{- range .Errors}}
+<li>{{.Message}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTMLEncoder writes an error response as a HTML page.
+// All messages are HTML-escaped exactly once, regardless of
+// the EscapeHTML setting of the Responder.
+type HTMLEncoder struct{}
+
+// Encode writes the error response as a HTML page.
+func (HTMLEncoder) Encode(w http.ResponseWriter, _ *http.Request, resp *ErrorResponse) error {
+	if resp.htmlEscaped {
+		// The template escapes the messages itself:
+		unescaped := *resp
+		unescaped.Errors = make([]ErrorEntry, len(resp.Errors))
+		for i, e := range resp.Errors {
+			e.Message = html.UnescapeString(e.Message)
+			unescaped.Errors[i] = e
+		}
+		resp = &unescaped
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(resp.Status)
+	return htmlTemplate.Execute(w, resp)
+}
+
+func newErrorResponse(status int) *ErrorResponse {
+	return &ErrorResponse{
+		Status:  status,
+		Message: http.StatusText(status),
+	}
+}
+
+func newUserErrorResponse(status int, userErr *fault.UserError) *ErrorResponse {
+	userErr = userErr.Scrub()
+	msgs := userErr.ErrorMessages()
+	codes := userErr.Codes()
+	entries := make([]ErrorEntry, len(codes))
+	for i, code := range codes {
+		entries[i] = ErrorEntry{Code: code.String(), Message: msgs[i], params: userErr.Params(code)}
+	}
+	resp := newErrorResponse(status)
+	resp.Errors = entries
+	return resp
+}
@@ -0,0 +1,298 @@
+package httpfault
+
+import (
+	"errors"
+	"html"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// Responder writes errors as HTTP responses.
+//
+// A UserError is rendered as a 4xx response which includes all error codes and messages.
+// Any other error (e.g. a SystemError) is rendered as a sanitized response
+// which doesn't leak any internal details to the client. The internal details
+// of errors which result in a 5xx response get logged instead.
+type Responder struct {
+	// Logger receives all errors which resulted in a 5xx response.
+	// If nil then the error including its stack trace will be written to the standard logger.
+	Logger func(r *http.Request, err error)
+
+	// Encoder writes the status code and body of the error response.
+	// If nil then the NegotiatingEncoder will be used.
+	Encoder Encoder
+
+	// StatusResolver decides the status code of the error response.
+	// If nil then the DefaultStatusResolver will be used.
+	StatusResolver StatusResolver
+
+	// Debug renders errors which result in a 5xx response as a HTML page
+	// which shows the message chain, fields and the stack trace including
+	// source code snippets.
+	//
+	// Debug exposes internal details and must only be enabled in development
+	// environments. It can only be enabled in code and never via request input.
+	// It is enabled by the DebugAttachments setting of the fault.Profile as well.
+	Debug bool
+
+	// RequestIDHeader is the name of the request header which contains the request ID.
+	// Defaults to X-Request-ID.
+	RequestIDHeader string
+
+	// RoutePattern returns the route pattern which matched the request (e.g. /users/{id}).
+	// Routers which expose the matched pattern can be plugged in here.
+	RoutePattern func(r *http.Request) string
+
+	// Catalog translates user error messages into the languages of the
+	// Accept-Language header. Messages remain untranslated if nil.
+	Catalog fault.Catalog
+
+	// DefaultLanguage is the language which will be used if the Catalog
+	// doesn't have a translation for any language of the Accept-Language header.
+	DefaultLanguage string
+
+	// Stats records the errors of all error responses, including user errors,
+	// if not nil (see StatsHandler).
+	Stats *fault.Stats
+
+	// EscapeHTML escapes the messages of user errors in error responses, for clients
+	// which insert them into HTML without escaping them (e.g. via innerHTML), since
+	// messages frequently echo user input. The HTMLEncoder escapes messages regardless.
+	EscapeHTML bool
+}
+
+// DefaultResponder is the Responder used by WriteError.
+var DefaultResponder = &Responder{}
+
+// WriteError writes the error as a HTTP response using the DefaultResponder.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	DefaultResponder.WriteError(w, r, err)
+}
+
+// HandlerFunc is a HTTP handler which can return an error.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Handle converts the handler into a http.Handler using the DefaultResponder.
+func Handle(h HandlerFunc) http.Handler {
+	return DefaultResponder.Handle(h)
+}
+
+// Handle converts the handler into a http.Handler which writes
+// returned errors and recovered panics as HTTP responses.
+func (rs *Responder) Handle(h HandlerFunc) http.Handler {
+	return rs.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := h(w, r); err != nil {
+			rs.WriteError(w, r, err)
+		}
+	}))
+}
+
+// Recover is a middleware which recovers panics using the DefaultResponder.
+func Recover(next http.Handler) http.Handler {
+	return DefaultResponder.Recover(next)
+}
+
+// Recover is a middleware which recovers panics of the next handler.
+// The recovered panic gets converted into a SystemError, pointing to the
+// location where the panic occurred, and is then written as a HTTP response.
+//
+// A http.ErrAbortHandler panic gets re-panicked so that the HTTP server
+// can abort the response as intended.
+func (rs *Responder) Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if v := recover(); v != nil {
+				// nolint: errorlint // Sentinel panic value must be compared directly:
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				rs.WriteError(w, r, fault.FromPanic(v))
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WriteError writes the error as a HTTP response.
+// Nothing will be written if the error is nil.
+//
+// If the error contains a retry hint then the response will have a 429 (user error)
+// or 503 (any other error) status code and a Retry-After header.
+//
+// Before the error gets logged, the request metadata (method, path, route pattern,
+// status, request ID and peer IP) will be attached as fields to a SystemError which
+// wraps the error (see fault.Annotate), as well as the breadcrumbs of the request's
+// context if none have been attached yet. The error itself isn't modified. Errors with a
+// 5xx status code are logged and published to the listeners of the fault.DefaultBus.
+func (rs *Responder) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+
+	resp := rs.NewErrorResponse(err)
+	rs.localize(r, w, resp)
+	if resp.RetryAfter > 0 {
+		seconds := int(math.Ceil(resp.RetryAfter.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+	err = rs.enrich(r, resp.Status, err)
+	if rs.Stats != nil {
+		rs.Stats.Record(err)
+	}
+	if resp.Status >= http.StatusInternalServerError {
+		rs.log(r, err)
+		fault.Publish(err)
+		if rs.Debug || fault.CurrentProfile().DebugAttachments {
+			if pageErr := writeDebugPage(w, resp.Status, err, rs.Debug || fault.CurrentProfile().SourceSnippets); pageErr != nil {
+				rs.log(r, fault.SystemWrap(pageErr, "failed to write debug page"))
+			}
+			return
+		}
+	}
+
+	if encErr := rs.encoder().Encode(w, r, resp); encErr != nil {
+		rs.log(r, fault.SystemWrap(encErr, "failed to encode error response"))
+	}
+}
+
+// NewErrorResponse resolves the status code and the sanitized
+// content of the error response without writing it.
+//
+// This allows integrations with web frameworks which are not based
+// on net/http to render the same error responses as WriteError.
+//
+// The message of an error which isn't a UserError is the status text,
+// unless the MaskMessages setting of the fault.Profile has been disabled.
+func (rs *Responder) NewErrorResponse(err error) *ErrorResponse {
+	status := rs.statusResolver().ResolveStatus(err)
+	retryAfter, ok := fault.RetryAfter(err)
+	if ok {
+		status = retryStatus(status, err)
+	}
+
+	var resp *ErrorResponse
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		if rs.EscapeHTML {
+			userErr = userErr.EscapeHTML()
+		}
+		resp = newUserErrorResponse(status, userErr)
+		resp.htmlEscaped = rs.EscapeHTML
+	} else {
+		resp = newErrorResponse(status)
+		if !fault.CurrentProfile().MaskMessages {
+			resp.Message = fault.Scrub(err.Error())
+		}
+	}
+	resp.RetryAfter = retryAfter
+	return resp
+}
+
+func (rs *Responder) localize(r *http.Request, w http.ResponseWriter, resp *ErrorResponse) {
+	if rs.Catalog == nil {
+		return
+	}
+	w.Header().Add("Vary", "Accept-Language")
+	rs.translate(rs.languages(r), resp)
+}
+
+// languages returns the languages of the Accept-Language header
+// followed by the DefaultLanguage.
+func (rs *Responder) languages(r *http.Request) []string {
+	langs := acceptedLanguages(r.Header.Get("Accept-Language"))
+	if rs.DefaultLanguage != "" {
+		langs = append(langs, rs.DefaultLanguage)
+	}
+	return langs
+}
+
+func (rs *Responder) translate(langs []string, resp *ErrorResponse) {
+	for i, e := range resp.Errors {
+		if msg, ok := rs.Catalog.Format(e.Code, e.params, langs...); ok {
+			// The params which are formatted into the translation may contain PII.
+			msg = fault.Scrub(msg)
+			if resp.htmlEscaped {
+				msg = html.EscapeString(msg)
+			}
+			resp.Errors[i].Message = msg
+		}
+	}
+}
+
+func (rs *Responder) statusResolver() StatusResolver {
+	if rs.StatusResolver != nil {
+		return rs.StatusResolver
+	}
+	return DefaultStatusResolver
+}
+
+func (rs *Responder) encoder() Encoder {
+	if rs.Encoder != nil {
+		return rs.Encoder
+	}
+	return NegotiatingEncoder{}
+}
+
+// retryStatus returns a status code which is valid in conjunction
+// with a Retry-After header, preferring the already resolved status.
+func retryStatus(status int, err error) int {
+	if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+		return status
+	}
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		return http.StatusTooManyRequests
+	}
+	return http.StatusServiceUnavailable
+}
+
+// enrich returns the error annotated with the request metadata
+// if its chain contains a SystemError, or the error otherwise.
+func (rs *Responder) enrich(r *http.Request, status int, err error) error {
+	var inner *fault.SystemError
+	if !errors.As(err, &inner) {
+		return err
+	}
+	sysErr := fault.Annotate(err).
+		WithField("method", r.Method).
+		WithField("path", r.URL.Path).
+		WithField("status", status).
+		WithField("peer_ip", peerIP(r))
+	if rs.RoutePattern != nil {
+		if route := rs.RoutePattern(r); route != "" {
+			sysErr.WithField("route", route)
+		}
+	}
+	header := rs.RequestIDHeader
+	if header == "" {
+		header = "X-Request-ID"
+	}
+	if requestID := r.Header.Get(header); requestID != "" {
+		sysErr.WithField("request_id", requestID)
+	}
+	if sysErr.Breadcrumbs() == nil {
+		sysErr.WithContext(r.Context())
+	}
+	return sysErr
+}
+
+func peerIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (rs *Responder) log(r *http.Request, err error) {
+	if rs.Logger != nil {
+		rs.Logger(r, err)
+		return
+	}
+	log.Printf("%s %s: %+v", r.Method, r.URL.Path, err)
+}
@@ -0,0 +1,358 @@
+package httpfault
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%v\n\nactual:\n%v\n\n"
+)
+
+func Test_WriteError_WithUserError(t *testing.T) {
+	err := fault.User("MISSING_FIRST_NAME", "Please provide your first name.")
+	err.Add("MISSING_LAST_NAME", "Please provide your last name.")
+	rs := &Responder{Logger: func(r *http.Request, err error) {
+		t.Error("User errors were not expected to be logged.")
+	}}
+	w := httptest.NewRecorder()
+
+	rs.WriteError(w, httptest.NewRequest(http.MethodPost, "/users", nil), fault.SystemWrap(err, "creating user"))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf(expectedFormat, http.StatusBadRequest, w.Code)
+	}
+	expected := `{"message":"Bad Request","errors":[` +
+		`{"code":"MISSING_FIRST_NAME","message":"Please provide your first name."},` +
+		`{"code":"MISSING_LAST_NAME","message":"Please provide your last name."}]}`
+	if actual := strings.TrimSpace(w.Body.String()); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_WriteError_WithSystemError(t *testing.T) {
+	err := fault.SystemWrap(errors.New("connection refused"), "connecting to db")
+	var logged error
+	rs := &Responder{Logger: func(r *http.Request, err error) {
+		logged = err
+	}}
+	w := httptest.NewRecorder()
+
+	rs.WriteError(w, httptest.NewRequest(http.MethodGet, "/users", nil), err)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, w.Code)
+	}
+	expected := `{"message":"Internal Server Error"}`
+	if actual := strings.TrimSpace(w.Body.String()); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if !errors.Is(logged, err) {
+		t.Error("System errors were expected to be logged.")
+	}
+}
+
+func Test_Recover_WithPanic(t *testing.T) {
+	var logged error
+	rs := &Responder{Logger: func(r *http.Request, err error) {
+		logged = err
+	}}
+	handler := rs.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("something went wrong")
+	}))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf(expectedFormat, http.StatusInternalServerError, w.Code)
+	}
+	var sysErr *fault.SystemError
+	if !errors.As(logged, &sysErr) {
+		t.Fatal("The panic was expected to be logged as a SystemError.")
+	}
+	expected := "panic: something went wrong"
+	if actual := sysErr.Error(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	expected = "httpfault_test.go"
+	if actual := sysErr.StackTrace(); !strings.Contains(strings.SplitN(actual, "\n", 3)[1], expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_WriteError_WithCustomEncoder(t *testing.T) {
+	rs := &Responder{
+		Encoder: EncoderFunc(func(w http.ResponseWriter, r *http.Request, resp *ErrorResponse) error {
+			w.WriteHeader(resp.Status)
+			_, err := fmt.Fprintf(w, "%s: %s", resp.Errors[0].Code, resp.Errors[0].Message)
+			return err
+		}),
+	}
+	w := httptest.NewRecorder()
+
+	rs.WriteError(w, httptest.NewRequest(http.MethodGet, "/", nil), fault.User("INVALID_ID", "Invalid ID."))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf(expectedFormat, http.StatusBadRequest, w.Code)
+	}
+	expected := "INVALID_ID: Invalid ID."
+	if actual := w.Body.String(); actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_WriteError_WithEscapeHTML(t *testing.T) {
+	rs := &Responder{
+		EscapeHTML: true,
+		Catalog:    fault.Catalog{"de": {"INVALID_NAME": "'{name}' ist kein gültiger Name."}},
+	}
+	userErr := fault.UserParams("INVALID_NAME", "'{name}' is not a valid name.", fault.Params{"name": "<b>"})
+
+	testCases := []struct {
+		accept, language, expected string
+	}{
+		{"text/plain", "", "&#39;&lt;b&gt;&#39; is not a valid name."},
+		{"text/plain", "de", "&#39;&lt;b&gt;&#39; ist kein gültiger Name."},
+		{"text/html", "", "<li>&#39;&lt;b&gt;&#39; is not a valid name.</li>"},
+	}
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", tc.accept)
+		r.Header.Set("Accept-Language", tc.language)
+
+		rs.WriteError(w, r, userErr)
+
+		if actual := w.Body.String(); !strings.Contains(actual, tc.expected) {
+			t.Errorf(expectedFormat, tc.expected, actual)
+		}
+	}
+}
+
+func Test_Handle_WithReturnedSystemError(t *testing.T) {
+	var logged error
+	rs := &Responder{
+		Logger: func(r *http.Request, err error) {
+			logged = err
+		},
+		RoutePattern: func(r *http.Request) string {
+			return "/users/{id}"
+		},
+	}
+	handler := rs.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		return fault.System("connection refused")
+	})
+	r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	r.Header.Set("X-Request-ID", "abc")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+
+	var sysErr *fault.SystemError
+	if !errors.As(logged, &sysErr) {
+		t.Fatal("The returned error was expected to be logged.")
+	}
+	expected := map[string]interface{}{
+		"method":     http.MethodGet,
+		"path":       "/users/42",
+		"route":      "/users/{id}",
+		"status":     http.StatusInternalServerError,
+		"request_id": "abc",
+		"peer_ip":    "192.0.2.1",
+	}
+	if actual := sysErr.Fields(); fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_WriteError_DoesntModifySharedSystemError(t *testing.T) {
+	sentinel := fault.System("service is down").WithKind(fault.Unavailable)
+	var logged []error
+	rs := &Responder{Logger: func(r *http.Request, err error) {
+		logged = append(logged, err)
+	}}
+
+	for _, path := range []string{"/a", "/b"} {
+		rs.WriteError(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil), sentinel)
+	}
+
+	if actual := sentinel.Fields(); len(actual) != 0 {
+		t.Errorf(expectedFormat, map[string]interface{}{}, actual)
+	}
+	for i, path := range []string{"/a", "/b"} {
+		var sysErr *fault.SystemError
+		if !errors.As(logged[i], &sysErr) || sysErr.Fields()["path"] != path {
+			t.Errorf(expectedFormat, path, logged[i])
+		}
+		if !errors.Is(logged[i], sentinel) || logged[i].Error() != sentinel.Error() {
+			t.Errorf(expectedFormat, sentinel, logged[i])
+		}
+	}
+}
+
+func Test_WriteError_PublishesCompleteErrors(t *testing.T) {
+	var published []error
+	sub := fault.Subscribe(func(err error) {
+		published = append(published, err)
+	})
+	rs := &Responder{Logger: func(r *http.Request, err error) {}}
+	err := fault.System("db down").WithKind(fault.Unavailable).WithSeverity(fault.SeverityCritical)
+
+	rs.WriteError(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil), err)
+	rs.WriteError(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil), fault.User("A", "a"))
+	sub.Close()
+
+	if len(published) != 1 {
+		t.Fatalf(expectedFormat, "1 error", published)
+	}
+	if kind, severity := fault.KindOf(published[0]), fault.SeverityOf(published[0]); kind != fault.Unavailable || severity != fault.SeverityCritical {
+		t.Errorf(expectedFormat, "unavailable critical", fmt.Sprint(kind, " ", severity))
+	}
+	if actual := fault.Fingerprint(published[0]); actual != fault.Fingerprint(err) {
+		t.Errorf(expectedFormat, fault.Fingerprint(err), actual)
+	}
+}
+
+func Test_Handle_AttachesBreadcrumbs(t *testing.T) {
+	var logged error
+	rs := &Responder{Logger: func(r *http.Request, err error) {
+		logged = err
+	}}
+	handler := rs.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		fault.AddBreadcrumb(r.Context(), "fetched user 42")
+		return fault.System("connection refused")
+	})
+	r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	r = r.WithContext(fault.WithBreadcrumbs(r.Context(), 0))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+
+	var sysErr *fault.SystemError
+	if !errors.As(logged, &sysErr) {
+		t.Fatal("The returned error was expected to be logged.")
+	}
+	crumbs := sysErr.Breadcrumbs()
+	if len(crumbs) != 1 || crumbs[0].Message != "fetched user 42" {
+		t.Errorf(expectedFormat, "[fetched user 42]", crumbs)
+	}
+}
+
+func Test_Handle_WithPanic(t *testing.T) {
+	var logged error
+	rs := &Responder{Logger: func(r *http.Request, err error) {
+		logged = err
+	}}
+	handler := rs.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		panic("something went wrong")
+	})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	var sysErr *fault.SystemError
+	if !errors.As(logged, &sysErr) {
+		t.Fatal("The panic was expected to be logged.")
+	}
+	if actual := sysErr.Fields()["method"]; actual != http.MethodPost {
+		t.Errorf(expectedFormat, http.MethodPost, actual)
+	}
+}
+
+func Test_WriteError_WithRetryAfter(t *testing.T) {
+	testCases := []struct {
+		err        error
+		status     int
+		retryAfter string
+	}{
+		{
+			fault.User("TOO_MANY_REQUESTS", "Please slow down.").WithRetryAfter(time.Minute),
+			http.StatusTooManyRequests,
+			"60",
+		},
+		{
+			fault.SystemWrap(fault.System("c").WithRetryAfter(1500*time.Millisecond), "f"),
+			http.StatusServiceUnavailable,
+			"2",
+		},
+		{
+			fault.System("c").WithKind(fault.Unavailable),
+			http.StatusServiceUnavailable,
+			"",
+		},
+	}
+
+	rs := &Responder{Logger: func(r *http.Request, err error) {}}
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+
+		rs.WriteError(w, httptest.NewRequest(http.MethodGet, "/", nil), tc.err)
+
+		if w.Code != tc.status {
+			t.Errorf(expectedFormat, tc.status, w.Code)
+		}
+		if actual := w.Header().Get("Retry-After"); actual != tc.retryAfter {
+			t.Errorf(expectedFormat, tc.retryAfter, actual)
+		}
+	}
+}
+
+func Test_WriteError_ScrubsUserErrors(t *testing.T) {
+	previous := fault.DefaultScrubber
+	fault.DefaultScrubber = &fault.Scrubber{}
+	defer func() { fault.DefaultScrubber = previous }()
+	fault.RegisterScrubRule(fault.EmailRule)
+	rs := &Responder{}
+	w := httptest.NewRecorder()
+
+	rs.WriteError(w, httptest.NewRequest(http.MethodPost, "/users", nil),
+		fault.User("EMAIL_TAKEN", "jane@example.com is already registered."))
+
+	if body := w.Body.String(); strings.Contains(body, "jane@example.com") || !strings.Contains(body, "[REDACTED]") {
+		t.Errorf(expectedFormat, "[REDACTED] is already registered.", body)
+	}
+}
+
+func Test_WriteError_ScrubsTranslatedUserErrors(t *testing.T) {
+	previous := fault.DefaultScrubber
+	fault.DefaultScrubber = &fault.Scrubber{}
+	defer func() { fault.DefaultScrubber = previous }()
+	fault.RegisterScrubRule(fault.EmailRule)
+	rs := &Responder{Catalog: fault.Catalog{"de": {"EMAIL_TAKEN": "{email} ist bereits registriert."}}}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users", nil)
+	r.Header.Set("Accept-Language", "de")
+
+	rs.WriteError(w, r, fault.UserParams("EMAIL_TAKEN", "The email address is already registered.",
+		fault.Params{"email": "jane@example.com"}))
+
+	if body := w.Body.String(); strings.Contains(body, "jane@example.com") || !strings.Contains(body, "[REDACTED] ist bereits registriert.") {
+		t.Errorf(expectedFormat, "[REDACTED] ist bereits registriert.", body)
+	}
+}
+
+func Test_WriteError_WithDevelopmentProfile(t *testing.T) {
+	restore := fault.SetProfile(fault.Development)
+	defer restore()
+	rs := &Responder{Logger: func(r *http.Request, err error) {}}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+
+	resp := rs.NewErrorResponse(fault.System("connection refused"))
+	rs.WriteError(w, r, fault.System("connection refused"))
+
+	if expected := "connection refused"; resp.Message != expected {
+		t.Errorf(expectedFormat, expected, resp.Message)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "<!DOCTYPE html>") || !strings.Contains(body, `class="current"`) {
+		t.Errorf(expectedFormat, "debug page with source snippets", body)
+	}
+}
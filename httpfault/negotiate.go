@@ -0,0 +1,122 @@
+package httpfault
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NegotiatingEncoder selects an Encoder based on the Accept header of the request.
+//
+// It supports the following media types:
+//
+//	application/json          -> JSONEncoder
+//	application/problem+json  -> ProblemEncoder
+//	text/plain                -> TextEncoder
+//	text/html                 -> HTMLEncoder
+//
+// The JSONEncoder is used when the request doesn't have an Accept header
+// or none of the accepted media types are supported.
+type NegotiatingEncoder struct{}
+
+var mediaTypes = []struct {
+	mediaType string
+	encoder   Encoder
+}{
+	{"application/json", JSONEncoder{}},
+	{"application/problem+json", ProblemEncoder{}},
+	{"text/plain", TextEncoder{}},
+	{"text/html", HTMLEncoder{}},
+}
+
+// Encode writes the error response with the Encoder which matches the Accept header best.
+func (NegotiatingEncoder) Encode(w http.ResponseWriter, r *http.Request, resp *ErrorResponse) error {
+	w.Header().Add("Vary", "Accept")
+	return negotiate(r.Header.Get("Accept")).Encode(w, r, resp)
+}
+
+func negotiate(accept string) Encoder {
+	var best Encoder = JSONEncoder{}
+	bestQuality := 0.0
+	bestSpecificity := -1
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaRange, quality := parseQuality(mediaRange)
+		if quality <= 0 {
+			continue
+		}
+		for _, mt := range mediaTypes {
+			specificity := match(mediaRange, mt.mediaType)
+			if specificity < 0 {
+				continue
+			}
+			if quality > bestQuality ||
+				(quality == bestQuality && specificity > bestSpecificity) {
+				best = mt.encoder
+				bestQuality = quality
+				bestSpecificity = specificity
+			}
+		}
+	}
+	return best
+}
+
+// acceptedLanguages returns the language tags of the Accept-Language
+// header ordered by their quality, excluding the wildcard.
+func acceptedLanguages(acceptLanguage string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+	var languages []language
+	for _, s := range strings.Split(acceptLanguage, ",") {
+		tag, quality := parseQuality(s)
+		if tag == "" || tag == "*" || quality <= 0 {
+			continue
+		}
+		languages = append(languages, language{tag, quality})
+	}
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+	tags := make([]string, len(languages))
+	for i, l := range languages {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// parseQuality parses a value of an Accept or Accept-Language
+// header into its lower case value and its quality.
+func parseQuality(s string) (string, float64) {
+	parts := strings.Split(s, ";")
+	value := strings.ToLower(strings.TrimSpace(parts[0]))
+	quality := 1.0
+	for _, param := range parts[1:] {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || strings.ToLower(strings.TrimSpace(key)) != "q" {
+			continue
+		}
+		if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			quality = q
+		}
+	}
+	return value, quality
+}
+
+// match returns how specifically the media range matches the media type
+// (2 = exact, 1 = subtype wildcard, 0 = full wildcard) or -1 if it doesn't match.
+func match(mediaRange, mediaType string) int {
+	switch {
+	case mediaRange == mediaType:
+		return 2
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*") &&
+		strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*")):
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,136 @@
+package httpfault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_negotiate(t *testing.T) {
+	testCases := []struct {
+		accept   string
+		expected Encoder
+	}{
+		{"", JSONEncoder{}},
+		{"*/*", JSONEncoder{}},
+		{"application/xml", JSONEncoder{}},
+		{"application/json", JSONEncoder{}},
+		{"application/problem+json", ProblemEncoder{}},
+		{"application/json;q=0.5, application/problem+json", ProblemEncoder{}},
+		{"text/plain", TextEncoder{}},
+		{"text/*", TextEncoder{}},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", HTMLEncoder{}},
+		{"text/html;q=0, */*", JSONEncoder{}},
+	}
+
+	for _, tc := range testCases {
+		actual := negotiate(tc.accept)
+		if actual != tc.expected {
+			t.Errorf("Accept: %s"+expectedFormat, tc.accept, tc.expected, actual)
+		}
+	}
+}
+
+func Test_WriteError_WithAcceptHeader(t *testing.T) {
+	testCases := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{
+			"application/json",
+			"application/json; charset=utf-8",
+			`{"message":"Bad Request","errors":[{"code":"INVALID_NAME","message":"'\u003cb\u003e' is not a valid name."}]}`,
+		},
+		{
+			"application/problem+json",
+			"application/problem+json; charset=utf-8",
+			`{"type":"about:blank","title":"Bad Request","status":400,"errors":[{"code":"INVALID_NAME","message":"'\u003cb\u003e' is not a valid name."}]}`,
+		},
+		{
+			"text/plain",
+			"text/plain; charset=utf-8",
+			"Bad Request\n\n- '<b>' is not a valid name. (INVALID_NAME)",
+		},
+		{
+			"text/html",
+			"text/html; charset=utf-8",
+			"<li>&#39;&lt;b&gt;&#39; is not a valid name.</li>",
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", tc.accept)
+
+		WriteError(w, r, fault.User("INVALID_NAME", "'<b>' is not a valid name."))
+
+		if actual := w.Header().Get("Content-Type"); actual != tc.contentType {
+			t.Errorf(expectedFormat, tc.contentType, actual)
+		}
+		if actual := w.Body.String(); !strings.Contains(actual, tc.body) {
+			t.Errorf(expectedFormat, tc.body, actual)
+		}
+	}
+}
+
+func Test_acceptedLanguages(t *testing.T) {
+	actual := acceptedLanguages("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.95, *;q=0.5")
+
+	expected := []string{"fr-ch", "de", "fr", "en"}
+	if strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_WriteError_WithAcceptLanguage(t *testing.T) {
+	rs := &Responder{
+		Catalog: fault.Catalog{
+			"en": {"INVALID_NAME": "Invalid name."},
+			"de": {"INVALID_NAME": "Ungültiger Name."},
+		},
+		DefaultLanguage: "en",
+	}
+
+	testCases := []struct {
+		acceptLanguage string
+		expected       string
+	}{
+		{"de-CH, en;q=0.5", "Ungültiger Name."},
+		{"fr", "Invalid name."},
+		{"", "Invalid name."},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Language", tc.acceptLanguage)
+
+		rs.WriteError(w, r, fault.User("INVALID_NAME", "invalid name"))
+
+		if actual := w.Body.String(); !strings.Contains(actual, tc.expected) {
+			t.Errorf(expectedFormat, tc.expected, actual)
+		}
+	}
+}
+
+func Test_WriteError_WithAcceptLanguageAndParams(t *testing.T) {
+	rs := &Responder{
+		Catalog: fault.Catalog{
+			"de": {"INVALID_ITEMS": "{count, plural, one {# Eintrag ist} other {# Einträge sind}} ungültig."},
+		},
+	}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "de")
+
+	rs.WriteError(w, r, fault.UserParams("INVALID_ITEMS", "{count} items are invalid.", fault.Params{"count": 1}))
+
+	if actual := w.Body.String(); !strings.Contains(actual, "1 Eintrag ist ungültig.") {
+		t.Errorf(expectedFormat, "1 Eintrag ist ungültig.", actual)
+	}
+}
@@ -0,0 +1,105 @@
+package httpfault
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// OpenAPIComponents returns the OpenAPI components which describe the error responses
+// of the JSONEncoder, including all error codes of the fault code registry.
+//
+// The result can be merged into the components object of an OpenAPI document
+// or marshalled to JSON directly, so that the API documentation always lists the
+// codes which the application actually returns:
+//
+//	{
+//	   "schemas": {
+//	      "ErrorCode": { "type": "string", "enum": [...], ... },
+//	      "ErrorEntry": { ... },
+//	      "ErrorResponse": { ... }
+//	   }
+//	}
+func OpenAPIComponents() map[string]interface{} {
+	infos := fault.RegisteredCodes()
+	codes := make([]string, len(infos))
+	descriptions := make([]string, len(infos))
+	sb := strings.Builder{}
+	sb.WriteString("A machine readable error code.")
+	if len(infos) > 0 {
+		sb.WriteString("\n")
+	}
+	for i, info := range infos {
+		codes[i] = info.Code.String()
+		descriptions[i] = info.Description
+		sb.WriteString(fmt.Sprintf("\n- `%s`: %s", info.Code, info.Description))
+	}
+
+	errorCode := map[string]interface{}{
+		"type":        "string",
+		"description": sb.String(),
+	}
+	if len(codes) > 0 {
+		errorCode["enum"] = codes
+		errorCode["x-enum-descriptions"] = descriptions
+	}
+
+	return map[string]interface{}{
+		"schemas": map[string]interface{}{
+			"ErrorCode": errorCode,
+			"ErrorEntry": map[string]interface{}{
+				"type":     "object",
+				"required": []string{"code", "message"},
+				"properties": map[string]interface{}{
+					"code": map[string]interface{}{
+						"$ref": "#/components/schemas/ErrorCode",
+					},
+					"message": map[string]interface{}{
+						"type":        "string",
+						"description": "A human readable error message.",
+					},
+				},
+			},
+			"ErrorResponse": map[string]interface{}{
+				"type":     "object",
+				"required": []string{"message"},
+				"properties": map[string]interface{}{
+					"message": map[string]interface{}{
+						"type":        "string",
+						"description": "A short description of the error.",
+					},
+					"errors": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"$ref": "#/components/schemas/ErrorEntry",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// CodesHandler returns a http.Handler which serves all error codes of the fault code
+// registry (e.g. on a /errors endpoint), so that clients can discover them at runtime.
+//
+// The codes are rendered as Markdown if the Accept header asks for text/markdown
+// and as JSON otherwise.
+func CodesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+		var err error
+		if strings.Contains(r.Header.Get("Accept"), "text/markdown") {
+			w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+			err = fault.ExportCodesMarkdown(w)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			err = fault.ExportCodesJSON(w)
+		}
+		if err != nil {
+			DefaultResponder.log(r, fault.SystemWrap(err, "failed to export error codes"))
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package httpfault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_OpenAPIComponents_WithRegisteredCodes(t *testing.T) {
+	fault.RegisterCode(
+		fault.CodeInfo{Code: "TEST_OPENAPI_NOT_FOUND", Description: "The resource doesn't exist.", Status: 404},
+		fault.CodeInfo{Code: "TEST_OPENAPI_INVALID", Description: "The input is invalid."},
+	)
+
+	data, err := json.Marshal(OpenAPIComponents())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := string(data)
+	for _, expected := range []string{
+		`"enum":["TEST_OPENAPI_INVALID","TEST_OPENAPI_NOT_FOUND"]`,
+		"- `TEST_OPENAPI_NOT_FOUND`: The resource doesn't exist.",
+		`"ErrorResponse":{`,
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf(expectedFormat, expected, actual)
+		}
+	}
+}
+
+func Test_CodesHandler(t *testing.T) {
+	fault.RegisterCode(fault.CodeInfo{Code: "TEST_SERVED_CODE", Description: "Test.", Status: 409})
+
+	tests := []struct {
+		accept      string
+		contentType string
+		expected    string
+	}{
+		{"", "application/json", `"code": "TEST_SERVED_CODE"`},
+		{"text/markdown", "text/markdown; charset=utf-8", "| `TEST_SERVED_CODE` | 409 | Test. |"},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/errors", nil)
+		r.Header.Set("Accept", test.accept)
+		w := httptest.NewRecorder()
+
+		CodesHandler().ServeHTTP(w, r)
+
+		if actual := w.Header().Get("Content-Type"); actual != test.contentType {
+			t.Errorf(expectedFormat, test.contentType, actual)
+		}
+		if actual := w.Body.String(); !strings.Contains(actual, test.expected) {
+			t.Errorf(expectedFormat, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package httpfault
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// StatsHandler returns a handler which writes a snapshot of the error statistics as JSON,
+// so that operators can inspect the error distribution of a live instance.
+// It is meant to be mounted on an internal debug route:
+//
+//	Example:
+//	   stats := fault.NewStats()
+//	   httpfault.DefaultResponder.Stats = stats
+//	   mux.Handle("/debug/faults", httpfault.StatsHandler(stats))
+func StatsHandler(stats *fault.Stats) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "no-store")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "   ")
+		_ = enc.Encode(stats.Snapshot())
+	})
+}
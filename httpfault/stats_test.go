@@ -0,0 +1,47 @@
+package httpfault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_StatsHandler(t *testing.T) {
+	stats := fault.NewStats()
+	stats.Record(fault.User("MISSING_NAME", "Please provide a name."))
+	stats.Record(fault.System("failed to connect").WithKind(fault.Unavailable))
+	w := httptest.NewRecorder()
+
+	StatsHandler(stats).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/faults", nil))
+
+	var snapshot fault.StatsSnapshot
+	if err := json.Unmarshal(w.Body.Bytes(), &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Total != 2 || snapshot.Codes["MISSING_NAME"] != 1 || snapshot.Kinds[fault.Unavailable] != 1 {
+		t.Errorf(expectedFormat, "2 errors", w.Body.String())
+	}
+	if actual := w.Header().Get("Content-Type"); actual != "application/json; charset=utf-8" {
+		t.Errorf(expectedFormat, "application/json; charset=utf-8", actual)
+	}
+}
+
+func Test_Responder_RecordsStats(t *testing.T) {
+	stats := fault.NewStats()
+	rs := &Responder{Stats: stats, Logger: func(r *http.Request, err error) {}}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	rs.WriteError(httptest.NewRecorder(), r, fault.User("MISSING_NAME", "Please provide a name."))
+	rs.WriteError(httptest.NewRecorder(), r, fault.System("failed to connect").WithKind(fault.Unavailable))
+	agg := &fault.Aggregate{}
+	agg.Add(1, fault.User("MISSING_NAME", "Please provide a name."))
+	rs.WriteBatch(httptest.NewRecorder(), r, IndexKeys(2), agg)
+
+	snapshot := stats.Snapshot()
+	if snapshot.Total != 3 || snapshot.Codes["MISSING_NAME"] != 2 || snapshot.Kinds[fault.Unavailable] != 1 {
+		t.Errorf(expectedFormat, "3 errors", stats.String())
+	}
+}
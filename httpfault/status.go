@@ -0,0 +1,90 @@
+package httpfault
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// StatusResolver decides the HTTP status code of an error response.
+type StatusResolver interface {
+	ResolveStatus(err error) int
+}
+
+// StatusResolverFunc is an adapter to allow the use of ordinary functions as a StatusResolver.
+type StatusResolverFunc func(err error) int
+
+// ResolveStatus calls f(err).
+func (f StatusResolverFunc) ResolveStatus(err error) int {
+	return f(err)
+}
+
+// StatusMapper is a StatusResolver which maps user error codes
+// and SystemError kinds to HTTP status codes.
+type StatusMapper struct {
+	// UserCodes maps user error codes to status codes.
+	// If a UserError contains multiple mapped codes then the first one wins.
+	// Mapped codes take precedence over the status of the fault code registry,
+	// which only applies if none of the codes is mapped.
+	UserCodes map[fault.Code]int
+
+	// Kinds maps kinds of a SystemError to status codes.
+	Kinds map[fault.Kind]int
+
+	// UserFallback is the status code of a UserError without a mapped code.
+	// Defaults to 400 Bad Request.
+	UserFallback int
+
+	// Fallback is the status code of any other error without a mapped kind.
+	// Defaults to 500 Internal Server Error.
+	Fallback int
+}
+
+// DefaultKinds maps the kinds of the fault package to their closest HTTP status codes.
+var DefaultKinds = map[fault.Kind]int{
+	fault.Internal:          http.StatusInternalServerError,
+	fault.Canceled:          499, // Client Closed Request
+	fault.Timeout:           http.StatusGatewayTimeout,
+	fault.Unavailable:       http.StatusServiceUnavailable,
+	fault.NotFound:          http.StatusNotFound,
+	fault.Conflict:          http.StatusConflict,
+	fault.PermissionDenied:  http.StatusForbidden,
+	fault.Unauthenticated:   http.StatusUnauthorized,
+	fault.ResourceExhausted: http.StatusTooManyRequests,
+	fault.InvalidArgument:   http.StatusBadRequest,
+	fault.Unimplemented:     http.StatusNotImplemented,
+}
+
+// DefaultStatusResolver is the StatusResolver used by a Responder without a StatusResolver.
+var DefaultStatusResolver StatusResolver = &StatusMapper{Kinds: DefaultKinds}
+
+// ResolveStatus returns the HTTP status code for the error.
+func (m *StatusMapper) ResolveStatus(err error) int {
+	var userErr *fault.UserError
+	if errors.As(err, &userErr) {
+		codes := userErr.Codes()
+		for _, code := range codes {
+			if status, ok := m.UserCodes[code]; ok {
+				return status
+			}
+		}
+		for _, code := range codes {
+			if info, ok := fault.LookupCode(code); ok && info.Status != 0 {
+				return info.Status
+			}
+		}
+		if m.UserFallback != 0 {
+			return m.UserFallback
+		}
+		return http.StatusBadRequest
+	}
+
+	if status, ok := m.Kinds[fault.KindOf(err)]; ok {
+		return status
+	}
+	if m.Fallback != 0 {
+		return m.Fallback
+	}
+	return http.StatusInternalServerError
+}
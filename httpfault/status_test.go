@@ -0,0 +1,75 @@
+package httpfault
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_StatusMapper_ResolveStatus(t *testing.T) {
+	mapper := &StatusMapper{
+		UserCodes: map[fault.Code]int{"USER_NOT_FOUND": http.StatusNotFound},
+		Kinds:     DefaultKinds,
+	}
+	userErr := fault.User("INVALID_ID", "Invalid ID.")
+	userErr.Add("USER_NOT_FOUND", "User not found.")
+
+	testCases := []struct {
+		err      error
+		expected int
+	}{
+		{fault.User("INVALID_ID", "Invalid ID."), http.StatusBadRequest},
+		{userErr, http.StatusNotFound},
+		{fault.SystemWrap(userErr, "f"), http.StatusNotFound},
+		{fault.System("c"), http.StatusInternalServerError},
+		{fault.System("c").WithKind(fault.Timeout), http.StatusGatewayTimeout},
+		{fault.SystemWrap(fault.System("c").WithKind(fault.NotFound), "f"), http.StatusNotFound},
+		{errors.New("foo bar"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		actual := mapper.ResolveStatus(tc.err)
+		if actual != tc.expected {
+			t.Errorf(expectedFormat, tc.expected, actual)
+		}
+	}
+}
+
+func Test_StatusMapper_ResolveStatus_WithFallbacks(t *testing.T) {
+	mapper := &StatusMapper{
+		UserFallback: http.StatusUnprocessableEntity,
+		Fallback:     http.StatusServiceUnavailable,
+	}
+
+	if actual := mapper.ResolveStatus(fault.User("X", "x")); actual != http.StatusUnprocessableEntity {
+		t.Errorf(expectedFormat, http.StatusUnprocessableEntity, actual)
+	}
+	if actual := mapper.ResolveStatus(errors.New("foo bar")); actual != http.StatusServiceUnavailable {
+		t.Errorf(expectedFormat, http.StatusServiceUnavailable, actual)
+	}
+}
+
+func Test_StatusMapper_ResolveStatus_WithRegisteredCode(t *testing.T) {
+	fault.RegisterCode(fault.CodeInfo{Code: "TEST_STATUS_GONE", Status: http.StatusGone})
+
+	actual := DefaultStatusResolver.ResolveStatus(fault.User("TEST_STATUS_GONE", "Gone."))
+
+	if actual != http.StatusGone {
+		t.Errorf(expectedFormat, http.StatusGone, actual)
+	}
+}
+
+func Test_StatusMapper_ResolveStatus_WithMappedAndRegisteredCodes(t *testing.T) {
+	fault.RegisterCode(fault.CodeInfo{Code: "TEST_STATUS_REGISTERED_ONLY", Status: http.StatusGone})
+	m := &StatusMapper{UserCodes: map[fault.Code]int{"TEST_STATUS_MAPPED": http.StatusConflict}}
+
+	err := fault.User("TEST_STATUS_REGISTERED_ONLY", "Gone.")
+	err.Add("TEST_STATUS_MAPPED", "Conflict.")
+	actual := m.ResolveStatus(err)
+
+	if actual != http.StatusConflict {
+		t.Errorf(expectedFormat, http.StatusConflict, actual)
+	}
+}
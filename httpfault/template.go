@@ -0,0 +1,55 @@
+package httpfault
+
+import (
+	"errors"
+	"html/template"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+// TemplateFuncs returns html/template functions which display the user errors
+// of a server-rendered form next to their input fields (see fault.UserField):
+//
+//   - userErrors returns the codes and messages of all user errors of an error
+//   - fieldError returns the first message which refers to an input field
+//   - hasError reports whether an error contains a user error with the given code
+//
+// All functions accept any error, including nil and errors which don't contain a UserError.
+//
+//	Example:
+//	   tmpl := template.Must(template.New("form").Funcs(httpfault.TemplateFuncs()).Parse(`
+//	      <input name="email" value="{{.Email}}">
+//	      {{with fieldError "email" .Err}}<p class="error">{{.}}</p>{{end}}
+//	      {{if hasError "TERMS_NOT_ACCEPTED" .Err}}<p>Please accept the terms.</p>{{end}}
+//	   `))
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"userErrors": templateUserErrors,
+		"fieldError": templateFieldError,
+		"hasError":   templateHasError,
+	}
+}
+
+func templateUserErrors(err error) []ErrorEntry {
+	var userErr *fault.UserError
+	if !errors.As(err, &userErr) {
+		return nil
+	}
+	return newUserErrorResponse(0, userErr).Errors
+}
+
+func templateFieldError(field string, err error) string {
+	var userErr *fault.UserError
+	if !errors.As(err, &userErr) {
+		return ""
+	}
+	if msgs := userErr.FieldErrors(field); len(msgs) > 0 {
+		return msgs[0]
+	}
+	return ""
+}
+
+func templateHasError(code string, err error) bool {
+	var userErr *fault.UserError
+	return errors.As(err, &userErr) && userErr.HasCode(fault.Code(code))
+}
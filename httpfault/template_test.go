@@ -0,0 +1,44 @@
+package httpfault
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+const formTemplate = `{{range userErrors .Err}}[{{.Code}}: {{.Message}}]{{end}}` +
+	`|{{fieldError "email" .Err}}` +
+	`|{{if hasError "TERMS_NOT_ACCEPTED" .Err}}terms{{end}}`
+
+func renderForm(t *testing.T, err error) string {
+	tmpl := template.Must(template.New("form").Funcs(TemplateFuncs()).Parse(formTemplate))
+	sb := &strings.Builder{}
+	if execErr := tmpl.Execute(sb, struct{ Err error }{err}); execErr != nil {
+		t.Fatal(execErr)
+	}
+	return sb.String()
+}
+
+func Test_TemplateFuncs_WithUserError(t *testing.T) {
+	err := fault.UserField("email", "INVALID_EMAIL", "'<b>' is not a valid email address.")
+	err.Add("TERMS_NOT_ACCEPTED", "Please accept the terms.")
+
+	actual := renderForm(t, fault.SystemWrap(err, "failed to sign up"))
+
+	expected := "[INVALID_EMAIL: &#39;&lt;b&gt;&#39; is not a valid email address.]" +
+		"[TERMS_NOT_ACCEPTED: Please accept the terms.]" +
+		"|&#39;&lt;b&gt;&#39; is not a valid email address.|terms"
+	if actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_TemplateFuncs_WithoutUserError(t *testing.T) {
+	for _, err := range []error{nil, fault.System("boom")} {
+		if actual := renderForm(t, err); actual != "||" {
+			t.Errorf(expectedFormat, "||", actual)
+		}
+	}
+}
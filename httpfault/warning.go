@@ -0,0 +1,90 @@
+package httpfault
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+var warnTextEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", " ", "\n", " ")
+
+// AddWarningHeaders adds a Warning header with the miscellaneous persistent
+// warning code 299 for each warning (e.g. Warning: 299 - "The parameter sort
+// is deprecated. (DEPRECATED_PARAMETER)"), as done by the Kubernetes API server.
+func AddWarningHeaders(h http.Header, warnings []fault.Warning) {
+	for _, w := range warnings {
+		h.Add("Warning", `299 - "`+warnTextEscaper.Replace(w.String())+`"`)
+	}
+}
+
+// Warnings is a middleware which attaches the warnings of a request to its response.
+//
+// It adds a fault.Collector to the request context (unless the context already
+// carries one) and writes the collected warnings as Warning headers before the
+// response headers are sent.
+//
+//	Example:
+//	   fault.FromContext(r.Context()).AddWarning("DEPRECATED_PARAMETER", "The parameter sort is deprecated.")
+func Warnings(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := fault.FromContext(r.Context())
+		if c == nil {
+			c = &fault.Collector{}
+			r = r.WithContext(fault.NewContext(r.Context(), c))
+		}
+		ww := &warningWriter{ResponseWriter: w, collector: c}
+		next.ServeHTTP(ww, r)
+		ww.flushWarnings()
+	})
+}
+
+type warningWriter struct {
+	http.ResponseWriter
+	collector   *fault.Collector
+	wroteHeader bool
+}
+
+func (w *warningWriter) flushWarnings() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	AddWarningHeaders(w.Header(), w.collector.Warnings())
+}
+
+func (w *warningWriter) WriteHeader(status int) {
+	w.flushWarnings()
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *warningWriter) Write(b []byte) (int, error) {
+	w.flushWarnings()
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher, so that streaming handlers (e.g. server-sent events)
+// keep working behind the middleware. The warnings are written before the first flush.
+func (w *warningWriter) Flush() {
+	w.flushWarnings()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker, so that websocket handlers keep working behind the middleware.
+func (w *warningWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("httpfault: %T doesn't implement http.Hijacker", w.ResponseWriter)
+	}
+	return h.Hijack()
+}
+
+// Unwrap returns the original http.ResponseWriter for http.ResponseController.
+func (w *warningWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
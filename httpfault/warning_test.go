@@ -0,0 +1,103 @@
+package httpfault
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dusted-go/fault/fault"
+)
+
+func Test_Warnings(t *testing.T) {
+	handler := Warnings(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := fault.FromContext(r.Context())
+		c.AddWarning("DEPRECATED_PARAMETER", `The parameter "sort" is deprecated.`)
+		c.AddWarning("PARTIAL_RESULT", "Some sources didn't respond.")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	expected := []string{
+		`299 - "The parameter \"sort\" is deprecated. (DEPRECATED_PARAMETER)"`,
+		`299 - "Some sources didn't respond. (PARTIAL_RESULT)"`,
+	}
+	actual := w.Header().Values("Warning")
+	if len(actual) != 2 || actual[0] != expected[0] || actual[1] != expected[1] {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+	if w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Errorf(expectedFormat, "200 ok", w.Body.String())
+	}
+}
+
+func Test_Warnings_WithoutWrite(t *testing.T) {
+	handler := Warnings(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault.FromContext(r.Context()).AddWarning("A", "a")
+	}))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if actual := w.Header().Get("Warning"); actual != `299 - "a (A)"` {
+		t.Errorf(expectedFormat, `299 - "a (A)"`, actual)
+	}
+}
+
+type hijackableWriter struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (w *hijackableWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+	return nil, nil, nil
+}
+
+func Test_Warnings_WithFlush(t *testing.T) {
+	handler := Warnings(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault.FromContext(r.Context()).AddWarning("A", "a")
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("expected the ResponseWriter to implement http.Flusher")
+		}
+		f.Flush()
+	}))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !w.Flushed {
+		t.Errorf(expectedFormat, true, w.Flushed)
+	}
+	if actual := w.Header().Get("Warning"); actual != `299 - "a (A)"` {
+		t.Errorf(expectedFormat, `299 - "a (A)"`, actual)
+	}
+}
+
+func Test_Warnings_WithHijack(t *testing.T) {
+	var err error
+	handler := Warnings(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("expected the ResponseWriter to implement http.Hijacker")
+		}
+		_, _, err = h.Hijack()
+	}))
+	w := &hijackableWriter{ResponseRecorder: httptest.NewRecorder()}
+
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if err != nil || !w.hijacked {
+		t.Errorf(expectedFormat, true, w.hijacked)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if err == nil {
+		t.Error("expected an error when the ResponseWriter can't be hijacked")
+	}
+}
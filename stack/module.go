@@ -0,0 +1,93 @@
+package stack
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// module is a dependency of the running binary.
+type module struct {
+	path    string
+	version string
+}
+
+var (
+	modulesOnce sync.Once
+	modules     []module
+)
+
+// loadModules returns the dependencies of the running binary, ordered by the
+// length of their path, so that the most specific module matches first.
+func loadModules() []module {
+	modulesOnce.Do(func() {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		modules = dependencies(info)
+	})
+	return modules
+}
+
+func dependencies(info *debug.BuildInfo) []module {
+	var deps []module
+	for _, dep := range info.Deps {
+		version := dep.Version
+		if dep.Replace != nil {
+			version = dep.Replace.Version
+		}
+		if version == "" || version == "(devel)" {
+			continue
+		}
+		deps = append(deps, module{path: dep.Path, version: version})
+	}
+	sort.Slice(deps, func(i, j int) bool {
+		return len(deps[i].path) > len(deps[j].path)
+	})
+	return deps
+}
+
+// packagePath returns the import path of the package of a raw runtime symbol.
+// The runtime escapes dots and other special characters in the last element of
+// the import path (e.g. gopkg.in/yaml%2ev3), which get unescaped after the package
+// has been split off from the function name.
+func packagePath(function string) string {
+	pkg := function
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		pkg = function[:slash+1+dot]
+	}
+	if unescaped, err := url.PathUnescape(pkg); err == nil {
+		return unescaped
+	}
+	return pkg
+}
+
+// Location returns the file and line of the frame. Frames of dependencies are
+// annotated with the version of their module, so that it can be told which version
+// of a dependency produced a frame.
+//
+//	Example:
+//	   github.com/foo/bar@v1.4.2/client.go:88
+//
+// Frames of the main module and the standard library keep their file path.
+func Location(f runtime.Frame) string {
+	return location(f, loadModules())
+}
+
+func location(f runtime.Frame, deps []module) string {
+	pkg := packagePath(f.Function)
+	for _, m := range deps {
+		if pkg != m.path && !strings.HasPrefix(pkg, m.path+"/") {
+			continue
+		}
+		return fmt.Sprintf("%s@%s%s/%s:%d", m.path, m.version, pkg[len(m.path):], filepath.Base(f.File), f.Line)
+	}
+	return fmt.Sprintf("%s:%d", f.File, f.Line)
+}
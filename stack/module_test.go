@@ -0,0 +1,59 @@
+package stack
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+)
+
+func Test_location(t *testing.T) {
+	deps := dependencies(&debug.BuildInfo{Deps: []*debug.Module{
+		{Path: "github.com/foo/bar", Version: "v1.4.2"},
+		{Path: "github.com/foo/bar/v2", Version: "v2.0.1"},
+		{Path: "github.com/foo/baz", Version: "v0.1.0", Replace: &debug.Module{Path: "../baz"}},
+		{Path: "github.com/foo/qux", Version: "v0.1.0", Replace: &debug.Module{Path: "github.com/fork/qux", Version: "v0.1.1"}},
+		{Path: "gopkg.in/yaml.v3", Version: "v3.0.1"},
+	}})
+	testCases := []struct {
+		frame    runtime.Frame
+		expected string
+	}{
+		{
+			runtime.Frame{Function: "github.com/foo/bar.(*Client).Do", File: "/go/pkg/mod/github.com/foo/bar@v1.4.2/client.go", Line: 88},
+			"github.com/foo/bar@v1.4.2/client.go:88",
+		},
+		{
+			runtime.Frame{Function: "github.com/foo/bar/internal/wire.Read", File: "/vendor/github.com/foo/bar/internal/wire/read.go", Line: 7},
+			"github.com/foo/bar@v1.4.2/internal/wire/read.go:7",
+		},
+		{
+			runtime.Frame{Function: "github.com/foo/bar/v2.Open", File: "/src/open.go", Line: 3},
+			"github.com/foo/bar/v2@v2.0.1/open.go:3",
+		},
+		{
+			runtime.Frame{Function: "github.com/foo/baz.Run", File: "/src/baz/run.go", Line: 1},
+			"/src/baz/run.go:1",
+		},
+		{
+			runtime.Frame{Function: "github.com/foo/qux.Run", File: "/src/qux/run.go", Line: 2},
+			"github.com/foo/qux@v0.1.1/run.go:2",
+		},
+		{
+			runtime.Frame{Function: "gopkg.in/yaml%2ev3.(*decoder).unmarshal", File: "/go/pkg/mod/gopkg.in/yaml.v3@v3.0.1/decode.go", Line: 470},
+			"gopkg.in/yaml.v3@v3.0.1/decode.go:470",
+		},
+		{
+			runtime.Frame{Function: "net/http.(*Server).Serve", File: "/usr/local/go/src/net/http/server.go", Line: 3285},
+			"/usr/local/go/src/net/http/server.go:3285",
+		},
+		{
+			runtime.Frame{Function: "main.main", File: "/app/main.go", Line: 12},
+			"/app/main.go:12",
+		},
+	}
+	for _, tc := range testCases {
+		if actual := location(tc.frame, deps); actual != tc.expected {
+			t.Errorf(expectedFormat, tc.expected, actual)
+		}
+	}
+}
@@ -2,34 +2,296 @@ package stack
 
 import (
 	"fmt"
+	"hash/fnv"
+	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 )
 
+// closureSuffix matches the suffixes which the Go runtime appends to the
+// name of anonymous functions, e.g. ".func1", ".func1.2" or ".gowrap1".
+var closureSuffix = regexp.MustCompile(`(\.(func|gowrap|deferwrap)\d+(\.\d+)*)+$`)
+
 type Trace []uintptr
 
-func (t *Trace) String() string {
-	s := strings.Builder{}
+// Frames returns the resolved frames of the trace, excluding the
+// frames which belong to the stack and fault packages themselves.
+// A nil trace has no frames.
+func (t *Trace) Frames() []runtime.Frame {
+	if t == nil || len(*t) == 0 {
+		return nil
+	}
+	if frames, ok := t.syntheticFrames(); ok {
+		return frames
+	}
+	var result []runtime.Frame
 	frames := runtime.CallersFrames(*t)
 	for {
 		f, more := frames.Next()
-		if strings.HasSuffix(f.File, "stack/stack.go") ||
-			strings.HasSuffix(f.File, "fault/fault.go") {
-			continue
+		if !isInternal(f) {
+			result = append(result, f)
+		}
+		if !more {
+			return result
+		}
+	}
+}
+
+// Caller returns the most recent frame of the trace which doesn't belong to the
+// stack and fault packages themselves, which is the location where the trace has
+// been captured. Unlike Frames it only resolves the frames up to that one.
+func (t *Trace) Caller() (runtime.Frame, bool) {
+	if t == nil || len(*t) == 0 {
+		return runtime.Frame{}, false
+	}
+	if frames, ok := t.syntheticFrames(); ok {
+		return frames[0], true
+	}
+	frames := runtime.CallersFrames(*t)
+	for {
+		f, more := frames.Next()
+		if !isInternal(f) {
+			return f, true
 		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+// isInternal reports whether the frame belongs to the stack or fault package,
+// not counting their tests.
+func isInternal(f runtime.Frame) bool {
+	if strings.HasSuffix(f.File, "_test.go") {
+		return false
+	}
+	return strings.HasPrefix(f.Function, "github.com/dusted-go/fault/stack.") ||
+		strings.HasPrefix(f.Function, "github.com/dusted-go/fault/fault.")
+}
+
+// String returns the frames of the trace, starting with the most recent call.
+// The frames of dependencies are annotated with their module version (see Location).
+func (t *Trace) String() string {
+	s := strings.Builder{}
+	for _, f := range t.Frames() {
 		s.WriteString(
-			fmt.Sprintf("\nat %s:%d\n   --> %s", f.File, f.Line, f.Function),
+			fmt.Sprintf("\nat %s\n   --> %s", Location(f), FuncName(f.Function)),
 		)
-		if !more {
-			return s.String()
+	}
+	return s.String()
+}
+
+// RuntimeString returns the trace in the same layout as the Go runtime
+// prints the stack of a goroutine when it panics.
+//
+//	Example:
+//	   goroutine 1 [running]:
+//	   main.main()
+//	   	/app/main.go:12
+//
+// This is the format which Google Cloud Error Reporting expects in order
+// to parse and group errors which have been written to Cloud Logging.
+func (t *Trace) RuntimeString() string {
+	s := strings.Builder{}
+	s.WriteString("goroutine 1 [running]:")
+	for _, f := range t.Frames() {
+		s.WriteString(
+			fmt.Sprintf("\n%s()\n\t%s:%d", f.Function, f.File, f.Line),
+		)
+	}
+	return s.String()
+}
+
+// OTelStackTrace returns the trace formatted as the exception.stacktrace
+// attribute of the OpenTelemetry exception semantic conventions, which
+// is the natural representation of a stack trace in the Go runtime.
+func (t *Trace) OTelStackTrace() string {
+	return t.RuntimeString()
+}
+
+// Equal reports whether both traces resolve to the same
+// sequence of functions, files and line numbers. A nil trace only equals nil.
+func (t *Trace) Equal(other *Trace) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return equal(t.identities(true), other.identities(true))
+}
+
+// EqualIgnoreLines reports whether both traces resolve to the same
+// sequence of functions and files, regardless of the line numbers.
+// A nil trace only equals nil.
+func (t *Trace) EqualIgnoreLines(other *Trace) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return equal(t.identities(false), other.identities(false))
+}
+
+// Hash returns a hash of the functions, files and line numbers of the trace.
+// Two traces which are Equal will always produce the same hash.
+// The hash of a nil trace is 0.
+func (t *Trace) Hash() uint64 {
+	if t == nil {
+		return 0
+	}
+	return hash(t.identities(true))
+}
+
+// HashIgnoreLines returns a hash of the functions and files of the trace.
+// The hash remains stable when only line numbers change.
+// The hash of a nil trace is 0.
+func (t *Trace) HashIgnoreLines() uint64 {
+	if t == nil {
+		return 0
+	}
+	return hash(t.identities(false))
+}
+
+// HashFunctions returns a hash of the function names of the trace. The hash
+// remains stable when line numbers change or files get moved or renamed.
+// The hash of a nil trace is 0.
+func (t *Trace) HashFunctions() uint64 {
+	if t == nil {
+		return 0
+	}
+	frames := t.Frames()
+	names := make([]string, len(frames))
+	for i, f := range frames {
+		names[i] = f.Function
+	}
+	return hash(names)
+}
+
+func (t *Trace) identities(includeLines bool) []string {
+	frames := t.Frames()
+	ids := make([]string, len(frames))
+	for i, f := range frames {
+		if includeLines {
+			ids[i] = fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+		} else {
+			ids[i] = fmt.Sprintf("%s %s", f.Function, f.File)
 		}
 	}
+	return ids
 }
 
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hash(ids []string) uint64 {
+	h := fnv.New64a()
+	for _, id := range ids {
+		_, _ = h.Write([]byte(id))
+		_, _ = h.Write([]byte{'\n'})
+	}
+	return h.Sum64()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The text representation is the same as the one returned by String().
+func (t *Trace) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// FuncName turns a raw runtime symbol into a more readable function name.
+// It strips the package path (including any vendor prefix), the type
+// parameter placeholders of generic functions and the closure suffixes.
+//
+//	Example:
+//	   github.com/acme/app/vendor/github.com/foo/store.(*Repo[...]).Get.func1
+//
+// becomes:
+//
+//	store.(*Repo).Get
+func FuncName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.ReplaceAll(name, "[...]", "")
+	return closureSuffix.ReplaceAllString(name, "")
+}
+
+// CapturePanic captures the stack trace of a panicking goroutine.
+// It must be called (directly or indirectly) from a deferred function
+// which recovered the panic, in which case the returned trace starts
+// at the location where the panic occurred rather than at the location
+// where it got recovered. Otherwise it behaves the same as Capture.
+func CapturePanic() *Trace {
+	t := callers(3, panicDepth)
+	for i, pc := range t {
+		if fn := runtime.FuncForPC(pc - 1); fn == nil || fn.Name() != "runtime.gopanic" {
+			continue
+		}
+		// Skip the runtime functions which raised the panic on behalf of the program,
+		// e.g. runtime.panicmem or runtime.sigpanic for a nil pointer dereference:
+		for i++; i < len(t); i++ {
+			if fn := runtime.FuncForPC(t[i] - 1); fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+				break
+			}
+		}
+		t = t[i:]
+		break
+	}
+	return &t
+}
+
+// DefaultDepth is the maximum number of frames which are captured by Capture.
+const DefaultDepth = 32
+
+// Capture captures the stack trace of the calling goroutine,
+// starting with the caller of the function which calls Capture.
 func Capture() *Trace {
-	const depth = 32
-	var pcs [depth]uintptr
-	n := runtime.Callers(3, pcs[:])
-	var t Trace = pcs[0:n]
+	return captureDepth(DefaultDepth)
+}
+
+// CaptureDepth captures the stack trace of the calling goroutine like Capture,
+// but with the given maximum number of frames.
+func CaptureDepth(depth int) *Trace {
+	if depth <= 0 {
+		depth = DefaultDepth
+	}
+	return captureDepth(depth)
+}
+
+func captureDepth(depth int) *Trace {
+	t := callers(4, depth)
 	return &t
 }
+
+// panicDepth is the maximum number of frames which are captured by CapturePanic.
+const panicDepth = 64
+
+// pcPool holds the buffers into which the program counters get captured, so that
+// a trace only allocates the frames which have actually been captured.
+var pcPool = sync.Pool{
+	New: func() interface{} {
+		pcs := make([]uintptr, panicDepth)
+		return &pcs
+	},
+}
+
+// callers captures at most depth program counters of the calling goroutine,
+// skipping the given number of frames as counted by runtime.Callers from
+// the caller of callers.
+func callers(skip int, depth int) Trace {
+	buf := pcPool.Get().(*[]uintptr)
+	if len(*buf) < depth {
+		*buf = make([]uintptr, depth)
+	}
+	n := runtime.Callers(skip+1, (*buf)[:depth])
+	t := make(Trace, n)
+	copy(t, *buf)
+	pcPool.Put(buf)
+	return t
+}
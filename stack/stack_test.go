@@ -0,0 +1,161 @@
+package stack
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const (
+	expectedFormat = "\n\nexpected:\n%s\n\nactual:\n%s\n\n"
+)
+
+func Test_funcName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"main.main", "main.main"},
+		{"github.com/acme/app/store.(*Repo).Get", "store.(*Repo).Get"},
+		{"github.com/acme/app/store.(*Repo[...]).Get", "store.(*Repo).Get"},
+		{"github.com/acme/app/store.Map[...]", "store.Map"},
+		{"github.com/acme/app/store.(*Repo).Get.func1", "store.(*Repo).Get"},
+		{"github.com/acme/app/store.(*Repo).Get.func1.2", "store.(*Repo).Get"},
+		{"github.com/acme/app/store.(*Repo).Get.func1.func3", "store.(*Repo).Get"},
+		{"github.com/acme/app/store.Run.gowrap1", "store.Run"},
+		{"github.com/acme/app/vendor/github.com/foo/store.(*Repo).Get", "store.(*Repo).Get"},
+		{"github.com/acme/app/store.(*Repo).funcs", "store.(*Repo).funcs"},
+	}
+
+	for _, tc := range testCases {
+		actual := FuncName(tc.name)
+		if actual != tc.expected {
+			t.Errorf(expectedFormat, tc.expected, actual)
+		}
+	}
+}
+
+func Test_MarshalText_ReturnsSameAsString(t *testing.T) {
+	trace := Capture()
+
+	actual, err := trace.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := trace.String()
+	if string(actual) != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_RuntimeString(t *testing.T) {
+	trace := Capture()
+
+	actual := trace.RuntimeString()
+
+	expected := "goroutine 1 [running]:\ntesting.tRunner()\n\t"
+	if !strings.HasPrefix(actual, expected) {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+// capture mimics the fault package, which calls Capture on behalf of its caller.
+func capture() *Trace {
+	return Capture()
+}
+
+func Test_Equal_WithSameCallSite(t *testing.T) {
+	var traces []*Trace
+	for i := 0; i < 2; i++ {
+		traces = append(traces, capture())
+	}
+
+	if !traces[0].Equal(traces[1]) {
+		t.Error("Traces captured at the same call site were expected to be equal.")
+	}
+	if traces[0].Hash() != traces[1].Hash() {
+		t.Error("Traces captured at the same call site were expected to have the same hash.")
+	}
+}
+
+func Test_Equal_WithDifferentLines(t *testing.T) {
+	t1 := capture()
+	t2 := capture()
+
+	if t1.Equal(t2) {
+		t.Error("Traces captured at different lines were not expected to be equal.")
+	}
+	if t1.Hash() == t2.Hash() {
+		t.Error("Traces captured at different lines were not expected to have the same hash.")
+	}
+	if !t1.EqualIgnoreLines(t2) {
+		t.Error("Traces captured in the same function were expected to be equal when ignoring lines.")
+	}
+	if t1.HashIgnoreLines() != t2.HashIgnoreLines() {
+		t.Error("Traces captured in the same function were expected to have the same hash when ignoring lines.")
+	}
+	if t1.HashFunctions() != t2.HashFunctions() {
+		t.Error("Traces captured in the same function were expected to have the same hash of function names.")
+	}
+	if t1.HashFunctions() == t1.HashIgnoreLines() {
+		t.Error("The hash of function names was not expected to include the files.")
+	}
+}
+
+// captureDepthOf mimics the fault package, which calls CaptureDepth on behalf of its caller.
+func captureDepthOf(depth int) *Trace {
+	return CaptureDepth(depth)
+}
+
+func Test_CaptureDepth(t *testing.T) {
+	trace := captureDepthOf(1)
+
+	frames := trace.Frames()
+	if len(frames) != 1 || !strings.HasSuffix(frames[0].Function, "Test_CaptureDepth") {
+		t.Errorf(expectedFormat, "Test_CaptureDepth", trace.String())
+	}
+}
+
+func Test_CaptureDepth_AllocatesOnlyCapturedFrames(t *testing.T) {
+	trace := captureDepthOf(DefaultDepth)
+
+	if len(*trace) == 0 || len(*trace) != cap(*trace) {
+		t.Errorf(expectedFormat, fmt.Sprint(len(*trace)), fmt.Sprint(cap(*trace)))
+	}
+}
+
+func Test_Caller(t *testing.T) {
+	trace := capture()
+
+	caller, ok := trace.Caller()
+	if !ok || caller != trace.Frames()[0] {
+		t.Errorf(expectedFormat, fmt.Sprint(trace.Frames()[0]), fmt.Sprint(caller))
+	}
+	if _, ok := (&Trace{}).Caller(); ok {
+		t.Error("An empty trace was not expected to have a caller.")
+	}
+}
+
+func Test_NilTrace(t *testing.T) {
+	var trace *Trace
+
+	if frames := trace.Frames(); frames != nil {
+		t.Errorf(expectedFormat, "nil", fmt.Sprint(frames))
+	}
+	if _, ok := trace.Caller(); ok {
+		t.Error("A nil trace was not expected to have a caller.")
+	}
+	if !trace.Equal(nil) || !trace.EqualIgnoreLines(nil) {
+		t.Error("A nil trace was expected to equal nil.")
+	}
+	if trace.Equal(&Trace{}) || (&Trace{}).Equal(trace) || trace.EqualIgnoreLines(capture()) {
+		t.Error("A nil trace was not expected to equal a non-nil trace.")
+	}
+	if hashes := fmt.Sprint(trace.Hash(), trace.HashIgnoreLines(), trace.HashFunctions()); hashes != "0 0 0" {
+		t.Errorf(expectedFormat, "0 0 0", hashes)
+	}
+	if s := trace.String(); s != "" {
+		t.Errorf(expectedFormat, "", s)
+	}
+}
@@ -0,0 +1,60 @@
+package stack
+
+import (
+	"runtime"
+	"sync"
+)
+
+type frameKey struct {
+	function string
+	file     string
+	line     int
+}
+
+// synthetic holds the frames of synthetic traces. Each distinct frame is assigned
+// a fake program counter, which is far below the address of any real function.
+var synthetic = struct {
+	sync.RWMutex
+	pcs    map[frameKey]uintptr
+	frames map[uintptr]runtime.Frame
+}{
+	pcs:    map[frameKey]uintptr{},
+	frames: map[uintptr]runtime.Frame{},
+}
+
+// Synthetic creates a trace which consists of the given frames rather than of
+// captured program counters. It allows tests to produce stable stack traces
+// which don't depend on the location of the test code.
+//
+//	Example:
+//	   t := stack.Synthetic(runtime.Frame{Function: "main.main", File: "main.go", Line: 12})
+func Synthetic(frames ...runtime.Frame) *Trace {
+	synthetic.Lock()
+	defer synthetic.Unlock()
+	t := make(Trace, len(frames))
+	for i, f := range frames {
+		key := frameKey{function: f.Function, file: f.File, line: f.Line}
+		pc, ok := synthetic.pcs[key]
+		if !ok {
+			pc = uintptr(len(synthetic.pcs) + 1)
+			synthetic.pcs[key] = pc
+			synthetic.frames[pc] = runtime.Frame{Function: f.Function, File: f.File, Line: f.Line}
+		}
+		t[i] = pc
+	}
+	return &t
+}
+
+// syntheticFrames returns the frames of a synthetic trace.
+func (t *Trace) syntheticFrames() ([]runtime.Frame, bool) {
+	synthetic.RLock()
+	defer synthetic.RUnlock()
+	if _, ok := synthetic.frames[(*t)[0]]; !ok {
+		return nil, false
+	}
+	frames := make([]runtime.Frame, len(*t))
+	for i, pc := range *t {
+		frames[i] = synthetic.frames[pc]
+	}
+	return frames, true
+}
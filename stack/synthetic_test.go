@@ -0,0 +1,30 @@
+package stack
+
+import (
+	"runtime"
+	"testing"
+)
+
+func Test_Synthetic_String(t *testing.T) {
+	trace := Synthetic(
+		runtime.Frame{Function: "github.com/acme/app/store.(*Repo).Get", File: "/app/store/repo.go", Line: 42},
+		runtime.Frame{Function: "main.main", File: "/app/main.go", Line: 12},
+	)
+
+	expected := "\nat /app/store/repo.go:42\n   --> store.(*Repo).Get\nat /app/main.go:12\n   --> main.main"
+	actual := trace.String()
+	if actual != expected {
+		t.Errorf(expectedFormat, expected, actual)
+	}
+}
+
+func Test_Synthetic_Equal(t *testing.T) {
+	frame := runtime.Frame{Function: "main.main", File: "/app/main.go", Line: 12}
+
+	if !Synthetic(frame).Equal(Synthetic(frame)) {
+		t.Error("Synthetic traces with the same frames were expected to be equal.")
+	}
+	if Synthetic(frame).Equal(capture()) {
+		t.Error("A synthetic trace was not expected to equal a captured trace.")
+	}
+}